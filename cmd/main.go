@@ -11,6 +11,7 @@ import (
 
 	"github.com/Chaika-Team/ChaikaGoods/internal/config"
 	"github.com/Chaika-Team/ChaikaGoods/internal/handler"
+	"github.com/Chaika-Team/ChaikaGoods/internal/repository/filesystem"
 	repo "github.com/Chaika-Team/ChaikaGoods/internal/repository/postgresql"
 	"github.com/Chaika-Team/ChaikaGoods/internal/service"
 
@@ -68,11 +69,18 @@ func main() {
 	defer pool.Close()
 	_ = level.Info(logger).Log("message", "Connection to the database is successful")
 
+	// Инициализация хранилища изображений
+	images, err := filesystem.NewBlobStore(cfg.Images.Dir, cfg.Images.BaseURL)
+	if err != nil {
+		_ = level.Error(logger).Log("message", "Failed to initialize image storage", "err", err)
+		return
+	}
+
 	// Создание нового сервиса
 	var svc service.Service
 	{
 		rep := repo.NewGoodsRepository(pool, logger)
-		svc = service.NewService(rep, logger)
+		svc = service.NewService(rep, images, logger)
 	}
 
 	// Канал для ошибок
@@ -97,7 +105,7 @@ func main() {
 	go func() {
 		address := cfg.Listen.BindIP + ":" + cfg.Listen.Port
 		_ = level.Info(logger).Log("message", "HTTP server is starting", "address", address)
-		httpHandler := handler.NewHTTPServer(logger, endpoints)
+		httpHandler := handler.NewHTTPServer(logger, endpoints, cfg.HTTP.CacheControl)
 		serverErr := http.ListenAndServe(address, httpHandler)
 		if serverErr != nil {
 			errs <- serverErr
@@ -27,7 +27,7 @@ const docTemplate = `{
     "paths": {
         "/api/v1/product": {
             "get": {
-                "description": "Get all products from the database",
+                "description": "Get all published products priced by the chosen price list, optionally without products containing given allergens",
                 "consumes": [
                     "application/json"
                 ],
@@ -38,11 +38,64 @@ const docTemplate = `{
                     "products"
                 ],
                 "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Price list name (base price list by default)",
+                        "name": "price_list",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated allergens to exclude, e.g. gluten,nuts",
+                        "name": "exclude_allergens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached list",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/schemas.GetAllProductsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Weak tag of the list contents"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cached list is up to date"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
                     "500": {
@@ -53,8 +106,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update product details in the database",
+            "post": {
+                "description": "Add a new product to the database",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,7 +117,7 @@ const docTemplate = `{
                 "tags": [
                     "products"
                 ],
-                "summary": "Update product",
+                "summary": "Add product",
                 "parameters": [
                     {
                         "description": "Product details",
@@ -72,7 +125,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.UpdateProductRequest"
+                            "$ref": "#/definitions/schemas.CreateProductRequest"
                         }
                     }
                 ],
@@ -80,7 +133,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.UpdateProductResponse"
+                            "$ref": "#/definitions/schemas.CreateProductResponse"
                         }
                     },
                     "400": {
@@ -96,9 +149,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Add a new product to the database",
+            }
+        },
+        "/api/v1/product/available": {
+            "get": {
+                "description": "Get products that can be sold on the route at the given local time according to their availability rules",
                 "consumes": [
                     "application/json"
                 ],
@@ -108,23 +163,51 @@ const docTemplate = `{
                 "tags": [
                     "products"
                 ],
-                "summary": "Add product",
+                "summary": "Get products available for sale",
                 "parameters": [
                     {
-                        "description": "Product details",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.CreateProductRequest"
-                        }
+                        "type": "string",
+                        "description": "Route code",
+                        "name": "route",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Region code",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Local sale time in RFC 3339 (current time by default)",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price list name (base price list by default)",
+                        "name": "price_list",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.CreateProductResponse"
+                            "$ref": "#/definitions/schemas.GetAvailableProductsResponse"
                         }
                     },
                     "400": {
@@ -133,6 +216,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -142,9 +231,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/product/template": {
-            "post": {
-                "description": "Add a new Template of products to the database",
+        "/api/v1/product/barcode/{code}": {
+            "get": {
+                "description": "Get product details by a scanned EAN-8, EAN-13 or GTIN-14 barcode",
                 "consumes": [
                     "application/json"
                 ],
@@ -152,25 +241,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Templates"
+                    "products"
                 ],
-                "summary": "Add Template",
+                "summary": "Get product by barcode",
                 "parameters": [
                     {
-                        "description": "Template details",
-                        "name": "Template",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.AddTemplateRequest"
-                        }
+                        "type": "string",
+                        "description": "Barcode",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price list name (base price list by default)",
+                        "name": "price_list",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.AddTemplateResponse"
+                            "$ref": "#/definitions/schemas.GetProductByBarcodeResponse"
                         }
                     },
                     "400": {
@@ -179,6 +284,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -188,38 +299,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/product/template/search": {
+        "/api/v1/product/image/{name}": {
             "get": {
-                "description": "Search for Templates",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a stored product image or thumbnail by its file name",
                 "produces": [
-                    "application/json"
+                    "image/jpeg",
+                    "image/png"
                 ],
                 "tags": [
-                    "Templates"
+                    "products"
                 ],
-                "summary": "Search Template",
+                "summary": "Get image",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query",
+                        "description": "Image file name",
+                        "name": "name",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -227,7 +323,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.SearchTemplatesResponse"
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
                     "500": {
@@ -239,9 +341,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/product/template/{id}": {
+        "/api/v1/product/price-list": {
             "get": {
-                "description": "Get Template details by its ID",
+                "description": "Get all price lists with their currencies",
                 "consumes": [
                     "application/json"
                 ],
@@ -249,27 +351,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Templates"
+                    "price lists"
                 ],
-                "summary": "Get Template by ID",
+                "summary": "List price lists",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ListPriceListsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new named price list with its currency",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price lists"
+                ],
+                "summary": "Add price list",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Template ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Price list details",
+                        "name": "priceList",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreatePriceListRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetTemplateByIDResponse"
+                            "$ref": "#/definitions/schemas.CreatePriceListResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
@@ -283,9 +414,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/product/{id}": {
-            "get": {
-                "description": "Get product details by its ID",
+        "/api/v1/product/price-list/{id}/prices": {
+            "put": {
+                "description": "Insert or update product prices in a price list",
                 "consumes": [
                     "application/json"
                 ],
@@ -293,23 +424,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "price lists"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Set price list prices",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Price list ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Product prices",
+                        "name": "prices",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SetPriceListPricesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetProductByIDResponse"
+                            "$ref": "#/definitions/schemas.SetPriceListPricesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
                     "404": {
@@ -325,9 +471,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a product from the database",
+            }
+        },
+        "/api/v1/product/template": {
+            "get": {
+                "description": "Get all Templates without their content",
                 "consumes": [
                     "application/json"
                 ],
@@ -335,29 +483,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "Templates"
                 ],
-                "summary": "Delete product",
+                "summary": "List Templates",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.DeleteProductResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/schemas.ErrorResponse"
+                            "$ref": "#/definitions/schemas.ListTemplatesResponse"
                         }
                     },
                     "500": {
@@ -367,139 +514,2508 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "schemas.AddTemplateRequest": {
-            "description": "Запрос на добавление шаблона",
-            "type": "object",
-            "properties": {
-                "template": {
-                    "description": "Сведения о новом шаблоне",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/schemas.TemplateSchema"
-                        }
+            },
+            "post": {
+                "description": "Add a new Template of products to the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Add Template",
+                "parameters": [
+                    {
+                        "description": "Template details",
+                        "name": "Template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.AddTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.AddTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DetailedErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/diff": {
+            "get": {
+                "description": "List products only in Template A, products only in Template B and products whose quantities differ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Compare Templates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First Template ID",
+                        "name": "a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Second Template ID",
+                        "name": "b",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DiffTemplatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/export": {
+            "get": {
+                "description": "Export the given Templates, or all Templates when no ID is given, to a self-contained document\nthat references products by SKU, to be imported into another database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Export Templates",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Template IDs; all Templates when omitted",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document format: json (default) or yaml",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TemplateDocumentSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/import": {
+            "post": {
+                "description": "Create or replace Templates, matched by name, from a document produced by export.\nProducts are resolved by SKU; if any SKU is missing or any Template is invalid, nothing is written.\nDiscontinued products may stay in a Template they are already part of, as with a full Template update.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Import Templates",
+                "parameters": [
+                    {
+                        "description": "Templates to import",
+                        "name": "Document",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TemplateDocumentSchema"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Author recorded in the Template revisions",
+                        "name": "X-User",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ImportTemplatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DetailedErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/pick-list": {
+            "post": {
+                "description": "Merge several Templates, each taken the given number of times, into one list of product quantities",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Build pick list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Response format: json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Templates with multipliers",
+                        "name": "PickList",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.BuildPickListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.BuildPickListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/search": {
+            "get": {
+                "description": "Search for Templates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Search Template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "content"
+                        ],
+                        "type": "string",
+                        "description": "Set to content to include template contents",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SearchTemplatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}": {
+            "get": {
+                "description": "Get Template details by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get Template by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "products"
+                        ],
+                        "type": "string",
+                        "description": "Set to products to inline product details and template totals",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price list for expanded products (base price list by default)",
+                        "name": "price_list",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached template",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached template",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetTemplateByIDResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Template version, or weak tag of the contents with expand=products"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the latest template or expanded product change"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cached template is up to date"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update Template name and description and replace its content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Update Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the Template version being updated, or * to skip the check",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Template details",
+                        "name": "Template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateTemplateResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New Template version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DetailedErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a Template together with its content and record the deletion in its revisions; with dry_run=true only report what would be removed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Delete Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report what would be removed without deleting",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the Template version being deleted, or * to skip the check",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Author recorded in the deletion revision",
+                        "name": "X-User",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DeleteTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}/clone": {
+            "post": {
+                "description": "Copy a Template description and content into a new Template with the given name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Clone Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Source Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New Template name",
+                        "name": "Template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CloneTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CloneTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}/content": {
+            "post": {
+                "description": "Add a line with an active product to a Template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Add product to Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the Template version being changed, or * to skip the check",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Template line",
+                        "name": "Line",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.AddTemplateLineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TemplateLineResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New Template version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DetailedErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}/content/{productID}": {
+            "put": {
+                "description": "Change the quantity of a product already in a Template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Change product quantity in Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the Template version being changed, or * to skip the check",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New quantity",
+                        "name": "Line",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateTemplateLineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TemplateLineResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New Template version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DetailedErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the line with a product from a Template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Remove product from Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the Template version being changed, or * to skip the check",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TemplateLineResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New Template version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}/revisions": {
+            "get": {
+                "description": "List the revisions recorded on every change of a Template, newest first, without their contents",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "List Template revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ListTemplateRevisionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}/revisions/{revision}": {
+            "get": {
+                "description": "Get a single revision of a Template with the contents it recorded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get Template revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetTemplateRevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}/revisions/{revision}/restore": {
+            "post": {
+                "description": "Make a past revision the current state of a Template; the restore is recorded as a new revision",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Restore Template revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current Template ETag, or * to restore unconditionally",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Author recorded in the new revision",
+                        "name": "X-User",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RestoreTemplateRevisionResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New Template version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DetailedErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}/scaled": {
+            "get": {
+                "description": "Get Template quantities scaled from its base passenger count to the given one, or by a factor.\nQuantities are rounded up to whole units, or to whole packages of the packaging marked roundScaled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Scale Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Passenger count to scale to",
+                        "name": "passengers",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Scale factor, used instead of passengers",
+                        "name": "factor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ScaleTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/template/{id}/total": {
+            "get": {
+                "description": "Calculate the Template total against the chosen price list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get Template total",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price list name (base price list by default)",
+                        "name": "price_list",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetTemplateTotalResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/{id}": {
+            "get": {
+                "description": "Get product details by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price list name (base price list by default)",
+                        "name": "price_list",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached product",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached product",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetProductByIDResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Product version, followed by a hash of the price list and languages when given"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the latest product or variant change"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cached product is up to date"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update product details in the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the product version being updated, or * to skip the check",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Product details",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateProductResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New product version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a product from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the product version being deleted, or * to skip the check",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DeleteProductResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/{id}/image": {
+            "post": {
+                "description": "Upload a JPEG or PNG image of a product (up to 5 MiB). A thumbnail is generated and the product image URLs are updated.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Upload product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UploadProductImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "schemas.AccessListSchema": {
+            "type": "object",
+            "properties": {
+                "allow": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "MOW-SPB"
+                    ]
+                },
+                "deny": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "MOW-KZN"
+                    ]
+                }
+            }
+        },
+        "schemas.AddTemplateLineRequest": {
+            "description": "Запрос на добавление продукта в шаблон",
+            "type": "object",
+            "properties": {
+                "content": {
+                    "$ref": "#/definitions/schemas.TemplateContentSchema"
+                }
+            }
+        },
+        "schemas.AddTemplateRequest": {
+            "description": "Запрос на добавление шаблона",
+            "type": "object",
+            "properties": {
+                "template": {
+                    "description": "Сведения о новом шаблоне",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.TemplateSchema"
+                        }
+                    ]
+                }
+            }
+        },
+        "schemas.AddTemplateResponse": {
+            "description": "Ответ на запрос на добавление шаблона",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID созданного шаблона",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.AvailabilitySchema": {
+            "type": "object",
+            "properties": {
+                "periods": {
+                    "description": "Периоды продаж; продукт продается, если дата попадает хотя бы в один",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DateRangeSchema"
+                    }
+                },
+                "regions": {
+                    "description": "Регионы, в которых продукт можно или нельзя продавать",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.AccessListSchema"
+                        }
+                    ]
+                },
+                "routes": {
+                    "description": "Маршруты, на которых продукт можно или нельзя продавать",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.AccessListSchema"
+                        }
+                    ]
+                },
+                "windows": {
+                    "description": "Окна продаж; продукт продается, если момент попадает хотя бы в одно",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TimeWindowSchema"
+                    }
+                }
+            }
+        },
+        "schemas.BuildPickListRequest": {
+            "description": "Запрос на сведение нескольких шаблонов в один список комплектации",
+            "type": "object",
+            "properties": {
+                "sort": {
+                    "description": "Порядок строк: name (по умолчанию) или sku",
+                    "type": "string",
+                    "example": "name"
+                },
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.PickListEntrySchema"
+                    }
+                }
+            }
+        },
+        "schemas.BuildPickListResponse": {
+            "description": "Ответ со сводным списком комплектации",
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.PickListItemSchema"
+                    }
+                }
+            }
+        },
+        "schemas.CloneTemplateRequest": {
+            "description": "Запрос на копирование шаблона под новым названием",
+            "type": "object",
+            "properties": {
+                "templateName": {
+                    "description": "Название нового шаблона",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CloneTemplateResponse": {
+            "description": "Ответ на запрос на копирование шаблона",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID созданной копии",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.CreatePriceListRequest": {
+            "description": "Запрос на создание прайс-листа",
+            "type": "object",
+            "properties": {
+                "priceList": {
+                    "$ref": "#/definitions/schemas.PriceListSchema"
+                }
+            }
+        },
+        "schemas.CreatePriceListResponse": {
+            "description": "Ответ на запрос на создание прайс-листа",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID созданного прайс-листа",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.CreateProductRequest": {
+            "description": "Запрос на добавление продукта",
+            "type": "object",
+            "properties": {
+                "product": {
+                    "$ref": "#/definitions/schemas.ProductSchema"
+                }
+            }
+        },
+        "schemas.CreateProductResponse": {
+            "description": "Ответ на запрос на добавление продукта",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.DateRangeSchema": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2024-06-01"
+                },
+                "to": {
+                    "description": "Дата окончания входит в период",
+                    "type": "string",
+                    "example": "2024-08-31"
+                }
+            }
+        },
+        "schemas.DeleteProductResponse": {
+            "description": "Ответ на запрос на удаление продукта",
+            "type": "object"
+        },
+        "schemas.DeleteTemplateResponse": {
+            "description": "Ответ на запрос на удаление шаблона",
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "description": "Шаблон не удалялся, а только был проверен",
+                    "type": "boolean"
+                },
+                "template": {
+                    "description": "Удаленный (или подлежащий удалению) шаблон вместе с составом",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.TemplateSchema"
+                        }
+                    ]
+                }
+            }
+        },
+        "schemas.DetailedErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "description": "Errors перечисляет все проблемы с полями запроса, найденные при проверке",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.FieldErrorSchema"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Дополнительное объяснение",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.DiffTemplatesResponse": {
+            "description": "Ответ на запрос на сравнение состава двух шаблонов",
+            "type": "object",
+            "properties": {
+                "a": {
+                    "description": "Первый шаблон без состава",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.TemplateSchema"
+                        }
+                    ]
+                },
+                "b": {
+                    "description": "Второй шаблон без состава",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.TemplateSchema"
+                        }
                     ]
+                },
+                "changed": {
+                    "description": "Продукты с разными количествами",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TemplateLineDiffSchema"
+                    }
+                },
+                "onlyInA": {
+                    "description": "Продукты только в первом шаблоне",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TemplateContentSchema"
+                    }
+                },
+                "onlyInB": {
+                    "description": "Продукты только во втором шаблоне",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TemplateContentSchema"
+                    }
+                }
+            }
+        },
+        "schemas.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Код ошибки",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Сообщение об ошибке",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.FieldErrorSchema": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "content[2].quantity"
+                },
+                "index": {
+                    "description": "Индекс элемента списка (например, строки шаблона), если поле принадлежит ему",
+                    "type": "integer",
+                    "example": 2
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetAllProductsResponse": {
+            "description": "Ответ на запрос на получение всех продуктов",
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ProductSchema"
+                    }
+                }
+            }
+        },
+        "schemas.GetAvailableProductsResponse": {
+            "description": "Ответ на запрос на получение продуктов, доступных для продажи",
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ProductSchema"
+                    }
+                }
+            }
+        },
+        "schemas.GetProductByBarcodeResponse": {
+            "description": "Ответ на запрос на получение продукта по штрихкоду",
+            "type": "object",
+            "properties": {
+                "product": {
+                    "$ref": "#/definitions/schemas.ProductSchema"
+                }
+            }
+        },
+        "schemas.GetProductByIDResponse": {
+            "description": "Ответ на запрос на получение продукта по его ID",
+            "type": "object",
+            "properties": {
+                "product": {
+                    "$ref": "#/definitions/schemas.ProductSchema"
+                }
+            }
+        },
+        "schemas.GetTemplateByIDResponse": {
+            "description": "Ответ на запрос на получение шаблона по его ID",
+            "type": "object",
+            "properties": {
+                "template": {
+                    "$ref": "#/definitions/schemas.TemplateSchema"
+                }
+            }
+        },
+        "schemas.GetTemplateRevisionResponse": {
+            "description": "Ответ на запрос на получение ревизии шаблона",
+            "type": "object",
+            "properties": {
+                "revision": {
+                    "$ref": "#/definitions/schemas.TemplateRevisionSchema"
+                }
+            }
+        },
+        "schemas.GetTemplateTotalResponse": {
+            "description": "Ответ на запрос на расчет стоимости шаблона по прайс-листу",
+            "type": "object",
+            "properties": {
+                "total": {
+                    "$ref": "#/definitions/schemas.TemplateTotalSchema"
+                }
+            }
+        },
+        "schemas.ImportTemplatesResponse": {
+            "description": "Ответ на запрос на импорт шаблонов",
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "description": "Результаты в порядке шаблонов документа",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ImportedTemplateSchema"
+                    }
+                }
+            }
+        },
+        "schemas.ImportedTemplateSchema": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "true, если шаблон создан, false — если заменен существующий",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "ID шаблона в этой базе данных",
+                    "type": "integer"
+                },
+                "templateName": {
+                    "description": "Название шаблона",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Версия шаблона после импорта",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.ListPriceListsResponse": {
+            "description": "Ответ на запрос на получение всех прайс-листов",
+            "type": "object",
+            "properties": {
+                "priceLists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.PriceListSchema"
+                    }
+                }
+            }
+        },
+        "schemas.ListTemplateRevisionsResponse": {
+            "description": "Ответ на запрос на получение истории изменений шаблона",
+            "type": "object",
+            "properties": {
+                "revisions": {
+                    "description": "Ревизии от новых к старым",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TemplateRevisionSchema"
+                    }
+                }
+            }
+        },
+        "schemas.ListTemplatesResponse": {
+            "description": "Ответ на запрос на получение всех шаблонов; состав шаблонов не заполняется",
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TemplateSchema"
+                    }
+                }
+            }
+        },
+        "schemas.NutritionSchema": {
+            "type": "object",
+            "properties": {
+                "carbohydrates": {
+                    "type": "number",
+                    "example": 35.2
+                },
+                "fats": {
+                    "type": "number",
+                    "example": 9
+                },
+                "kcal": {
+                    "type": "number",
+                    "example": 250
+                },
+                "proteins": {
+                    "type": "number",
+                    "example": 7.5
+                }
+            }
+        },
+        "schemas.PackagingSchema": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "box"
+                },
+                "quantity": {
+                    "description": "Число базовых единиц в упаковке",
+                    "type": "integer",
+                    "example": 24
+                },
+                "roundScaled": {
+                    "description": "Округлять масштабированные шаблоны вверх до целых упаковок этого уровня",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.PickListEntrySchema": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "multiplier": {
+                    "description": "Число комплектов шаблона, например вагонов; от 1 до 10000",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "schemas.PickListItemSchema": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "Базовая единица, в которой задано количество",
+                    "type": "string",
+                    "example": "pcs"
+                }
+            }
+        },
+        "schemas.PortableTemplateLineSchema": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "description": "Количество; при экспорте — в базовых единицах продукта",
+                    "type": "integer",
+                    "example": 10
+                },
+                "sku": {
+                    "description": "Артикул продукта",
+                    "type": "string",
+                    "example": "WATER-05"
+                },
+                "unit": {
+                    "description": "Упаковка, в которой задано количество; только при импорте",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "schemas.PortableTemplateSchema": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.PortableTemplateLineSchema"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "passengers": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "templateName": {
+                    "description": "Название; шаблон с таким названием в целевой базе данных заменяется",
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/schemas.TranslationSchema"
+                    }
                 }
             }
         },
-        "schemas.AddTemplateResponse": {
-            "description": "Ответ на запрос на добавление шаблона",
+        "schemas.PriceListSchema": {
             "type": "object",
             "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "BYN"
+                },
                 "id": {
-                    "description": "ID созданного шаблона",
                     "type": "integer"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.CreateProductRequest": {
-            "description": "Запрос на добавление продукта",
+        "schemas.ProductImageSchema": {
             "type": "object",
             "properties": {
-                "product": {
-                    "$ref": "#/definitions/schemas.ProductSchema"
+                "imageurl": {
+                    "type": "string",
+                    "example": "/api/v1/product/image/product-1-9f86d081884c7d65.jpg"
+                },
+                "thumbnailurl": {
+                    "type": "string",
+                    "example": "/api/v1/product/image/product-1-9f86d081884c7d65-thumb.jpg"
                 }
             }
         },
-        "schemas.CreateProductResponse": {
-            "description": "Ответ на запрос на добавление продукта",
+        "schemas.ProductPriceSchema": {
             "type": "object",
             "properties": {
-                "id": {
+                "price": {
+                    "type": "string",
+                    "example": "149.99"
+                },
+                "productID": {
                     "type": "integer"
                 }
             }
         },
-        "schemas.DeleteProductResponse": {
-            "description": "Ответ на запрос на удаление продукта",
-            "type": "object"
-        },
-        "schemas.ErrorResponse": {
+        "schemas.ProductSchema": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Код ошибки",
+                "allergens": {
+                    "description": "Аллергены, которые содержит продукт",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "gluten",
+                        "lactose"
+                    ]
+                },
+                "availability": {
+                    "description": "Правила, когда и где продукт можно продавать",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.AvailabilitySchema"
+                        }
+                    ]
+                },
+                "barcodes": {
+                    "description": "Штрихкоды EAN-8, EAN-13 или GTIN-14",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "4600000000008"
+                    ]
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "grossPrice": {
+                    "type": "string",
+                    "readOnly": true,
+                    "example": "149.99"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "message": {
-                    "description": "Сообщение об ошибке",
+                "imageurl": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "netPrice": {
+                    "type": "string",
+                    "readOnly": true,
+                    "example": "124.99"
+                },
+                "nutrition": {
+                    "description": "Пищевая ценность на 100 г",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.NutritionSchema"
+                        }
+                    ]
+                },
+                "options": {
+                    "description": "Значения опций варианта",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "flavor": "green",
+                        "volume": "0.5 L"
+                    }
+                },
+                "packaging": {
+                    "description": "Уровни упаковки продукта",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.PackagingSchema"
+                    }
+                },
+                "parentID": {
+                    "description": "ID родительского продукта, если это вариант",
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "149.99"
+                },
+                "status": {
+                    "description": "Этап жизненного цикла; новые продукты по умолчанию черновики",
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "active",
+                        "discontinued"
+                    ]
+                },
+                "thumbnailurl": {
                     "type": "string"
+                },
+                "translations": {
+                    "description": "Переводы названия и описания по кодам языков",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/schemas.TranslationSchema"
+                    }
+                },
+                "unit": {
+                    "description": "Базовая единица измерения, по умолчанию pcs",
+                    "type": "string",
+                    "example": "pcs"
+                },
+                "variants": {
+                    "description": "Варианты родительского продукта",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ProductSchema"
+                    },
+                    "readOnly": true
+                },
+                "vatAmount": {
+                    "type": "string",
+                    "readOnly": true,
+                    "example": "25.00"
+                },
+                "vatRate": {
+                    "type": "string",
+                    "enum": [
+                        "20",
+                        "10",
+                        "0",
+                        "exempt"
+                    ],
+                    "example": "20"
+                },
+                "version": {
+                    "description": "Версия строки, передается в If-Match при изменении",
+                    "type": "integer",
+                    "readOnly": true
                 }
             }
         },
-        "schemas.GetAllProductsResponse": {
-            "description": "Ответ на запрос на получение всех продуктов",
+        "schemas.RestoreTemplateRevisionResponse": {
+            "description": "Ответ на запрос на восстановление ревизии шаблона",
             "type": "object",
             "properties": {
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.ProductSchema"
-                    }
+                "version": {
+                    "description": "Новая версия шаблона",
+                    "type": "integer"
                 }
             }
         },
-        "schemas.GetProductByIDResponse": {
-            "description": "Ответ на запрос на получение продукта по его ID",
+        "schemas.ScaleTemplateResponse": {
+            "description": "Ответ на запрос на масштабирование шаблона",
             "type": "object",
             "properties": {
-                "product": {
-                    "$ref": "#/definitions/schemas.ProductSchema"
+                "template": {
+                    "description": "Шаблон с пересчитанными количествами и числом пассажиров",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.TemplateSchema"
+                        }
+                    ]
                 }
             }
         },
-        "schemas.GetTemplateByIDResponse": {
-            "description": "Ответ на запрос на получение шаблона по его ID",
+        "schemas.SearchTemplatesResponse": {
+            "description": "Ответ на запрос на поиск шаблонов",
             "type": "object",
             "properties": {
-                "template": {
-                    "$ref": "#/definitions/schemas.TemplateSchema"
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TemplateSchema"
+                    }
                 }
             }
         },
-        "schemas.ProductSchema": {
+        "schemas.SetPriceListPricesRequest": {
+            "description": "Запрос на установку цен продуктов в прайс-листе",
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ProductPriceSchema"
+                    }
+                }
+            }
+        },
+        "schemas.SetPriceListPricesResponse": {
+            "description": "Ответ на запрос на установку цен продуктов в прайс-листе",
+            "type": "object"
+        },
+        "schemas.TemplateContentSchema": {
+            "type": "object",
+            "properties": {
+                "product": {
+                    "description": "Продукт строки с ценой, только при expand=products",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.ProductSchema"
+                        }
+                    ],
+                    "readOnly": true
                 },
-                "id": {
+                "productID": {
                     "type": "integer"
                 },
-                "imageurl": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
                 },
-                "price": {
-                    "type": "number"
+                "unit": {
+                    "description": "Упаковка, в которой задано количество; в ответах количество всегда в базовых единицах",
+                    "type": "string",
+                    "example": "box"
                 }
             }
         },
-        "schemas.SearchTemplatesResponse": {
-            "description": "Ответ на запрос на поиск шаблонов",
+        "schemas.TemplateDocumentSchema": {
+            "description": "Документ для переноса шаблонов между базами данных: шаблоны определяются названием, продукты — артикулом",
             "type": "object",
             "properties": {
+                "formatVersion": {
+                    "description": "Версия формата документа",
+                    "type": "integer",
+                    "example": 1
+                },
                 "templates": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schemas.TemplateSchema"
+                        "$ref": "#/definitions/schemas.PortableTemplateSchema"
                     }
                 }
             }
         },
-        "schemas.TemplateContentSchema": {
+        "schemas.TemplateLineDiffSchema": {
             "type": "object",
             "properties": {
+                "delta": {
+                    "description": "Изменение от первого шаблона ко второму",
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/schemas.ProductSchema"
+                },
                 "productID": {
                     "type": "integer"
                 },
-                "quantity": {
+                "quantityA": {
+                    "description": "Количество в первом шаблоне",
+                    "type": "integer"
+                },
+                "quantityB": {
+                    "description": "Количество во втором шаблоне",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.TemplateLineResponse": {
+            "description": "Ответ на изменение строки шаблона",
+            "type": "object",
+            "properties": {
+                "version": {
+                    "description": "Новая версия шаблона",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.TemplateRevisionSchema": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Автор изменения из заголовка X-User; пустая строка, если автор не передан",
+                    "type": "string",
+                    "example": "ivanov"
+                },
+                "createdAt": {
+                    "description": "Время изменения",
+                    "type": "string"
+                },
+                "deleted": {
+                    "description": "Изменение удалило шаблон; шаблон — его состояние перед удалением",
+                    "type": "boolean"
+                },
+                "revision": {
+                    "description": "Версия шаблона, которую получил шаблон в результате изменения",
                     "type": "integer"
+                },
+                "template": {
+                    "description": "Шаблон на момент изменения; в списке ревизий без состава",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.TemplateSchema"
+                        }
+                    ]
                 }
             }
         },
@@ -518,8 +3034,115 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "passengers": {
+                    "description": "Базовое число пассажиров, на которое рассчитан состав",
+                    "type": "integer",
+                    "example": 60
+                },
                 "templateName": {
                     "type": "string"
+                },
+                "total": {
+                    "description": "Итоги шаблона, только при expand=products",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.TemplateTotalSchema"
+                        }
+                    ],
+                    "readOnly": true
+                },
+                "translations": {
+                    "description": "Переводы названия и описания по кодам языков",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/schemas.TranslationSchema"
+                    }
+                },
+                "version": {
+                    "description": "Версия строки",
+                    "type": "integer",
+                    "readOnly": true
+                }
+            }
+        },
+        "schemas.TemplateTotalSchema": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "items": {
+                    "description": "Число единиц товара во всех строках",
+                    "type": "integer",
+                    "example": 10
+                },
+                "net": {
+                    "type": "string",
+                    "example": "1249.92"
+                },
+                "priceList": {
+                    "type": "string"
+                },
+                "templateID": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "1499.90"
+                },
+                "vat": {
+                    "type": "string",
+                    "example": "249.98"
+                }
+            }
+        },
+        "schemas.TimeWindowSchema": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Пустой список означает все дни",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "mon",
+                            "tue",
+                            "wed",
+                            "thu",
+                            "fri",
+                            "sat",
+                            "sun"
+                        ]
+                    },
+                    "example": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri"
+                    ]
+                },
+                "end": {
+                    "description": "Конец окна, не входит в окно",
+                    "type": "string",
+                    "example": "11:00"
+                },
+                "start": {
+                    "description": "Начало окна по местному времени",
+                    "type": "string",
+                    "example": "07:00"
+                }
+            }
+        },
+        "schemas.TranslationSchema": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Still water 0.5 L"
                 }
             }
         },
@@ -534,7 +3157,55 @@ const docTemplate = `{
         },
         "schemas.UpdateProductResponse": {
             "description": "Ответ на запрос на обновление продукта",
-            "type": "object"
+            "type": "object",
+            "properties": {
+                "version": {
+                    "description": "Новая версия продукта",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.UpdateTemplateLineRequest": {
+            "description": "Запрос на изменение количества продукта в шаблоне",
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "Упаковка, в которой задано количество",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "schemas.UpdateTemplateRequest": {
+            "description": "Запрос на обновление шаблона; состав шаблона заменяется целиком",
+            "type": "object",
+            "properties": {
+                "template": {
+                    "$ref": "#/definitions/schemas.TemplateSchema"
+                }
+            }
+        },
+        "schemas.UpdateTemplateResponse": {
+            "description": "Ответ на запрос на обновление шаблона",
+            "type": "object",
+            "properties": {
+                "version": {
+                    "description": "Новая версия шаблона",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.UploadProductImageResponse": {
+            "description": "Ответ на запрос на загрузку изображения продукта",
+            "type": "object",
+            "properties": {
+                "image": {
+                    "$ref": "#/definitions/schemas.ProductImageSchema"
+                }
+            }
         }
     }
 }`
@@ -23,6 +23,20 @@ type Config struct {
 		Port   string `yaml:"port" env-default:"8080" env:"PORT"`
 	} `yaml:"listen"`
 	Storage StorageConfig `yaml:"storage"`
+	Images  ImagesConfig  `yaml:"images"`
+	HTTP    HTTPConfig    `yaml:"http"`
+}
+
+// HTTPConfig is the HTTP transport configuration structure that is read from the config file.
+type HTTPConfig struct {
+	// CacheControl is sent with cacheable GET responses; the default makes clients revalidate with ETag on every use.
+	CacheControl string `yaml:"cache_control" env-default:"no-cache" env:"HTTP_CACHE_CONTROL"`
+}
+
+// ImagesConfig is the product image storage configuration structure that is read from the config file.
+type ImagesConfig struct {
+	Dir     string `yaml:"dir" env-default:"./images" env:"IMAGES_DIR"`
+	BaseURL string `yaml:"base_url" env-default:"/api/v1/product/image" env:"IMAGES_BASE_URL"`
 }
 
 // StorageConfig is the database configuration structure that is read from the config file.
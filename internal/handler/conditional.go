@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/handler/schemas"
+
+	httpGoKit "github.com/go-kit/kit/transport/http"
+)
+
+// conditionsKey — ключ контекста, под которым хранятся условия условного GET-запроса.
+type conditionsKey struct{}
+
+// conditions описывает заголовки условного GET-запроса.
+type conditions struct {
+	ifNoneMatch     string
+	ifModifiedSince string
+}
+
+// cacheableOptions возвращает опции go-kit сервера для кэшируемых GET-маршрутов: условия запроса
+// переносятся в контекст, а к успешному ответу добавляются Cache-Control из конфигурации и Vary.
+// Сами ETag, Last-Modified и ответ 304 выставляет encodeResponse для ответов, реализующих schemas.Cacheable.
+func cacheableOptions(cacheControl string) []httpGoKit.ServerOption {
+	after := []httpGoKit.ServerResponseFunc{httpGoKit.SetResponseHeader("Vary", "Accept-Language")}
+	if cacheControl != "" {
+		after = append(after, httpGoKit.SetResponseHeader("Cache-Control", cacheControl))
+	}
+	return []httpGoKit.ServerOption{
+		httpGoKit.ServerBefore(conditionsToContext),
+		httpGoKit.ServerAfter(after...),
+	}
+}
+
+// conditionsToContext сохраняет заголовки If-None-Match и If-Modified-Since в контексте запроса.
+func conditionsToContext(ctx context.Context, req *http.Request) context.Context {
+	return context.WithValue(ctx, conditionsKey{}, conditions{
+		ifNoneMatch:     req.Header.Get("If-None-Match"),
+		ifModifiedSince: req.Header.Get("If-Modified-Since"),
+	})
+}
+
+// encodeCacheableResponse выставляет ETag и Last-Modified и отвечает 304 Not Modified,
+// если данные у клиента не устарели. Если ответ не задает ETag, используется слабый тег по хешу тела.
+func encodeCacheableResponse(ctx context.Context, w http.ResponseWriter, response schemas.Cacheable) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	body = append(body, '\n')
+
+	etag := response.ETag()
+	if etag == "" {
+		sum := sha256.Sum256(body)
+		etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+	w.Header().Set("ETag", etag)
+	modified := response.LastModified().UTC().Truncate(time.Second)
+	if !modified.IsZero() {
+		w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+	}
+
+	cond, _ := ctx.Value(conditionsKey{}).(conditions)
+	if notModified(cond, etag, modified) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// notModified проверяет условия запроса по RFC 9110: If-None-Match сравнивается слабым сравнением
+// и имеет приоритет, а If-Modified-Since учитывается только при его отсутствии.
+func notModified(cond conditions, etag string, modified time.Time) bool {
+	if cond.ifNoneMatch != "" {
+		if strings.TrimSpace(cond.ifNoneMatch) == "*" {
+			return true
+		}
+		for _, tag := range strings.Split(cond.ifNoneMatch, ",") {
+			if weakETag(strings.TrimSpace(tag)) == weakETag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+	if cond.ifModifiedSince == "" || modified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(cond.ifModifiedSince)
+	return err == nil && !modified.After(since)
+}
+
+// weakETag отбрасывает признак слабого тега для слабого сравнения.
+func weakETag(tag string) string {
+	return strings.TrimPrefix(tag, "W/")
+}
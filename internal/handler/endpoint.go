@@ -3,8 +3,10 @@ package handler
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/Chaika-Team/ChaikaGoods/internal/handler/schemas"
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
 	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
 	"github.com/Chaika-Team/ChaikaGoods/internal/service"
 
@@ -19,18 +21,44 @@ const (
 // Endpoints содержит все Go kit эндпоинты для всех операций
 type Endpoints struct {
 	// For products
-	GetAllProducts    endpoint.Endpoint
-	GetProductByID    endpoint.Endpoint
-	GetCurrentVersion endpoint.Endpoint
-	GetDelta          endpoint.Endpoint
+	GetAllProducts       endpoint.Endpoint
+	GetProductByID       endpoint.Endpoint
+	GetProductByBarcode  endpoint.Endpoint
+	GetAvailableProducts endpoint.Endpoint
+	GetCurrentVersion    endpoint.Endpoint
+	GetDelta             endpoint.Endpoint
 	// For Templates
-	SearchTemplates endpoint.Endpoint
-	AddTemplate     endpoint.Endpoint
-	GetTemplateByID endpoint.Endpoint
+	SearchTemplates    endpoint.Endpoint
+	AddTemplate        endpoint.Endpoint
+	UpdateTemplate     endpoint.Endpoint
+	ListTemplates      endpoint.Endpoint
+	DeleteTemplate     endpoint.Endpoint
+	CloneTemplate      endpoint.Endpoint
+	BuildPickList      endpoint.Endpoint
+	DiffTemplates      endpoint.Endpoint
+	ExportTemplates    endpoint.Endpoint
+	ImportTemplates    endpoint.Endpoint
+	AddTemplateLine    endpoint.Endpoint
+	UpdateTemplateLine endpoint.Endpoint
+	RemoveTemplateLine endpoint.Endpoint
+	GetTemplateByID    endpoint.Endpoint
+	GetTemplateTotal   endpoint.Endpoint
+	ScaleTemplate      endpoint.Endpoint
+	// For Template revisions
+	ListTemplateRevisions   endpoint.Endpoint
+	GetTemplateRevision     endpoint.Endpoint
+	RestoreTemplateRevision endpoint.Endpoint
 	// For products (admin)
-	CreateProduct endpoint.Endpoint
-	UpdateProduct endpoint.Endpoint
-	DeleteProduct endpoint.Endpoint
+	CreateProduct      endpoint.Endpoint
+	UpdateProduct      endpoint.Endpoint
+	DeleteProduct      endpoint.Endpoint
+	UploadProductImage endpoint.Endpoint
+	// For images
+	GetImage endpoint.Endpoint
+	// For price lists
+	ListPriceLists     endpoint.Endpoint
+	CreatePriceList    endpoint.Endpoint
+	SetPriceListPrices endpoint.Endpoint
 }
 
 // MakeEndpoints инициализирует все Go kit эндпоинты для всех операций
@@ -41,22 +69,55 @@ func MakeEndpoints(logger log.Logger, svc service.Service) Endpoints {
 	templateContentMapper := schemas.NewTemplateContentMapper()
 	templateMapper := schemas.NewTemplateMapper(templateContentMapper, productMapper)
 	templatesMapper := schemas.NewTemplatesMapper(templateMapper)
+	templateTotalMapper := schemas.NewTemplateTotalMapper()
+	priceListMapper := schemas.NewPriceListMapper()
+	productPriceMapper := schemas.NewProductPriceMapper()
+	productImageMapper := schemas.NewProductImageMapper()
+	pickListItemMapper := schemas.NewPickListItemMapper()
+	templateRevisionMapper := schemas.NewTemplateRevisionMapper(templateMapper)
+	portableTemplateMapper := schemas.NewPortableTemplateMapper()
 
 	// Создаем middleware для логирования и обработки ошибок
 	logMiddleware := LoggingMiddleware(logger)
 
 	return Endpoints{
 		// Products
-		GetAllProducts: logMiddleware(makeGetAllProductsEndpoint(svc, productsMapper)),
-		GetProductByID: logMiddleware(makeGetProductByIDEndpoint(svc, productMapper)),
+		GetAllProducts:       logMiddleware(makeGetAllProductsEndpoint(svc, productsMapper)),
+		GetProductByID:       logMiddleware(makeGetProductByIDEndpoint(svc, productMapper)),
+		GetProductByBarcode:  logMiddleware(makeGetProductByBarcodeEndpoint(svc, productMapper)),
+		GetAvailableProducts: logMiddleware(makeGetAvailableProductsEndpoint(svc, productsMapper)),
 		// Templates
-		SearchTemplates: logMiddleware(makeSearchTemplatesEndpoint(svc, templatesMapper)),
-		AddTemplate:     logMiddleware(makeAddTemplateEndpoint(svc, templateMapper)),
-		GetTemplateByID: logMiddleware(makeGetTemplateByIDEndpoint(svc, templateMapper)),
+		SearchTemplates:    logMiddleware(makeSearchTemplatesEndpoint(svc, templatesMapper)),
+		AddTemplate:        logMiddleware(makeAddTemplateEndpoint(svc, templateMapper)),
+		UpdateTemplate:     logMiddleware(makeUpdateTemplateEndpoint(svc, templateMapper)),
+		ListTemplates:      logMiddleware(makeListTemplatesEndpoint(svc, templatesMapper)),
+		DeleteTemplate:     logMiddleware(makeDeleteTemplateEndpoint(svc, templateMapper)),
+		CloneTemplate:      logMiddleware(makeCloneTemplateEndpoint(svc)),
+		BuildPickList:      logMiddleware(makeBuildPickListEndpoint(svc, pickListItemMapper)),
+		DiffTemplates:      logMiddleware(makeDiffTemplatesEndpoint(svc, templateMapper)),
+		ExportTemplates:    logMiddleware(makeExportTemplatesEndpoint(svc, portableTemplateMapper)),
+		ImportTemplates:    logMiddleware(makeImportTemplatesEndpoint(svc, portableTemplateMapper)),
+		AddTemplateLine:    logMiddleware(makeAddTemplateLineEndpoint(svc, templateContentMapper)),
+		UpdateTemplateLine: logMiddleware(makeUpdateTemplateLineEndpoint(svc)),
+		RemoveTemplateLine: logMiddleware(makeRemoveTemplateLineEndpoint(svc)),
+		GetTemplateByID:    logMiddleware(makeGetTemplateByIDEndpoint(svc, templateMapper)),
+		GetTemplateTotal:   logMiddleware(makeGetTemplateTotalEndpoint(svc, templateTotalMapper)),
+		ScaleTemplate:      logMiddleware(makeScaleTemplateEndpoint(svc, templateMapper)),
+		// Template revisions
+		ListTemplateRevisions:   logMiddleware(makeListTemplateRevisionsEndpoint(svc, templateRevisionMapper)),
+		GetTemplateRevision:     logMiddleware(makeGetTemplateRevisionEndpoint(svc, templateRevisionMapper)),
+		RestoreTemplateRevision: logMiddleware(makeRestoreTemplateRevisionEndpoint(svc)),
 		// Products (admin)
-		CreateProduct: logMiddleware(makeCreateProductEndpoint(svc, productMapper)),
-		UpdateProduct: logMiddleware(makeUpdateProductEndpoint(svc, productMapper)),
-		DeleteProduct: logMiddleware(makeDeleteProductEndpoint(svc)),
+		CreateProduct:      logMiddleware(makeCreateProductEndpoint(svc, productMapper)),
+		UpdateProduct:      logMiddleware(makeUpdateProductEndpoint(svc, productMapper)),
+		DeleteProduct:      logMiddleware(makeDeleteProductEndpoint(svc)),
+		UploadProductImage: logMiddleware(makeUploadProductImageEndpoint(svc, productImageMapper)),
+		// Images
+		GetImage: logMiddleware(makeGetImageEndpoint(svc)),
+		// Price lists
+		ListPriceLists:     logMiddleware(makeListPriceListsEndpoint(svc, priceListMapper)),
+		CreatePriceList:    logMiddleware(makeCreatePriceListEndpoint(svc, priceListMapper)),
+		SetPriceListPrices: logMiddleware(makeSetPriceListPricesEndpoint(svc, productPriceMapper)),
 	}
 }
 
@@ -72,16 +133,32 @@ func castRequest[T any](req interface{}) (T, error) {
 // makeGetAllProductsEndpoint constructs a GetAllProducts endpoint wrapping the service.
 //
 //	@Summary		Get all products
-//	@Description	Get all products from the database
+//	@Description	Get all published products priced by the chosen price list, optionally without products containing given allergens
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	schemas.GetAllProductsResponse
-//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Param			price_list			query		string	false	"Price list name (base price list by default)"
+//	@Param			exclude_allergens	query		string	false	"Comma-separated allergens to exclude, e.g. gluten,nuts"
+//	@Param			lang				query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language		header		string	false	"Preferred languages"
+//	@Param			If-None-Match		header		string	false	"ETag of the cached list"
+//	@Success		200					{object}	schemas.GetAllProductsResponse
+//	@Header			200					{string}	ETag	"Weak tag of the list contents"
+//	@Success		304					"Cached list is up to date"
+//	@Failure		400					{object}	schemas.ErrorResponse
+//	@Failure		404					{object}	schemas.ErrorResponse
+//	@Failure		500					{object}	schemas.ErrorResponse
 //	@Router			/api/v1/product [get]
 func makeGetAllProductsEndpoint(s service.Service, mapper *schemas.ProductsMapper) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		products, err := s.GetAllProducts(ctx)
+		var query models.ProductQuery
+		if req, ok := request.(*schemas.GetAllProductsRequest); ok {
+			query.PriceList = req.PriceList
+			query.ExcludeAllergens = schemas.AllergensToModels(req.ExcludeAllergens)
+			query.Locales = req.Locales
+		}
+
+		products, err := s.GetAllProducts(ctx, query)
 		if err != nil {
 			return nil, err
 		}
@@ -98,10 +175,18 @@ func makeGetAllProductsEndpoint(s service.Service, mapper *schemas.ProductsMappe
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int	true	"Product ID"
-//	@Success		200	{object}	schemas.GetProductByIDResponse
-//	@Failure		404	{object}	schemas.ErrorResponse
-//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Param			id				path		int		true	"Product ID"
+//	@Param			price_list		query		string	false	"Price list name (base price list by default)"
+//	@Param			lang			query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language		header		string	false	"Preferred languages"
+//	@Param			If-None-Match		header		string	false	"ETag of the cached product"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of the cached product"
+//	@Success		200			{object}	schemas.GetProductByIDResponse
+//	@Header			200			{string}	ETag			"Product version, followed by a hash of the price list and languages when given"
+//	@Header			200			{string}	Last-Modified	"Time of the latest product or variant change"
+//	@Success		304			"Cached product is up to date"
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
 //	@Router			/api/v1/product/{id} [get]
 func makeGetProductByIDEndpoint(s service.Service, mapper *schemas.ProductMapper) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -110,13 +195,80 @@ func makeGetProductByIDEndpoint(s service.Service, mapper *schemas.ProductMapper
 			return nil, myerr.Validation(invalidRequestType, err)
 		}
 
-		product, err := s.GetProductByID(ctx, req.ProductID)
+		product, err := s.GetProductByID(ctx, req.ProductID, models.ProductQuery{PriceList: req.PriceList, Locales: req.Locales})
 		if err != nil {
 			return nil, err
 		}
 
 		productSchema := mapper.ToSchema(product)
-		return schemas.GetProductByIDResponse{Product: productSchema}, nil
+		return schemas.GetProductByIDResponse{Product: productSchema, Modified: product.LastModified(), PriceList: req.PriceList, Locales: req.Locales}, nil
+	}
+}
+
+// makeGetProductByBarcodeEndpoint constructs a GetProductByBarcode endpoint wrapping the service.
+//
+//	@Summary		Get product by barcode
+//	@Description	Get product details by a scanned EAN-8, EAN-13 or GTIN-14 barcode
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			code		path		string	true	"Barcode"
+//	@Param			price_list	query		string	false	"Price list name (base price list by default)"
+//	@Param			lang			query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language	header		string	false	"Preferred languages"
+//	@Success		200			{object}	schemas.GetProductByBarcodeResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/barcode/{code} [get]
+func makeGetProductByBarcodeEndpoint(s service.Service, mapper *schemas.ProductMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.GetProductByBarcodeRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		product, err := s.GetProductByBarcode(ctx, req.Code, models.ProductQuery{PriceList: req.PriceList, Locales: req.Locales})
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.GetProductByBarcodeResponse{Product: mapper.ToSchema(product)}, nil
+	}
+}
+
+// makeGetAvailableProductsEndpoint constructs a GetAvailableProducts endpoint wrapping the service.
+//
+//	@Summary		Get products available for sale
+//	@Description	Get products that can be sold on the route at the given local time according to their availability rules
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			route			query		string	true	"Route code"
+//	@Param			region			query		string	false	"Region code"
+//	@Param			at				query		string	false	"Local sale time in RFC 3339 (current time by default)"
+//	@Param			price_list		query		string	false	"Price list name (base price list by default)"
+//	@Param			lang			query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language	header		string	false	"Preferred languages"
+//	@Success		200				{object}	schemas.GetAvailableProductsResponse
+//	@Failure		400				{object}	schemas.ErrorResponse
+//	@Failure		404				{object}	schemas.ErrorResponse
+//	@Failure		500				{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/available [get]
+func makeGetAvailableProductsEndpoint(s service.Service, mapper *schemas.ProductsMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.GetAvailableProductsRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		sale := models.SaleContext{Route: req.Route, Region: req.Region, At: req.At}
+		products, err := s.GetAvailableProducts(ctx, sale, models.ProductQuery{PriceList: req.PriceList, Locales: req.Locales})
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.GetAvailableProductsResponse{Products: mapper.ToSchemas(products)}, nil
 	}
 }
 
@@ -130,7 +282,11 @@ func makeGetProductByIDEndpoint(s service.Service, mapper *schemas.ProductMapper
 //	@Param			query	query		string	false	"Search query"
 //	@Param			limit	query		int		true	"Limit"
 //	@Param			offset	query		int		true	"Offset"
+//	@Param			expand	query		string	false	"Set to content to include template contents"	Enums(content)
+//	@Param			lang			query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language	header		string	false	"Preferred languages"
 //	@Success		200		{object}	schemas.SearchTemplatesResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
 //	@Failure		500		{object}	schemas.ErrorResponse
 //	@Router			/api/v1/product/template/search [get]
 func makeSearchTemplatesEndpoint(s service.Service, mapper *schemas.TemplatesMapper) endpoint.Endpoint {
@@ -140,7 +296,8 @@ func makeSearchTemplatesEndpoint(s service.Service, mapper *schemas.TemplatesMap
 			return nil, myerr.Validation(invalidRequestType, err)
 		}
 
-		Templates, err := s.SearchTemplates(ctx, req.Query, req.Limit, req.Offset)
+		query := models.TemplateQuery{Locales: req.Locales, WithContent: slices.Contains(req.Expand, schemas.ExpandContent)}
+		Templates, err := s.SearchTemplates(ctx, req.Query, req.Limit, req.Offset, query)
 		if err != nil {
 			return nil, err
 		}
@@ -159,7 +316,7 @@ func makeSearchTemplatesEndpoint(s service.Service, mapper *schemas.TemplatesMap
 //	@Produce		json
 //	@Param			Template	body		schemas.AddTemplateRequest	true	"Template details"
 //	@Success		200		{object}	schemas.AddTemplateResponse
-//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		400		{object}	schemas.DetailedErrorResponse
 //	@Failure		500		{object}	schemas.ErrorResponse
 //	@Router			/api/v1/product/template [post]
 func makeAddTemplateEndpoint(s service.Service, mapper *schemas.TemplateMapper) endpoint.Endpoint {
@@ -180,6 +337,421 @@ func makeAddTemplateEndpoint(s service.Service, mapper *schemas.TemplateMapper)
 	}
 }
 
+// makeUpdateTemplateEndpoint constructs a UpdateTemplate endpoint wrapping the service.
+//
+//	@Summary		Update Template
+//	@Description	Update Template name and description and replace its content
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int								true	"Template ID"
+//	@Param			If-Match	header		string							true	"ETag of the Template version being updated, or * to skip the check"
+//	@Param			Template	body		schemas.UpdateTemplateRequest	true	"Template details"
+//	@Success		200			{object}	schemas.UpdateTemplateResponse
+//	@Header			200			{string}	ETag	"New Template version"
+//	@Failure		400			{object}	schemas.DetailedErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		428			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id} [put]
+func makeUpdateTemplateEndpoint(s service.Service, mapper *schemas.TemplateMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.UpdateTemplateRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		templateModel := mapper.ToModel(req.Template)
+		templateModel.Version = req.Version
+
+		err = s.UpdateTemplate(ctx, &templateModel)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.UpdateTemplateResponse{Version: templateModel.Version}, nil
+	}
+}
+
+// makeListTemplatesEndpoint constructs a ListTemplates endpoint wrapping the service.
+//
+//	@Summary		List Templates
+//	@Description	Get all Templates without their content
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			lang			query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language	header		string	false	"Preferred languages"
+//	@Success		200				{object}	schemas.ListTemplatesResponse
+//	@Failure		500				{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template [get]
+func makeListTemplatesEndpoint(s service.Service, mapper *schemas.TemplatesMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.ListTemplatesRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		templates, err := s.ListTemplates(ctx, models.TemplateQuery{Locales: req.Locales})
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.ListTemplatesResponse{Templates: mapper.ToSchemas(templates)}, nil
+	}
+}
+
+// makeDeleteTemplateEndpoint constructs a DeleteTemplate endpoint wrapping the service.
+//
+//	@Summary		Delete Template
+//	@Description	Delete a Template together with its content and record the deletion in its revisions; with dry_run=true only report what would be removed
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Template ID"
+//	@Param			dry_run		query		bool	false	"Report what would be removed without deleting"
+//	@Param			If-Match	header		string	true	"ETag of the Template version being deleted, or * to skip the check"
+//	@Param			X-User		header		string	false	"Author recorded in the deletion revision"
+//	@Success		200			{object}	schemas.DeleteTemplateResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		428			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id} [delete]
+func makeDeleteTemplateEndpoint(s service.Service, mapper *schemas.TemplateMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.DeleteTemplateRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		template, err := s.DeleteTemplate(ctx, req.TemplateID, req.Version, req.DryRun)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.DeleteTemplateResponse{DryRun: req.DryRun, Template: mapper.ToSchema(template)}, nil
+	}
+}
+
+// makeCloneTemplateEndpoint constructs a CloneTemplate endpoint wrapping the service.
+//
+//	@Summary		Clone Template
+//	@Description	Copy a Template description and content into a new Template with the given name
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int								true	"Source Template ID"
+//	@Param			Template	body		schemas.CloneTemplateRequest	true	"New Template name"
+//	@Success		200			{object}	schemas.CloneTemplateResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/clone [post]
+func makeCloneTemplateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.CloneTemplateRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		id, err := s.CloneTemplate(ctx, req.TemplateID, req.TemplateName)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.CloneTemplateResponse{TemplateID: id}, nil
+	}
+}
+
+// makeDiffTemplatesEndpoint constructs a DiffTemplates endpoint wrapping the service.
+//
+//	@Summary		Compare Templates
+//	@Description	List products only in Template A, products only in Template B and products whose quantities differ
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			a				query		int		true	"First Template ID"
+//	@Param			b				query		int		true	"Second Template ID"
+//	@Param			lang			query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language	header		string	false	"Preferred languages"
+//	@Success		200				{object}	schemas.DiffTemplatesResponse
+//	@Failure		400				{object}	schemas.ErrorResponse
+//	@Failure		404				{object}	schemas.ErrorResponse
+//	@Failure		500				{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/diff [get]
+func makeDiffTemplatesEndpoint(s service.Service, mapper *schemas.TemplateMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.DiffTemplatesRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		diff, err := s.DiffTemplates(ctx, req.A, req.B, models.TemplateQuery{Locales: req.Locales})
+		if err != nil {
+			return nil, err
+		}
+
+		// Строки, которые есть только в одном из шаблонов, мапятся как состав шаблона, чтобы продукты выглядели одинаково
+		onlyInA := mapper.ToSchema(models.Template{Content: diff.OnlyInA}).Content
+		onlyInB := mapper.ToSchema(models.Template{Content: diff.OnlyInB}).Content
+		changed := make([]schemas.TemplateLineDiffSchema, len(diff.Changed))
+		for i, line := range diff.Changed {
+			changed[i] = schemas.TemplateLineDiffSchema{
+				ProductID: line.ProductID,
+				QuantityA: line.QuantityA,
+				QuantityB: line.QuantityB,
+				Delta:     line.QuantityB - line.QuantityA,
+			}
+			if line.Product != nil {
+				product := mapper.ProductMapper.ToSchema(*line.Product)
+				changed[i].Product = &product
+			}
+		}
+
+		return schemas.DiffTemplatesResponse{
+			A:       mapper.ToSchema(diff.A),
+			B:       mapper.ToSchema(diff.B),
+			OnlyInA: onlyInA,
+			OnlyInB: onlyInB,
+			Changed: changed,
+		}, nil
+	}
+}
+
+// makeBuildPickListEndpoint constructs a BuildPickList endpoint wrapping the service.
+//
+//	@Summary		Build pick list
+//	@Description	Merge several Templates, each taken the given number of times, into one list of product quantities
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			format			query		string							false	"Response format: json (default) or csv"
+//	@Param			lang			query		string							false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language	header		string							false	"Preferred languages"
+//	@Param			PickList		body		schemas.BuildPickListRequest	true	"Templates with multipliers"
+//	@Success		200				{object}	schemas.BuildPickListResponse
+//	@Failure		400				{object}	schemas.ErrorResponse
+//	@Failure		404				{object}	schemas.ErrorResponse
+//	@Failure		500				{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/pick-list [post]
+func makeBuildPickListEndpoint(s service.Service, mapper *schemas.PickListItemMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.BuildPickListRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		entries := make([]models.PickListEntry, len(req.Templates))
+		for i, entry := range req.Templates {
+			entries[i] = models.PickListEntry{TemplateID: entry.TemplateID, Multiplier: entry.Multiplier}
+		}
+		items, err := s.BuildPickList(ctx, entries, models.PickListQuery{SortBy: req.SortBy, Locales: req.Locales})
+		if err != nil {
+			return nil, err
+		}
+
+		itemSchemas := make([]schemas.PickListItemSchema, len(items))
+		for i, item := range items {
+			itemSchemas[i] = mapper.ToSchema(item)
+		}
+		return schemas.BuildPickListResponse{Items: itemSchemas, Format: req.Format}, nil
+	}
+}
+
+// makeExportTemplatesEndpoint constructs a ExportTemplates endpoint wrapping the service.
+//
+//	@Summary		Export Templates
+//	@Description	Export the given Templates, or all Templates when no ID is given, to a self-contained document
+//	@Description	that references products by SKU, to be imported into another database
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Produce		application/yaml
+//	@Param			id		query		[]int	false	"Template IDs; all Templates when omitted"	collectionFormat(multi)
+//	@Param			format	query		string	false	"Document format: json (default) or yaml"
+//	@Success		200		{object}	schemas.TemplateDocumentSchema
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		404		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/export [get]
+func makeExportTemplatesEndpoint(s service.Service, mapper *schemas.PortableTemplateMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.ExportTemplatesRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		templates, err := s.ExportTemplates(ctx, req.TemplateIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		templateSchemas := make([]schemas.PortableTemplateSchema, len(templates))
+		for i, template := range templates {
+			templateSchemas[i] = mapper.ToSchema(template)
+		}
+		document := schemas.TemplateDocumentSchema{FormatVersion: schemas.TemplateDocumentVersion, Templates: templateSchemas}
+		return schemas.ExportTemplatesResponse{Document: document, Format: req.Format}, nil
+	}
+}
+
+// makeImportTemplatesEndpoint constructs a ImportTemplates endpoint wrapping the service.
+//
+//	@Summary		Import Templates
+//	@Description	Create or replace Templates, matched by name, from a document produced by export.
+//	@Description	Products are resolved by SKU; if any SKU is missing or any Template is invalid, nothing is written.
+//	@Description	Discontinued products may stay in a Template they are already part of, as with a full Template update.
+//	@Tags			Templates
+//	@Accept			json
+//	@Accept			application/yaml
+//	@Produce		json
+//	@Param			Document	body		schemas.TemplateDocumentSchema	true	"Templates to import"
+//	@Param			X-User		header		string							false	"Author recorded in the Template revisions"
+//	@Success		200			{object}	schemas.ImportTemplatesResponse
+//	@Failure		400			{object}	schemas.DetailedErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/import [post]
+func makeImportTemplatesEndpoint(s service.Service, mapper *schemas.PortableTemplateMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.ImportTemplatesRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		templates := make([]models.PortableTemplate, len(req.Document.Templates))
+		for i, template := range req.Document.Templates {
+			templates[i] = mapper.ToModel(template)
+		}
+		results, err := s.ImportTemplates(ctx, templates)
+		if err != nil {
+			return nil, err
+		}
+
+		imported := make([]schemas.ImportedTemplateSchema, len(results))
+		for i, result := range results {
+			imported[i] = schemas.ImportedTemplateSchema{
+				ID:           result.TemplateID,
+				TemplateName: result.TemplateName,
+				Version:      result.Version,
+				Created:      result.Created,
+			}
+		}
+		return schemas.ImportTemplatesResponse{Templates: imported}, nil
+	}
+}
+
+// makeAddTemplateLineEndpoint constructs a AddTemplateLine endpoint wrapping the service.
+//
+//	@Summary		Add product to Template
+//	@Description	Add a line with an active product to a Template
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int								true	"Template ID"
+//	@Param			If-Match	header		string							true	"ETag of the Template version being changed, or * to skip the check"
+//	@Param			Line		body		schemas.AddTemplateLineRequest	true	"Template line"
+//	@Success		200			{object}	schemas.TemplateLineResponse
+//	@Header			200			{string}	ETag	"New Template version"
+//	@Failure		400			{object}	schemas.DetailedErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		428			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/content [post]
+func makeAddTemplateLineEndpoint(s service.Service, mapper *schemas.TemplateContentMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.AddTemplateLineRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		version, err := s.AddTemplateLine(ctx, req.TemplateID, mapper.ToModel(req.Content), req.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.TemplateLineResponse{Version: version}, nil
+	}
+}
+
+// makeUpdateTemplateLineEndpoint constructs a UpdateTemplateLine endpoint wrapping the service.
+//
+//	@Summary		Change product quantity in Template
+//	@Description	Change the quantity of a product already in a Template
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int									true	"Template ID"
+//	@Param			productID	path		int									true	"Product ID"
+//	@Param			If-Match	header		string								true	"ETag of the Template version being changed, or * to skip the check"
+//	@Param			Line		body		schemas.UpdateTemplateLineRequest	true	"New quantity"
+//	@Success		200			{object}	schemas.TemplateLineResponse
+//	@Header			200			{string}	ETag	"New Template version"
+//	@Failure		400			{object}	schemas.DetailedErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		428			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/content/{productID} [put]
+func makeUpdateTemplateLineEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.UpdateTemplateLineRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		line := models.TemplateContent{ProductID: req.ProductID, Quantity: req.Quantity, Unit: req.Unit}
+		version, err := s.UpdateTemplateLine(ctx, req.TemplateID, line, req.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.TemplateLineResponse{Version: version}, nil
+	}
+}
+
+// makeRemoveTemplateLineEndpoint constructs a RemoveTemplateLine endpoint wrapping the service.
+//
+//	@Summary		Remove product from Template
+//	@Description	Remove the line with a product from a Template
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Template ID"
+//	@Param			productID	path		int		true	"Product ID"
+//	@Param			If-Match	header		string	true	"ETag of the Template version being changed, or * to skip the check"
+//	@Success		200			{object}	schemas.TemplateLineResponse
+//	@Header			200			{string}	ETag	"New Template version"
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		428			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/content/{productID} [delete]
+func makeRemoveTemplateLineEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.RemoveTemplateLineRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		version, err := s.RemoveTemplateLine(ctx, req.TemplateID, req.ProductID, req.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.TemplateLineResponse{Version: version}, nil
+	}
+}
+
 // makeGetTemplateByIDEndpoint constructs a GetTemplateByID endpoint wrapping the service.
 //
 //	@Summary		Get Template by ID
@@ -188,7 +760,17 @@ func makeAddTemplateEndpoint(s service.Service, mapper *schemas.TemplateMapper)
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		int	true	"Template ID"
+//	@Param			expand			query		string	false	"Set to products to inline product details and template totals"	Enums(products)
+//	@Param			price_list		query		string	false	"Price list for expanded products (base price list by default)"
+//	@Param			lang			query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language	header		string	false	"Preferred languages"
+//	@Param			If-None-Match		header		string	false	"ETag of the cached template"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of the cached template"
 //	@Success		200		{object}	schemas.GetTemplateByIDResponse
+//	@Header			200		{string}	ETag			"Template version, or weak tag of the contents with expand=products"
+//	@Header			200		{string}	Last-Modified	"Time of the latest template or expanded product change"
+//	@Success		304		"Cached template is up to date"
+//	@Failure		400		{object}	schemas.ErrorResponse
 //	@Failure		404		{object}	schemas.ErrorResponse
 //	@Failure		500		{object}	schemas.ErrorResponse
 //	@Router			/api/v1/product/template/{id} [get]
@@ -199,13 +781,181 @@ func makeGetTemplateByIDEndpoint(s service.Service, mapper *schemas.TemplateMapp
 			return nil, myerr.Validation(invalidRequestType, err)
 		}
 
-		Template, err := s.GetTemplateByID(ctx, req.TemplateID)
+		query := models.TemplateQuery{
+			Locales:        req.Locales,
+			ExpandProducts: slices.Contains(req.Expand, schemas.ExpandProducts),
+			PriceList:      req.PriceList,
+		}
+		Template, err := s.GetTemplateByID(ctx, req.TemplateID, query)
 		if err != nil {
 			return nil, err
 		}
 
 		TemplateSchema := mapper.ToSchema(Template)
-		return schemas.GetTemplateByIDResponse{Template: TemplateSchema}, nil
+		return schemas.GetTemplateByIDResponse{Template: TemplateSchema, Modified: Template.LastModified(), Locales: req.Locales}, nil
+	}
+}
+
+// makeGetTemplateTotalEndpoint constructs a GetTemplateTotal endpoint wrapping the service.
+//
+//	@Summary		Get Template total
+//	@Description	Calculate the Template total against the chosen price list
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Template ID"
+//	@Param			price_list	query		string	false	"Price list name (base price list by default)"
+//	@Success		200			{object}	schemas.GetTemplateTotalResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/total [get]
+func makeGetTemplateTotalEndpoint(s service.Service, mapper *schemas.TemplateTotalMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.GetTemplateTotalRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		total, err := s.GetTemplateTotal(ctx, req.TemplateID, req.PriceList)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.GetTemplateTotalResponse{Total: mapper.ToSchema(total)}, nil
+	}
+}
+
+// makeScaleTemplateEndpoint constructs a ScaleTemplate endpoint wrapping the service.
+//
+//	@Summary		Scale Template
+//	@Description	Get Template quantities scaled from its base passenger count to the given one, or by a factor.
+//	@Description	Quantities are rounded up to whole units, or to whole packages of the packaging marked roundScaled.
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int		true	"Template ID"
+//	@Param			passengers		query		int		false	"Passenger count to scale to"
+//	@Param			factor			query		number	false	"Scale factor, used instead of passengers"
+//	@Param			lang			query		string	false	"Language code, takes precedence over Accept-Language"
+//	@Param			Accept-Language	header		string	false	"Preferred languages"
+//	@Success		200				{object}	schemas.ScaleTemplateResponse
+//	@Failure		400				{object}	schemas.ErrorResponse
+//	@Failure		404				{object}	schemas.ErrorResponse
+//	@Failure		500				{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/scaled [get]
+func makeScaleTemplateEndpoint(s service.Service, mapper *schemas.TemplateMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.ScaleTemplateRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		scale := models.TemplateScale{Passengers: req.Passengers, Factor: req.Factor}
+		template, err := s.ScaleTemplate(ctx, req.TemplateID, scale, models.TemplateQuery{Locales: req.Locales})
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.ScaleTemplateResponse{Template: mapper.ToSchema(template)}, nil
+	}
+}
+
+// makeListTemplateRevisionsEndpoint constructs a ListTemplateRevisions endpoint wrapping the service.
+//
+//	@Summary		List Template revisions
+//	@Description	List the revisions recorded on every change of a Template, newest first, without their contents
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Template ID"
+//	@Success		200	{object}	schemas.ListTemplateRevisionsResponse
+//	@Failure		400	{object}	schemas.ErrorResponse
+//	@Failure		404	{object}	schemas.ErrorResponse
+//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/revisions [get]
+func makeListTemplateRevisionsEndpoint(s service.Service, mapper *schemas.TemplateRevisionMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.ListTemplateRevisionsRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		revisions, err := s.ListTemplateRevisions(ctx, req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+
+		revisionSchemas := make([]schemas.TemplateRevisionSchema, len(revisions))
+		for i, revision := range revisions {
+			revisionSchemas[i] = mapper.ToSchema(revision)
+		}
+		return schemas.ListTemplateRevisionsResponse{Revisions: revisionSchemas}, nil
+	}
+}
+
+// makeGetTemplateRevisionEndpoint constructs a GetTemplateRevision endpoint wrapping the service.
+//
+//	@Summary		Get Template revision
+//	@Description	Get a single revision of a Template with the contents it recorded
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int	true	"Template ID"
+//	@Param			revision	path		int	true	"Revision number"
+//	@Success		200			{object}	schemas.GetTemplateRevisionResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/revisions/{revision} [get]
+func makeGetTemplateRevisionEndpoint(s service.Service, mapper *schemas.TemplateRevisionMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.GetTemplateRevisionRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		revision, err := s.GetTemplateRevision(ctx, req.TemplateID, req.Revision)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.GetTemplateRevisionResponse{Revision: mapper.ToSchema(revision)}, nil
+	}
+}
+
+// makeRestoreTemplateRevisionEndpoint constructs a RestoreTemplateRevision endpoint wrapping the service.
+//
+//	@Summary		Restore Template revision
+//	@Description	Make a past revision the current state of a Template; the restore is recorded as a new revision
+//	@Tags			Templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Template ID"
+//	@Param			revision	path		int		true	"Revision number"
+//	@Param			If-Match	header		string	true	"Current Template ETag, or * to restore unconditionally"
+//	@Param			X-User		header		string	false	"Author recorded in the new revision"
+//	@Success		200			{object}	schemas.RestoreTemplateRevisionResponse
+//	@Header			200			{string}	ETag	"New Template version"
+//	@Failure		400			{object}	schemas.DetailedErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		428			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/template/{id}/revisions/{revision}/restore [post]
+func makeRestoreTemplateRevisionEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.RestoreTemplateRevisionRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		version, err := s.RestoreTemplateRevision(ctx, req.TemplateID, req.Revision, req.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.RestoreTemplateRevisionResponse{Version: version}, nil
 	}
 }
 
@@ -246,11 +996,17 @@ func makeCreateProductEndpoint(s service.Service, mapper *schemas.ProductMapper)
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
-//	@Param			product	body		schemas.UpdateProductRequest	true	"Product details"
-//	@Success		200		{object}	schemas.UpdateProductResponse
-//	@Failure		400		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
-//	@Router			/api/v1/product [put]
+//	@Param			id			path		int								true	"Product ID"
+//	@Param			If-Match	header		string							true	"ETag of the product version being updated, or * to skip the check"
+//	@Param			product		body		schemas.UpdateProductRequest	true	"Product details"
+//	@Success		200			{object}	schemas.UpdateProductResponse
+//	@Header			200			{string}	ETag	"New product version"
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		428			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/{id} [put]
 func makeUpdateProductEndpoint(s service.Service, mapper *schemas.ProductMapper) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, err := castRequest[*schemas.UpdateProductRequest](request)
@@ -259,13 +1015,14 @@ func makeUpdateProductEndpoint(s service.Service, mapper *schemas.ProductMapper)
 		}
 
 		productModel := mapper.ToModel(req.Product)
+		productModel.Version = req.Version
 
 		err = s.UpdateProduct(ctx, &productModel)
 		if err != nil {
 			return nil, err
 		}
 
-		return schemas.UpdateProductResponse{}, nil
+		return schemas.UpdateProductResponse{Version: productModel.Version}, nil
 	}
 }
 
@@ -276,10 +1033,13 @@ func makeUpdateProductEndpoint(s service.Service, mapper *schemas.ProductMapper)
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int	true	"Product ID"
-//	@Success		200	{object}	schemas.DeleteProductResponse
-//	@Failure		404	{object}	schemas.ErrorResponse
-//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Param			id			path		int		true	"Product ID"
+//	@Param			If-Match	header		string	true	"ETag of the product version being deleted, or * to skip the check"
+//	@Success		200			{object}	schemas.DeleteProductResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		428			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
 //	@Router			/api/v1/product/{id} [delete]
 func makeDeleteProductEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -288,7 +1048,7 @@ func makeDeleteProductEndpoint(s service.Service) endpoint.Endpoint {
 			return nil, myerr.Validation(invalidRequestType, err)
 		}
 
-		err = s.DeleteProduct(ctx, req.ProductID)
+		err = s.DeleteProduct(ctx, req.ProductID, req.Version)
 		if err != nil {
 			return nil, err
 		}
@@ -296,3 +1056,149 @@ func makeDeleteProductEndpoint(s service.Service) endpoint.Endpoint {
 		return schemas.DeleteProductResponse{}, nil
 	}
 }
+
+// makeUploadProductImageEndpoint constructs a UploadProductImage endpoint wrapping the service.
+//
+//	@Summary		Upload product image
+//	@Description	Upload a JPEG or PNG image of a product (up to 5 MiB). A thumbnail is generated and the product image URLs are updated.
+//	@Tags			products
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		int		true	"Product ID"
+//	@Param			image	formData	file	true	"Image file"
+//	@Success		200		{object}	schemas.UploadProductImageResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		404		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/{id}/image [post]
+func makeUploadProductImageEndpoint(s service.Service, mapper *schemas.ProductImageMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.UploadProductImageRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		image, err := s.UploadProductImage(ctx, req.ProductID, models.Blob{ContentType: req.ContentType, Data: req.Data})
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.UploadProductImageResponse{Image: mapper.ToSchema(image)}, nil
+	}
+}
+
+// makeGetImageEndpoint constructs a GetImage endpoint wrapping the service.
+//
+//	@Summary		Get image
+//	@Description	Get a stored product image or thumbnail by its file name
+//	@Tags			products
+//	@Produce		image/jpeg,image/png
+//	@Param			name	path		string	true	"Image file name"
+//	@Success		200		{file}		file
+//	@Failure		404		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/image/{name} [get]
+func makeGetImageEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.GetImageRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		blob, err := s.GetImage(ctx, req.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.GetImageResponse{ContentType: blob.ContentType, Data: blob.Data}, nil
+	}
+}
+
+// makeListPriceListsEndpoint constructs a ListPriceLists endpoint wrapping the service.
+//
+//	@Summary		List price lists
+//	@Description	Get all price lists with their currencies
+//	@Tags			price lists
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.ListPriceListsResponse
+//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/price-list [get]
+func makeListPriceListsEndpoint(s service.Service, mapper *schemas.PriceListMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		priceLists, err := s.ListPriceLists(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		priceListsSchema := make([]schemas.PriceListSchema, len(priceLists))
+		for i, priceList := range priceLists {
+			priceListsSchema[i] = mapper.ToSchema(priceList)
+		}
+		return schemas.ListPriceListsResponse{PriceLists: priceListsSchema}, nil
+	}
+}
+
+// makeCreatePriceListEndpoint constructs a CreatePriceList endpoint wrapping the service.
+//
+//	@Summary		Add price list
+//	@Description	Add a new named price list with its currency
+//	@Tags			price lists
+//	@Accept			json
+//	@Produce		json
+//	@Param			priceList	body		schemas.CreatePriceListRequest	true	"Price list details"
+//	@Success		200			{object}	schemas.CreatePriceListResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/price-list [post]
+func makeCreatePriceListEndpoint(s service.Service, mapper *schemas.PriceListMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.CreatePriceListRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		priceListModel := mapper.ToModel(req.PriceList)
+
+		id, err := s.CreatePriceList(ctx, &priceListModel)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.CreatePriceListResponse{PriceListID: id}, nil
+	}
+}
+
+// makeSetPriceListPricesEndpoint constructs a SetPriceListPrices endpoint wrapping the service.
+//
+//	@Summary		Set price list prices
+//	@Description	Insert or update product prices in a price list
+//	@Tags			price lists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int									true	"Price list ID"
+//	@Param			prices	body		schemas.SetPriceListPricesRequest	true	"Product prices"
+//	@Success		200		{object}	schemas.SetPriceListPricesResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		404		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/api/v1/product/price-list/{id}/prices [put]
+func makeSetPriceListPricesEndpoint(s service.Service, mapper *schemas.ProductPriceMapper) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := castRequest[*schemas.SetPriceListPricesRequest](request)
+		if err != nil {
+			return nil, myerr.Validation(invalidRequestType, err)
+		}
+
+		prices := make([]models.ProductPrice, len(req.Prices))
+		for i, price := range req.Prices {
+			prices[i] = mapper.ToModel(price)
+		}
+
+		if err = s.SetPriceListPrices(ctx, req.PriceListID, prices); err != nil {
+			return nil, err
+		}
+
+		return schemas.SetPriceListPricesResponse{}, nil
+	}
+}
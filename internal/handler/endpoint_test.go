@@ -23,7 +23,7 @@ func TestMakeEndpoints(t *testing.T) {
 	mockSvc := mocks.NewMockService(t)
 	logger := log.NewNopLogger()
 
-	mockSvc.EXPECT().GetAllProducts(context.Background()).Return([]models.Product{}, nil)
+	mockSvc.EXPECT().GetAllProducts(context.Background(), models.ProductQuery{}).Return([]models.Product{}, nil)
 
 	endpoints := MakeEndpoints(logger, mockSvc)
 
@@ -55,7 +55,7 @@ func TestMakeGetAllProductsEndpointSuccess(t *testing.T) {
 		{ID: 1, Name: "Milk"},
 		{ID: 2, Name: "Chocolate"},
 	}
-	mockSvc.EXPECT().GetAllProducts(context.Background()).Return(products, nil)
+	mockSvc.EXPECT().GetAllProducts(context.Background(), models.ProductQuery{}).Return(products, nil)
 
 	mockProductMapper := schemas.NewProductMapper()
 	mockProductsMapper := schemas.NewProductsMapper(mockProductMapper)
@@ -81,7 +81,7 @@ func TestMakeGetAllProductsEndpointSuccess(t *testing.T) {
 func TestMakeGetAllProductsEndpointFailed(t *testing.T) {
 	mockSvc := mocks.NewMockService(t)
 	errMsg := "Database is unreachable!"
-	mockSvc.EXPECT().GetAllProducts(context.Background()).Return(nil, errors.New(errMsg))
+	mockSvc.EXPECT().GetAllProducts(context.Background(), models.ProductQuery{}).Return(nil, errors.New(errMsg))
 
 	mockProductMapper := schemas.NewProductMapper()
 	mockProductsMapper := schemas.NewProductsMapper(mockProductMapper)
@@ -102,7 +102,7 @@ func TestMakeGetAllProductsEndpointFailed(t *testing.T) {
 func TestMakeGetProductByIDEndpointSuccess(t *testing.T) {
 	mockSvc := mocks.NewMockService(t)
 	product := models.Product{ID: 33, Name: "Doshirak"}
-	mockSvc.EXPECT().GetProductByID(context.Background(), int64(33)).Return(product, nil)
+	mockSvc.EXPECT().GetProductByID(context.Background(), int64(33), models.ProductQuery{}).Return(product, nil)
 
 	mockProductMapper := schemas.NewProductMapper()
 
@@ -145,7 +145,7 @@ func TestMakeGetProductByIDEndpointCastFailed(t *testing.T) {
 func TestMakeGetProductByIDEndpointServiceFailed(t *testing.T) {
 	mockSvc := mocks.NewMockService(t)
 	errMsg := "ID not found"
-	mockSvc.EXPECT().GetProductByID(context.Background(), int64(5)).Return(models.Product{}, errors.New(errMsg))
+	mockSvc.EXPECT().GetProductByID(context.Background(), int64(5), models.ProductQuery{}).Return(models.Product{}, errors.New(errMsg))
 	mockProductMapper := schemas.NewProductMapper()
 
 	ep := makeGetProductByIDEndpoint(mockSvc, mockProductMapper)
@@ -175,7 +175,7 @@ func TestMakeSearchTemplatesEndpointSuccess(t *testing.T) {
 			},
 		},
 	}
-	mockSvc.EXPECT().SearchTemplates(context.Background(), string("Default"), int64(10), int64(0)).Return(templates, nil)
+	mockSvc.EXPECT().SearchTemplates(context.Background(), string("Default"), int64(10), int64(0), models.TemplateQuery{}).Return(templates, nil)
 	mockTemplateMapper := schemas.NewTemplateMapper(schemas.NewTemplateContentMapper(), schemas.NewProductMapper())
 	mockTemplatesMapper := schemas.NewTemplatesMapper(mockTemplateMapper)
 
@@ -221,7 +221,7 @@ func TestMakeSearchTemplatesEndpointCastFailed(t *testing.T) {
 func TestMakeSearchTemplatesEndpointServiceFailed(t *testing.T) {
 	mockSvc := mocks.NewMockService(t)
 	errMsg := "No such templates!"
-	mockSvc.EXPECT().SearchTemplates(context.Background(), string("Default"), int64(10), int64(0)).Return([]models.Template{}, errors.New(errMsg))
+	mockSvc.EXPECT().SearchTemplates(context.Background(), string("Default"), int64(10), int64(0), models.TemplateQuery{}).Return([]models.Template{}, errors.New(errMsg))
 	mockTemplateMapper := schemas.NewTemplateMapper(schemas.NewTemplateContentMapper(), schemas.NewProductMapper())
 	mockTemplatesMapper := schemas.NewTemplatesMapper(mockTemplateMapper)
 
@@ -348,7 +348,7 @@ func TestMakeGetTemplateByIDEndpointSuccess(t *testing.T) {
 			{ProductID: 5, Quantity: 10},
 		},
 	}
-	mockSvc.EXPECT().GetTemplateByID(context.Background(), int64(33)).Return(template, nil)
+	mockSvc.EXPECT().GetTemplateByID(context.Background(), int64(33), models.TemplateQuery{}).Return(template, nil)
 	mockProductMapper := schemas.NewProductMapper()
 	mockContentMapper := schemas.NewTemplateContentMapper()
 	mockTemplateMapper := schemas.NewTemplateMapper(mockContentMapper, mockProductMapper)
@@ -394,7 +394,7 @@ func TestMakeGetTemplateByIDEndpointCastFailed(t *testing.T) {
 func TestMakeGetTemplateByIDEndpointServiceFailed(t *testing.T) {
 	mockSvc := mocks.NewMockService(t)
 	errMsg := "ID not found"
-	mockSvc.EXPECT().GetTemplateByID(context.Background(), int64(51)).Return(models.Template{}, errors.New(errMsg))
+	mockSvc.EXPECT().GetTemplateByID(context.Background(), int64(51), models.TemplateQuery{}).Return(models.Template{}, errors.New(errMsg))
 	mockProductMapper := schemas.NewProductMapper()
 	mockContentMapper := schemas.NewTemplateContentMapper()
 	mockTemplateMapper := schemas.NewTemplateMapper(mockContentMapper, mockProductMapper)
@@ -419,7 +419,7 @@ func TestMakeCreateProductEndpointSuccess(t *testing.T) {
 		ID:          1,
 		Name:        "Updated Product",
 		Description: "Updated Description",
-		Price:       89.99,
+		Price:       models.Money(8999),
 		ImageURL:    "http://example.com/updatedimage.png",
 	}
 	mockProductMapper := schemas.NewProductMapper()
@@ -475,7 +475,7 @@ func TestMakeCreateProductEndpointServiceFailed(t *testing.T) {
 		ID:          1,
 		Name:        "Updated Product",
 		Description: "Updated Description",
-		Price:       89.99,
+		Price:       models.Money(8999),
 		ImageURL:    "http://example.com/updatedimage.png",
 	}
 	expectedModel := models.Product{
@@ -581,8 +581,9 @@ func TestMakeDeleteProductEndpointSuccess(t *testing.T) {
 	mockSvc := mocks.NewMockService(t)
 	reqDelete := &schemas.DeleteProductRequest{
 		ProductID: 10,
+		Version:   7,
 	}
-	mockSvc.EXPECT().DeleteProduct(context.Background(), int64(10)).Return(nil)
+	mockSvc.EXPECT().DeleteProduct(context.Background(), int64(10), int64(7)).Return(nil)
 
 	ep := makeDeleteProductEndpoint(mockSvc)
 	resp, err := ep(context.Background(), reqDelete)
@@ -622,7 +623,7 @@ func TestMakeDeleteProductEndpointServiceFailed(t *testing.T) {
 	reqDelete := &schemas.DeleteProductRequest{
 		ProductID: 19,
 	}
-	mockSvc.EXPECT().DeleteProduct(context.Background(), int64(19)).Return(errors.New(errMsg))
+	mockSvc.EXPECT().DeleteProduct(context.Background(), int64(19), int64(0)).Return(errors.New(errMsg))
 
 	ep := makeDeleteProductEndpoint(mockSvc)
 	resp, err := ep(context.Background(), reqDelete)
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
 )
 
 // LoggingMiddleware расширенный вариант
@@ -54,3 +58,25 @@ func HeaderMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// authorHeader — заголовок, в котором шлюз передает пользователя, выполняющего запрос.
+const authorHeader = "X-User"
+
+// maxAuthorLength — длина столбца author в таблице ревизий шаблонов.
+const maxAuthorLength = 255
+
+// AuthorMiddleware переносит автора запроса из заголовка X-User в контекст, чтобы он попал в ревизии шаблонов.
+// Сервис не проверяет подлинность пользователя: заголовок выставляет шлюз перед сервисом.
+func AuthorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		author := strings.TrimSpace(r.Header.Get(authorHeader))
+		if author == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if utf8.RuneCountInString(author) > maxAuthorLength {
+			author = string([]rune(author)[:maxAuthorLength])
+		}
+		next.ServeHTTP(w, r.WithContext(models.WithAuthor(r.Context(), author)))
+	})
+}
@@ -15,4 +15,13 @@ type DetailedErrorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Reason  string `json:"reason,omitempty"` // Дополнительное объяснение
+	// Errors перечисляет все проблемы с полями запроса, найденные при проверке
+	Errors []FieldErrorSchema `json:"errors,omitempty"`
+}
+
+// FieldErrorSchema описывает проблему с одним полем запроса
+type FieldErrorSchema struct {
+	Field   string `json:"field" example:"content[2].quantity"`
+	Index   *int   `json:"index,omitempty" example:"2"` // Индекс элемента списка (например, строки шаблона), если поле принадлежит ему
+	Message string `json:"message"`
 }
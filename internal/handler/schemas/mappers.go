@@ -18,23 +18,199 @@ func NewProductMapper() *ProductMapper {
 }
 
 func (pm *ProductMapper) ToSchema(product models.Product) ProductSchema {
+	breakdown := product.PriceBreakdown()
+	var variants []ProductSchema
+	if len(product.Variants) > 0 {
+		variants = make([]ProductSchema, len(product.Variants))
+		for i, variant := range product.Variants {
+			variants[i] = pm.ToSchema(variant)
+		}
+	}
 	return ProductSchema{
-		ID:          product.ID,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		ImageURL:    product.ImageURL,
+		ID:           product.ID,
+		Name:         product.Name,
+		Description:  product.Description,
+		Price:        product.Price,
+		Currency:     product.Currency,
+		VATRate:      product.VATRate,
+		NetPrice:     breakdown.Net,
+		VATAmount:    breakdown.VAT,
+		GrossPrice:   breakdown.Gross,
+		ImageURL:     product.ImageURL,
+		ThumbnailURL: product.ThumbnailURL,
+		ParentID:     product.ParentID,
+		Options:      product.Options,
+		Barcodes:     product.Barcodes,
+		Unit:         product.Unit,
+		Packaging:    packagingToSchemas(product.Packaging),
+		Nutrition:    (*NutritionSchema)(product.Nutrition),
+		Allergens:    allergensToStrings(product.Allergens),
+		Translations: translationsToSchemas(product.Translations),
+		Status:       string(product.Status),
+		Availability: availabilityToSchema(product.Availability),
+		Variants:     variants,
+		Version:      product.Version,
 	}
 }
 
 // ToModel преобразует schemas.ProductSchema в models.Product.
 func (pm *ProductMapper) ToModel(productSchema ProductSchema) models.Product {
 	return models.Product{
-		ID:          productSchema.ID,
-		Name:        productSchema.Name,
-		Description: productSchema.Description,
-		Price:       productSchema.Price,
-		ImageURL:    productSchema.ImageURL,
+		ID:           productSchema.ID,
+		Name:         productSchema.Name,
+		Description:  productSchema.Description,
+		Price:        productSchema.Price,
+		Currency:     productSchema.Currency,
+		VATRate:      productSchema.VATRate,
+		ImageURL:     productSchema.ImageURL,
+		ThumbnailURL: productSchema.ThumbnailURL,
+		ParentID:     productSchema.ParentID,
+		Options:      productSchema.Options,
+		Barcodes:     productSchema.Barcodes,
+		Unit:         productSchema.Unit,
+		Packaging:    packagingToModels(productSchema.Packaging),
+		Nutrition:    (*models.Nutrition)(productSchema.Nutrition),
+		Allergens:    AllergensToModels(productSchema.Allergens),
+		Translations: translationsToModels(productSchema.Translations),
+		Status:       models.ProductStatus(productSchema.Status),
+		Availability: availabilityToModel(productSchema.Availability),
+	}
+}
+
+// availabilityToSchema преобразует правила доступности продукта в схему; отсутствие правил дает nil.
+func availabilityToSchema(a models.Availability) *AvailabilitySchema {
+	if a.IsZero() {
+		return nil
+	}
+	schema := &AvailabilitySchema{
+		Routes:  AccessListSchema(a.Routes),
+		Regions: AccessListSchema(a.Regions),
+	}
+	for _, w := range a.Windows {
+		days := make([]string, len(w.Days))
+		for i, d := range w.Days {
+			days[i] = string(d)
+		}
+		schema.Windows = append(schema.Windows, TimeWindowSchema{Days: days, Start: w.Start, End: w.End})
+	}
+	for _, p := range a.Periods {
+		schema.Periods = append(schema.Periods, DateRangeSchema(p))
+	}
+	return schema
+}
+
+// availabilityToModel преобразует схему правил доступности в модель.
+func availabilityToModel(schema *AvailabilitySchema) models.Availability {
+	if schema == nil {
+		return models.Availability{}
+	}
+	a := models.Availability{
+		Routes:  models.AccessList(schema.Routes),
+		Regions: models.AccessList(schema.Regions),
+	}
+	for _, w := range schema.Windows {
+		days := make([]models.Weekday, len(w.Days))
+		for i, d := range w.Days {
+			days[i] = models.Weekday(d)
+		}
+		a.Windows = append(a.Windows, models.TimeWindow{Days: days, Start: w.Start, End: w.End})
+	}
+	for _, p := range schema.Periods {
+		a.Periods = append(a.Periods, models.DateRange(p))
+	}
+	return a
+}
+
+// packagingToSchemas преобразует уровни упаковки продукта в схемы.
+func packagingToSchemas(packaging []models.Packaging) []PackagingSchema {
+	if packaging == nil {
+		return nil
+	}
+	result := make([]PackagingSchema, len(packaging))
+	for i, p := range packaging {
+		result[i] = PackagingSchema(p)
+	}
+	return result
+}
+
+// translationsToSchemas преобразует переводы названия и описания в схемы.
+func translationsToSchemas(translations map[string]models.Translation) map[string]TranslationSchema {
+	if translations == nil {
+		return nil
+	}
+	result := make(map[string]TranslationSchema, len(translations))
+	for locale, t := range translations {
+		result[locale] = TranslationSchema(t)
+	}
+	return result
+}
+
+// translationsToModels преобразует схемы переводов в модели.
+func translationsToModels(translations map[string]TranslationSchema) map[string]models.Translation {
+	if translations == nil {
+		return nil
+	}
+	result := make(map[string]models.Translation, len(translations))
+	for locale, t := range translations {
+		result[locale] = models.Translation(t)
+	}
+	return result
+}
+
+// allergensToStrings преобразует аллергены продукта в строки.
+func allergensToStrings(allergens []models.Allergen) []string {
+	if allergens == nil {
+		return nil
+	}
+	result := make([]string, len(allergens))
+	for i, a := range allergens {
+		result[i] = string(a)
+	}
+	return result
+}
+
+// AllergensToModels преобразует названия аллергенов из запроса в модели.
+func AllergensToModels(allergens []string) []models.Allergen {
+	if allergens == nil {
+		return nil
+	}
+	result := make([]models.Allergen, len(allergens))
+	for i, a := range allergens {
+		result[i] = models.Allergen(a)
+	}
+	return result
+}
+
+// packagingToModels преобразует схемы уровней упаковки в модели.
+func packagingToModels(packaging []PackagingSchema) []models.Packaging {
+	if packaging == nil {
+		return nil
+	}
+	result := make([]models.Packaging, len(packaging))
+	for i, p := range packaging {
+		result[i] = models.Packaging(p)
+	}
+	return result
+}
+
+// ProductImageMapper реализует интерфейс Mapper для ProductImage.
+type ProductImageMapper struct{}
+
+func NewProductImageMapper() *ProductImageMapper {
+	return &ProductImageMapper{}
+}
+
+func (pim *ProductImageMapper) ToSchema(image models.ProductImage) ProductImageSchema {
+	return ProductImageSchema{
+		ImageURL:     image.ImageURL,
+		ThumbnailURL: image.ThumbnailURL,
+	}
+}
+
+func (pim *ProductImageMapper) ToModel(imageSchema ProductImageSchema) models.ProductImage {
+	return models.ProductImage{
+		ImageURL:     imageSchema.ImageURL,
+		ThumbnailURL: imageSchema.ThumbnailURL,
 	}
 }
 
@@ -49,6 +225,7 @@ func (tcm *TemplateContentMapper) ToSchema(content models.TemplateContent) Templ
 	return TemplateContentSchema{
 		ProductID: content.ProductID,
 		Quantity:  content.Quantity,
+		Unit:      content.Unit,
 	}
 }
 
@@ -56,6 +233,111 @@ func (tcm *TemplateContentMapper) ToModel(contentSchema TemplateContentSchema) m
 	return models.TemplateContent{
 		ProductID: contentSchema.ProductID,
 		Quantity:  contentSchema.Quantity,
+		Unit:      contentSchema.Unit,
+	}
+}
+
+// PriceListMapper реализует интерфейс Mapper для PriceList.
+type PriceListMapper struct{}
+
+func NewPriceListMapper() *PriceListMapper {
+	return &PriceListMapper{}
+}
+
+func (plm *PriceListMapper) ToSchema(priceList models.PriceList) PriceListSchema {
+	return PriceListSchema{
+		ID:       priceList.ID,
+		Name:     priceList.Name,
+		Currency: priceList.Currency,
+	}
+}
+
+func (plm *PriceListMapper) ToModel(priceListSchema PriceListSchema) models.PriceList {
+	return models.PriceList{
+		ID:       priceListSchema.ID,
+		Name:     priceListSchema.Name,
+		Currency: priceListSchema.Currency,
+	}
+}
+
+// ProductPriceMapper реализует интерфейс Mapper для ProductPrice.
+type ProductPriceMapper struct{}
+
+func NewProductPriceMapper() *ProductPriceMapper {
+	return &ProductPriceMapper{}
+}
+
+func (ppm *ProductPriceMapper) ToSchema(price models.ProductPrice) ProductPriceSchema {
+	return ProductPriceSchema{
+		ProductID: price.ProductID,
+		Price:     price.Price,
+	}
+}
+
+func (ppm *ProductPriceMapper) ToModel(priceSchema ProductPriceSchema) models.ProductPrice {
+	return models.ProductPrice{
+		ProductID: priceSchema.ProductID,
+		Price:     priceSchema.Price,
+	}
+}
+
+// PickListItemMapper реализует интерфейс Mapper для PickListItem.
+type PickListItemMapper struct{}
+
+func NewPickListItemMapper() *PickListItemMapper {
+	return &PickListItemMapper{}
+}
+
+func (plm *PickListItemMapper) ToSchema(item models.PickListItem) PickListItemSchema {
+	return PickListItemSchema{
+		ProductID: item.Product.ID,
+		SKU:       item.Product.SKU,
+		Name:      item.Product.Name,
+		Unit:      item.Product.Unit,
+		Quantity:  item.Quantity,
+	}
+}
+
+func (plm *PickListItemMapper) ToModel(itemSchema PickListItemSchema) models.PickListItem {
+	return models.PickListItem{
+		Product: models.Product{
+			ID:   itemSchema.ProductID,
+			SKU:  itemSchema.SKU,
+			Name: itemSchema.Name,
+			Unit: itemSchema.Unit,
+		},
+		Quantity: itemSchema.Quantity,
+	}
+}
+
+// TemplateTotalMapper реализует интерфейс Mapper для TemplateTotal.
+type TemplateTotalMapper struct{}
+
+func NewTemplateTotalMapper() *TemplateTotalMapper {
+	return &TemplateTotalMapper{}
+}
+
+func (ttm *TemplateTotalMapper) ToSchema(total models.TemplateTotal) TemplateTotalSchema {
+	return TemplateTotalSchema{
+		TemplateID: total.TemplateID,
+		PriceList:  total.PriceList,
+		Currency:   total.Currency,
+		Items:      total.Items,
+		Net:        total.Net,
+		VAT:        total.VAT,
+		Total:      total.Total,
+	}
+}
+
+func (ttm *TemplateTotalMapper) ToModel(totalSchema TemplateTotalSchema) models.TemplateTotal {
+	return models.TemplateTotal{
+		TemplateID: totalSchema.TemplateID,
+		PriceList:  totalSchema.PriceList,
+		Currency:   totalSchema.Currency,
+		Items:      totalSchema.Items,
+		Net:        totalSchema.Net,
+		VAT:        totalSchema.VAT,
+		Total:      totalSchema.Total,
 	}
 }
 
@@ -76,12 +358,25 @@ func (tm *TemplateMapper) ToSchema(template models.Template) TemplateSchema {
 	contentSchemas := make([]TemplateContentSchema, len(template.Content))
 	for i, content := range template.Content {
 		contentSchemas[i] = tm.ContentMapper.ToSchema(content)
+		if content.Product != nil {
+			product := tm.ProductMapper.ToSchema(*content.Product)
+			contentSchemas[i].Product = &product
+		}
+	}
+	var total *TemplateTotalSchema
+	if template.Total != nil {
+		totalSchema := NewTemplateTotalMapper().ToSchema(*template.Total)
+		total = &totalSchema
 	}
 	return TemplateSchema{
 		ID:           template.ID,
 		TemplateName: template.TemplateName,
 		Description:  template.Description,
 		Content:      contentSchemas,
+		Translations: translationsToSchemas(template.Translations),
+		Passengers:   template.Passengers,
+		Version:      template.Version,
+		Total:        total,
 	}
 }
 
@@ -95,6 +390,77 @@ func (tm *TemplateMapper) ToModel(templateSchema TemplateSchema) models.Template
 		TemplateName: templateSchema.TemplateName,
 		Description:  templateSchema.Description,
 		Content:      contentModels,
+		Translations: translationsToModels(templateSchema.Translations),
+		Passengers:   templateSchema.Passengers,
+	}
+}
+
+// TemplateRevisionMapper реализует интерфейс Mapper для TemplateRevision.
+type TemplateRevisionMapper struct {
+	TemplateMapper Mapper[models.Template, TemplateSchema]
+}
+
+func NewTemplateRevisionMapper(templateMapper Mapper[models.Template, TemplateSchema]) *TemplateRevisionMapper {
+	return &TemplateRevisionMapper{
+		TemplateMapper: templateMapper,
+	}
+}
+
+func (trm *TemplateRevisionMapper) ToSchema(revision models.TemplateRevision) TemplateRevisionSchema {
+	return TemplateRevisionSchema{
+		Revision:  revision.Revision,
+		Author:    revision.Author,
+		CreatedAt: revision.CreatedAt,
+		Template:  trm.TemplateMapper.ToSchema(revision.Template),
+		Deleted:   revision.Deleted,
+	}
+}
+
+func (trm *TemplateRevisionMapper) ToModel(revisionSchema TemplateRevisionSchema) models.TemplateRevision {
+	template := trm.TemplateMapper.ToModel(revisionSchema.Template)
+	template.Version = revisionSchema.Revision
+	return models.TemplateRevision{
+		TemplateID: revisionSchema.Template.ID,
+		Revision:   revisionSchema.Revision,
+		Template:   template,
+		Author:     revisionSchema.Author,
+		CreatedAt:  revisionSchema.CreatedAt,
+		Deleted:    revisionSchema.Deleted,
+	}
+}
+
+// PortableTemplateMapper реализует интерфейс Mapper для PortableTemplate.
+type PortableTemplateMapper struct{}
+
+func NewPortableTemplateMapper() *PortableTemplateMapper {
+	return &PortableTemplateMapper{}
+}
+
+func (ptm *PortableTemplateMapper) ToSchema(template models.PortableTemplate) PortableTemplateSchema {
+	lines := make([]PortableTemplateLineSchema, len(template.Content))
+	for i, line := range template.Content {
+		lines[i] = PortableTemplateLineSchema(line)
+	}
+	return PortableTemplateSchema{
+		TemplateName: template.TemplateName,
+		Description:  template.Description,
+		Translations: translationsToSchemas(template.Translations),
+		Passengers:   template.Passengers,
+		Content:      lines,
+	}
+}
+
+func (ptm *PortableTemplateMapper) ToModel(templateSchema PortableTemplateSchema) models.PortableTemplate {
+	lines := make([]models.PortableTemplateLine, len(templateSchema.Content))
+	for i, line := range templateSchema.Content {
+		lines[i] = models.PortableTemplateLine(line)
+	}
+	return models.PortableTemplate{
+		TemplateName: templateSchema.TemplateName,
+		Description:  templateSchema.Description,
+		Translations: translationsToModels(templateSchema.Translations),
+		Passengers:   templateSchema.Passengers,
+		Content:      lines,
 	}
 }
 
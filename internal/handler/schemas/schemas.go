@@ -1,32 +1,151 @@
 package schemas
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	_ "github.com/Chaika-Team/ChaikaGoods/docs"
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
 )
 
 type ProductSchema struct {
-	ID          int64   `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	ImageURL    string  `json:"imageurl"`
+	ID           int64                        `json:"id"`
+	Name         string                       `json:"name"`
+	Description  string                       `json:"description"`
+	Price        models.Money                 `json:"price" swaggertype:"string" example:"149.99"`
+	Currency     string                       `json:"currency,omitempty" example:"RUB"`
+	VATRate      models.VATRate               `json:"vatRate" swaggertype:"string" enums:"20,10,0,exempt" example:"20"`
+	NetPrice     models.Money                 `json:"netPrice" swaggertype:"string" example:"124.99" readonly:"true"`
+	VATAmount    models.Money                 `json:"vatAmount" swaggertype:"string" example:"25.00" readonly:"true"`
+	GrossPrice   models.Money                 `json:"grossPrice" swaggertype:"string" example:"149.99" readonly:"true"`
+	ImageURL     string                       `json:"imageurl"`
+	ThumbnailURL string                       `json:"thumbnailurl"`
+	ParentID     int64                        `json:"parentID,omitempty"`                                    // ID родительского продукта, если это вариант
+	Options      map[string]string            `json:"options,omitempty" example:"flavor:green,volume:0.5 L"` // Значения опций варианта
+	Barcodes     []string                     `json:"barcodes,omitempty" example:"4600000000008"`            // Штрихкоды EAN-8, EAN-13 или GTIN-14
+	Unit         string                       `json:"unit,omitempty" example:"pcs"`                          // Базовая единица измерения, по умолчанию pcs
+	Packaging    []PackagingSchema            `json:"packaging,omitempty"`                                   // Уровни упаковки продукта
+	Nutrition    *NutritionSchema             `json:"nutrition,omitempty"`                                   // Пищевая ценность на 100 г
+	Allergens    []string                     `json:"allergens,omitempty" example:"gluten,lactose"`          // Аллергены, которые содержит продукт
+	Translations map[string]TranslationSchema `json:"translations,omitempty"`                                // Переводы названия и описания по кодам языков
+	Status       string                       `json:"status,omitempty" enums:"draft,active,discontinued"`    // Этап жизненного цикла; новые продукты по умолчанию черновики
+	Availability *AvailabilitySchema          `json:"availability,omitempty"`                                // Правила, когда и где продукт можно продавать
+	Variants     []ProductSchema              `json:"variants,omitempty" readonly:"true"`                    // Варианты родительского продукта
+	Version      int64                        `json:"version" readonly:"true"`                               // Версия строки, передается в If-Match при изменении
+}
+
+type PackagingSchema struct {
+	Name        string `json:"name" example:"box"`
+	Quantity    int    `json:"quantity" example:"24"` // Число базовых единиц в упаковке
+	RoundScaled bool   `json:"roundScaled,omitempty"` // Округлять масштабированные шаблоны вверх до целых упаковок этого уровня
+}
+
+type NutritionSchema struct {
+	Kcal          float64 `json:"kcal" example:"250"`
+	Proteins      float64 `json:"proteins" example:"7.5"`
+	Fats          float64 `json:"fats" example:"9"`
+	Carbohydrates float64 `json:"carbohydrates" example:"35.2"`
 }
 
 type TemplateSchema struct {
-	ID           int64                   `json:"id"`
-	TemplateName string                  `json:"templateName"`
-	Description  string                  `json:"description"`
-	Content      []TemplateContentSchema `json:"content"`
+	ID           int64                        `json:"id"`
+	TemplateName string                       `json:"templateName"`
+	Description  string                       `json:"description"`
+	Content      []TemplateContentSchema      `json:"content"`
+	Translations map[string]TranslationSchema `json:"translations,omitempty"`            // Переводы названия и описания по кодам языков
+	Passengers   int                          `json:"passengers,omitempty" example:"60"` // Базовое число пассажиров, на которое рассчитан состав
+	Version      int64                        `json:"version" readonly:"true"`           // Версия строки
+	Total        *TemplateTotalSchema         `json:"total,omitempty" readonly:"true"`   // Итоги шаблона, только при expand=products
+}
+
+type AvailabilitySchema struct {
+	Windows []TimeWindowSchema `json:"windows,omitempty"` // Окна продаж; продукт продается, если момент попадает хотя бы в одно
+	Routes  AccessListSchema   `json:"routes"`            // Маршруты, на которых продукт можно или нельзя продавать
+	Regions AccessListSchema   `json:"regions"`           // Регионы, в которых продукт можно или нельзя продавать
+	Periods []DateRangeSchema  `json:"periods,omitempty"` // Периоды продаж; продукт продается, если дата попадает хотя бы в один
+}
+
+type TimeWindowSchema struct {
+	Days  []string `json:"days,omitempty" enums:"mon,tue,wed,thu,fri,sat,sun" example:"mon,tue,wed,thu,fri"` // Пустой список означает все дни
+	Start string   `json:"start" example:"07:00"`                                                            // Начало окна по местному времени
+	End   string   `json:"end" example:"11:00"`                                                              // Конец окна, не входит в окно
+}
+
+type AccessListSchema struct {
+	Allow []string `json:"allow,omitempty" example:"MOW-SPB"`
+	Deny  []string `json:"deny,omitempty" example:"MOW-KZN"`
+}
+
+type DateRangeSchema struct {
+	From string `json:"from" example:"2024-06-01"`
+	To   string `json:"to" example:"2024-08-31"` // Дата окончания входит в период
+}
+
+type TranslationSchema struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty" example:"Still water 0.5 L"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 type TemplateContentSchema struct {
-	ProductID int64 `json:"productID"`
-	Quantity  int   `json:"quantity"`
+	ProductID int64          `json:"productID"`
+	Quantity  int            `json:"quantity"`
+	Unit      string         `json:"unit,omitempty" example:"box"`      // Упаковка, в которой задано количество; в ответах количество всегда в базовых единицах
+	Product   *ProductSchema `json:"product,omitempty" readonly:"true"` // Продукт строки с ценой, только при expand=products
+}
+
+type PriceListSchema struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency" example:"BYN"`
+}
+
+type ProductPriceSchema struct {
+	ProductID int64        `json:"productID"`
+	Price     models.Money `json:"price" swaggertype:"string" example:"149.99"`
+}
+
+type PickListEntrySchema struct {
+	TemplateID int64 `json:"id"`
+	Multiplier int   `json:"multiplier" example:"3"` // Число комплектов шаблона, например вагонов; от 1 до 10000
+}
+
+type PickListItemSchema struct {
+	ProductID int64  `json:"productID"`
+	SKU       string `json:"sku"`
+	Name      string `json:"name"`
+	Unit      string `json:"unit" example:"pcs"` // Базовая единица, в которой задано количество
+	Quantity  int    `json:"quantity"`
+}
+
+type TemplateLineDiffSchema struct {
+	ProductID int64          `json:"productID"`
+	Product   *ProductSchema `json:"product,omitempty"`
+	QuantityA int            `json:"quantityA"` // Количество в первом шаблоне
+	QuantityB int            `json:"quantityB"` // Количество во втором шаблоне
+	Delta     int            `json:"delta"`     // Изменение от первого шаблона ко второму
+}
+
+type TemplateTotalSchema struct {
+	TemplateID int64        `json:"templateID"`
+	PriceList  string       `json:"priceList,omitempty"`
+	Currency   string       `json:"currency"`
+	Items      int          `json:"items" example:"10"` // Число единиц товара во всех строках
+	Net        models.Money `json:"net" swaggertype:"string" example:"1249.92"`
+	VAT        models.Money `json:"vat" swaggertype:"string" example:"249.98"`
+	Total      models.Money `json:"total" swaggertype:"string" example:"1499.90"`
 }
 
 // GetAllProductsRequest представляет собой запрос на получение всех продуктов
 // @Description Запрос на получение всех продуктов
 type GetAllProductsRequest struct {
+	PriceList        string   `json:"price_list,omitempty"`        // Имя прайс-листа, по умолчанию базовый
+	ExcludeAllergens []string `json:"exclude_allergens,omitempty"` // Аллергены, продукты с которыми не попадают в список
+	Locales          []string `json:"lang,omitempty"`              // Предпочитаемые языки в порядке убывания приоритета
 }
 
 // GetAllProductsResponse представляет собой ответ на запрос на получение всех продуктов
@@ -35,24 +154,82 @@ type GetAllProductsResponse struct {
 	Products []ProductSchema `json:"products"`
 }
 
+// ETag возвращает пустую строку: тег списка вычисляется по телу ответа.
+func (r GetAllProductsResponse) ETag() string {
+	return ""
+}
+
+// LastModified возвращает нулевое время: удаление продукта или его перевод в черновик не сдвигают
+// время изменения оставшихся продуктов, поэтому список перепроверяется только по ETag.
+func (r GetAllProductsResponse) LastModified() time.Time {
+	return time.Time{}
+}
+
 // GetProductByIDRequest представляет собой запрос на получение продукта по его ID
 // @Description Запрос на получение продукта по его ID
 type GetProductByIDRequest struct {
-	ProductID int64 `json:"id"`
+	ProductID int64    `json:"id"`
+	PriceList string   `json:"price_list,omitempty"` // Имя прайс-листа, по умолчанию базовый
+	Locales   []string `json:"lang,omitempty"`       // Предпочитаемые языки в порядке убывания приоритета
 }
 
 // GetProductByIDResponse представляет собой ответ на запрос на получение продукта по его ID
 // @Description Ответ на запрос на получение продукта по его ID
 type GetProductByIDResponse struct {
+	Product   ProductSchema `json:"product"`
+	Modified  time.Time     `json:"-"` // Время последнего изменения продукта и его вариантов
+	PriceList string        `json:"-"` // Прайс-лист, по которому выбрана цена
+	Locales   []string      `json:"-"` // Языки, по которым выбраны название и описание
+}
+
+// ETag возвращает тег с версией продукта и представлением, выбранным прайс-листом и языками.
+func (r GetProductByIDResponse) ETag() string {
+	return RepresentationETag(r.Product.Version, r.PriceList, r.Locales)
+}
+
+// LastModified возвращает время последнего изменения продукта и его вариантов.
+func (r GetProductByIDResponse) LastModified() time.Time {
+	return r.Modified
+}
+
+// GetProductByBarcodeRequest представляет собой запрос на получение продукта по штрихкоду
+// @Description Запрос на получение продукта по штрихкоду
+type GetProductByBarcodeRequest struct {
+	Code      string   `json:"code"`
+	PriceList string   `json:"price_list,omitempty"` // Имя прайс-листа, по умолчанию базовый
+	Locales   []string `json:"lang,omitempty"`       // Предпочитаемые языки в порядке убывания приоритета
+}
+
+// GetProductByBarcodeResponse представляет собой ответ на запрос на получение продукта по штрихкоду
+// @Description Ответ на запрос на получение продукта по штрихкоду
+type GetProductByBarcodeResponse struct {
 	Product ProductSchema `json:"product"`
 }
 
+// GetAvailableProductsRequest представляет собой запрос на получение продуктов, доступных для продажи
+// @Description Запрос на получение продуктов, которые можно продавать на маршруте в указанный момент
+type GetAvailableProductsRequest struct {
+	Route     string    `json:"route"`
+	Region    string    `json:"region,omitempty"`
+	At        time.Time `json:"at"`                   // Местное время продажи, по умолчанию текущее
+	PriceList string    `json:"price_list,omitempty"` // Имя прайс-листа, по умолчанию базовый
+	Locales   []string  `json:"lang,omitempty"`       // Предпочитаемые языки в порядке убывания приоритета
+}
+
+// GetAvailableProductsResponse представляет собой ответ на запрос на получение продуктов, доступных для продажи
+// @Description Ответ на запрос на получение продуктов, доступных для продажи
+type GetAvailableProductsResponse struct {
+	Products []ProductSchema `json:"products"`
+}
+
 // SearchTemplatesRequest представляет собой запрос на поиск шаблонов
 // @Description Запрос на поиск шаблонов
 type SearchTemplatesRequest struct {
-	Query  string `json:"query,omitempty"`
-	Limit  int64  `json:"limit"`
-	Offset int64  `json:"offset"`
+	Query   string   `json:"query,omitempty"`
+	Limit   int64    `json:"limit"`
+	Offset  int64    `json:"offset"`
+	Locales []string `json:"lang,omitempty"`   // Предпочитаемые языки в порядке убывания приоритета
+	Expand  []string `json:"expand,omitempty"` // Что раскрыть в ответе; поддерживается content
 }
 
 // SearchTemplatesResponse представляет собой ответ на запрос на поиск шаблонов
@@ -73,16 +250,138 @@ type AddTemplateResponse struct {
 	TemplateID int64 `json:"id"` // ID созданного шаблона
 }
 
+// ListTemplatesRequest представляет собой запрос на получение всех шаблонов
+// @Description Запрос на получение всех шаблонов
+type ListTemplatesRequest struct {
+	Locales []string `json:"lang,omitempty"` // Предпочитаемые языки в порядке убывания приоритета
+}
+
+// ListTemplatesResponse представляет собой ответ на запрос на получение всех шаблонов
+// @Description Ответ на запрос на получение всех шаблонов; состав шаблонов не заполняется
+type ListTemplatesResponse struct {
+	Templates []TemplateSchema `json:"templates"`
+}
+
+// DeleteTemplateRequest представляет собой запрос на удаление шаблона
+// @Description Запрос на удаление шаблона
+type DeleteTemplateRequest struct {
+	TemplateID int64 `json:"id"`
+	DryRun     bool  `json:"dry_run,omitempty"` // Только показать, что будет удалено
+	Version    int64 `json:"-"`                 // Ожидаемая версия шаблона из заголовка If-Match, 0 для If-Match: *
+}
+
+// DeleteTemplateResponse представляет собой ответ на запрос на удаление шаблона
+// @Description Ответ на запрос на удаление шаблона
+type DeleteTemplateResponse struct {
+	DryRun   bool           `json:"dryRun"`   // Шаблон не удалялся, а только был проверен
+	Template TemplateSchema `json:"template"` // Удаленный (или подлежащий удалению) шаблон вместе с составом
+}
+
+// UpdateTemplateRequest представляет собой запрос на обновление шаблона
+// @Description Запрос на обновление шаблона; состав шаблона заменяется целиком
+type UpdateTemplateRequest struct {
+	Template TemplateSchema `json:"template"`
+	Version  int64          `json:"-"` // Ожидаемая версия шаблона из заголовка If-Match, 0 для If-Match: *
+}
+
+// UpdateTemplateResponse представляет собой ответ на запрос на обновление шаблона
+// @Description Ответ на запрос на обновление шаблона
+type UpdateTemplateResponse struct {
+	Version int64 `json:"version"` // Новая версия шаблона
+}
+
+// Headers возвращает ETag с новой версией шаблона.
+func (r UpdateTemplateResponse) Headers() http.Header {
+	return versionHeaders(r.Version)
+}
+
+// CloneTemplateRequest представляет собой запрос на копирование шаблона
+// @Description Запрос на копирование шаблона под новым названием
+type CloneTemplateRequest struct {
+	TemplateID   int64  `json:"-"`            // ID исходного шаблона из пути
+	TemplateName string `json:"templateName"` // Название нового шаблона
+}
+
+// CloneTemplateResponse представляет собой ответ на запрос на копирование шаблона
+// @Description Ответ на запрос на копирование шаблона
+type CloneTemplateResponse struct {
+	TemplateID int64 `json:"id"` // ID созданной копии
+}
+
+// AddTemplateLineRequest представляет собой запрос на добавление строки в шаблон
+// @Description Запрос на добавление продукта в шаблон
+type AddTemplateLineRequest struct {
+	TemplateID int64                 `json:"-"` // ID шаблона из пути
+	Content    TemplateContentSchema `json:"content"`
+	Version    int64                 `json:"-"` // Ожидаемая версия шаблона из заголовка If-Match, 0 для If-Match: *
+}
+
+// UpdateTemplateLineRequest представляет собой запрос на изменение количества продукта в шаблоне
+// @Description Запрос на изменение количества продукта в шаблоне
+type UpdateTemplateLineRequest struct {
+	TemplateID int64  `json:"-"` // ID шаблона из пути
+	ProductID  int64  `json:"-"` // ID продукта из пути
+	Quantity   int    `json:"quantity"`
+	Unit       string `json:"unit,omitempty" example:"box"` // Упаковка, в которой задано количество
+	Version    int64  `json:"-"`                            // Ожидаемая версия шаблона из заголовка If-Match, 0 для If-Match: *
+}
+
+// RemoveTemplateLineRequest представляет собой запрос на удаление строки из шаблона
+// @Description Запрос на удаление продукта из шаблона
+type RemoveTemplateLineRequest struct {
+	TemplateID int64 `json:"-"` // ID шаблона из пути
+	ProductID  int64 `json:"-"` // ID продукта из пути
+	Version    int64 `json:"-"` // Ожидаемая версия шаблона из заголовка If-Match, 0 для If-Match: *
+}
+
+// TemplateLineResponse представляет собой ответ на изменение строки шаблона
+// @Description Ответ на изменение строки шаблона
+type TemplateLineResponse struct {
+	Version int64 `json:"version"` // Новая версия шаблона
+}
+
+// Headers возвращает ETag с новой версией шаблона.
+func (r TemplateLineResponse) Headers() http.Header {
+	return versionHeaders(r.Version)
+}
+
 // GetTemplateByIDRequest представляет собой запрос на получение шаблона по его ID
 // @Description Запрос на получение шаблона по его ID
 type GetTemplateByIDRequest struct {
-	TemplateID int64 `json:"id"`
+	TemplateID int64    `json:"id"`
+	Locales    []string `json:"lang,omitempty"`       // Предпочитаемые языки в порядке убывания приоритета
+	Expand     []string `json:"expand,omitempty"`     // Что раскрыть в ответе; поддерживается products
+	PriceList  string   `json:"price_list,omitempty"` // Прайс-лист для цен раскрытых продуктов, по умолчанию базовый
 }
 
+// Значения параметра expand.
+const (
+	// ExpandProducts раскрывает продукты в строках шаблона.
+	ExpandProducts = "products"
+	// ExpandContent загружает состав шаблонов в результатах поиска.
+	ExpandContent = "content"
+)
+
 // GetTemplateByIDResponse представляет собой ответ на запрос на получение шаблона по его ID
 // @Description Ответ на запрос на получение шаблона по его ID
 type GetTemplateByIDResponse struct {
 	Template TemplateSchema `json:"template"`
+	Modified time.Time      `json:"-"` // Время последнего изменения шаблона
+	Locales  []string       `json:"-"` // Языки, по которым выбраны название и описание
+}
+
+// ETag возвращает тег с версией шаблона и языками представления. Цены раскрытых продуктов меняются
+// независимо от шаблона, поэтому для шаблона с итогами тег вычисляется по телу ответа.
+func (r GetTemplateByIDResponse) ETag() string {
+	if r.Template.Total != nil {
+		return ""
+	}
+	return RepresentationETag(r.Template.Version, "", r.Locales)
+}
+
+// LastModified возвращает время последнего изменения шаблона.
+func (r GetTemplateByIDResponse) LastModified() time.Time {
+	return r.Modified
 }
 
 // CreateProductRequest представляет собой запрос на добавление продукта
@@ -101,20 +400,321 @@ type CreateProductResponse struct {
 // @Description Запрос на обновление продукта
 type UpdateProductRequest struct {
 	Product ProductSchema `json:"product"`
+	Version int64         `json:"-"` // Ожидаемая версия продукта из заголовка If-Match, 0 для If-Match: *
 }
 
 // UpdateProductResponse представляет собой ответ на запрос на обновление продукта
 // @Description Ответ на запрос на обновление продукта
 type UpdateProductResponse struct {
+	Version int64 `json:"version"` // Новая версия продукта
+}
+
+// Headers возвращает ETag с новой версией продукта.
+func (r UpdateProductResponse) Headers() http.Header {
+	return versionHeaders(r.Version)
 }
 
 // DeleteProductRequest представляет собой запрос на удаление продукта
 // @Description Запрос на удаление продукта
 type DeleteProductRequest struct {
 	ProductID int64 `json:"id"`
+	Version   int64 `json:"-"` // Ожидаемая версия продукта из заголовка If-Match, 0 для If-Match: *
 }
 
 // DeleteProductResponse представляет собой ответ на запрос на удаление продукта
 // @Description Ответ на запрос на удаление продукта
 type DeleteProductResponse struct {
 }
+
+// GetTemplateTotalRequest представляет собой запрос на расчет стоимости шаблона
+// @Description Запрос на расчет стоимости шаблона по прайс-листу
+type GetTemplateTotalRequest struct {
+	TemplateID int64  `json:"id"`
+	PriceList  string `json:"price_list,omitempty"` // Имя прайс-листа, по умолчанию базовый
+}
+
+// GetTemplateTotalResponse представляет собой ответ на запрос на расчет стоимости шаблона
+// @Description Ответ на запрос на расчет стоимости шаблона по прайс-листу
+type GetTemplateTotalResponse struct {
+	Total TemplateTotalSchema `json:"total"`
+}
+
+// ScaleTemplateRequest представляет собой запрос на масштабирование шаблона
+// @Description Запрос на пересчет состава шаблона на другое число пассажиров или по множителю
+type ScaleTemplateRequest struct {
+	TemplateID int64    `json:"id"`
+	Passengers int      `json:"passengers,omitempty"` // Число пассажиров, на которое пересчитывается состав
+	Factor     float64  `json:"factor,omitempty"`     // Множитель количеств, если число пассажиров не задано
+	Locales    []string `json:"lang,omitempty"`       // Предпочитаемые языки в порядке убывания приоритета
+}
+
+// ScaleTemplateResponse представляет собой ответ на запрос на масштабирование шаблона
+// @Description Ответ на запрос на масштабирование шаблона
+type ScaleTemplateResponse struct {
+	Template TemplateSchema `json:"template"` // Шаблон с пересчитанными количествами и числом пассажиров
+}
+
+// Форматы сводного списка комплектации.
+const (
+	PickListFormatJSON = "json"
+	PickListFormatCSV  = "csv"
+)
+
+// BuildPickListRequest представляет собой запрос на сводный список комплектации
+// @Description Запрос на сведение нескольких шаблонов в один список комплектации
+type BuildPickListRequest struct {
+	Templates []PickListEntrySchema `json:"templates"`
+	SortBy    string                `json:"sort,omitempty" example:"name"` // Порядок строк: name (по умолчанию) или sku
+	Format    string                `json:"-"`                             // Формат ответа из параметра format: json (по умолчанию) или csv
+	Locales   []string              `json:"-"`                             // Предпочитаемые языки названий
+}
+
+// BuildPickListResponse представляет собой ответ со сводным списком комплектации
+// @Description Ответ со сводным списком комплектации
+type BuildPickListResponse struct {
+	Items  []PickListItemSchema `json:"items"`
+	Format string               `json:"-"` // При csv ответ кодируется как CSV, а не JSON
+}
+
+// DiffTemplatesRequest представляет собой запрос на сравнение двух шаблонов
+// @Description Запрос на сравнение состава двух шаблонов
+type DiffTemplatesRequest struct {
+	A       int64    `json:"a"`              // ID первого шаблона
+	B       int64    `json:"b"`              // ID второго шаблона
+	Locales []string `json:"lang,omitempty"` // Предпочитаемые языки в порядке убывания приоритета
+}
+
+// DiffTemplatesResponse представляет собой ответ на запрос на сравнение двух шаблонов
+// @Description Ответ на запрос на сравнение состава двух шаблонов
+type DiffTemplatesResponse struct {
+	A       TemplateSchema           `json:"a"`       // Первый шаблон без состава
+	B       TemplateSchema           `json:"b"`       // Второй шаблон без состава
+	OnlyInA []TemplateContentSchema  `json:"onlyInA"` // Продукты только в первом шаблоне
+	OnlyInB []TemplateContentSchema  `json:"onlyInB"` // Продукты только во втором шаблоне
+	Changed []TemplateLineDiffSchema `json:"changed"` // Продукты с разными количествами
+}
+
+// TemplateRevisionSchema описывает неизменяемый снимок шаблона после одного изменения
+type TemplateRevisionSchema struct {
+	Revision  int64          `json:"revision"`                // Версия шаблона, которую получил шаблон в результате изменения
+	Author    string         `json:"author" example:"ivanov"` // Автор изменения из заголовка X-User; пустая строка, если автор не передан
+	CreatedAt time.Time      `json:"createdAt"`               // Время изменения
+	Template  TemplateSchema `json:"template"`                // Шаблон на момент изменения; в списке ревизий без состава
+	Deleted   bool           `json:"deleted"`                 // Изменение удалило шаблон; шаблон — его состояние перед удалением
+}
+
+// ListTemplateRevisionsRequest представляет собой запрос на получение истории изменений шаблона
+// @Description Запрос на получение истории изменений шаблона
+type ListTemplateRevisionsRequest struct {
+	TemplateID int64 `json:"-"` // ID шаблона из пути
+}
+
+// ListTemplateRevisionsResponse представляет собой ответ на запрос на получение истории изменений шаблона
+// @Description Ответ на запрос на получение истории изменений шаблона
+type ListTemplateRevisionsResponse struct {
+	Revisions []TemplateRevisionSchema `json:"revisions"` // Ревизии от новых к старым
+}
+
+// GetTemplateRevisionRequest представляет собой запрос на получение ревизии шаблона
+// @Description Запрос на получение ревизии шаблона
+type GetTemplateRevisionRequest struct {
+	TemplateID int64 `json:"-"` // ID шаблона из пути
+	Revision   int64 `json:"-"` // Номер ревизии из пути
+}
+
+// GetTemplateRevisionResponse представляет собой ответ на запрос на получение ревизии шаблона
+// @Description Ответ на запрос на получение ревизии шаблона
+type GetTemplateRevisionResponse struct {
+	Revision TemplateRevisionSchema `json:"revision"`
+}
+
+// RestoreTemplateRevisionRequest представляет собой запрос на восстановление ревизии шаблона
+// @Description Запрос на восстановление ревизии шаблона
+type RestoreTemplateRevisionRequest struct {
+	TemplateID int64 `json:"-"` // ID шаблона из пути
+	Revision   int64 `json:"-"` // Номер ревизии из пути
+	Version    int64 `json:"-"` // Ожидаемая версия шаблона из заголовка If-Match; 0 отключает проверку
+}
+
+// RestoreTemplateRevisionResponse представляет собой ответ на запрос на восстановление ревизии шаблона
+// @Description Ответ на запрос на восстановление ревизии шаблона
+type RestoreTemplateRevisionResponse struct {
+	Version int64 `json:"version"` // Новая версия шаблона
+}
+
+// Headers возвращает ETag с новой версией шаблона.
+func (r RestoreTemplateRevisionResponse) Headers() http.Header {
+	return versionHeaders(r.Version)
+}
+
+// TemplateDocumentVersion — версия формата переносимого документа с шаблонами.
+const TemplateDocumentVersion = 1
+
+// Форматы переносимого документа с шаблонами.
+const (
+	TemplateDocumentFormatJSON = "json"
+	TemplateDocumentFormatYAML = "yaml"
+)
+
+// TemplateDocumentSchema описывает переносимый документ с шаблонами
+// @Description Документ для переноса шаблонов между базами данных: шаблоны определяются названием, продукты — артикулом
+type TemplateDocumentSchema struct {
+	FormatVersion int                      `json:"formatVersion" yaml:"formatVersion" example:"1"` // Версия формата документа
+	Templates     []PortableTemplateSchema `json:"templates" yaml:"templates"`
+}
+
+// PortableTemplateSchema описывает шаблон в переносимом документе
+type PortableTemplateSchema struct {
+	TemplateName string                       `json:"templateName" yaml:"templateName"` // Название; шаблон с таким названием в целевой базе данных заменяется
+	Description  string                       `json:"description,omitempty" yaml:"description,omitempty"`
+	Translations map[string]TranslationSchema `json:"translations,omitempty" yaml:"translations,omitempty"`
+	Passengers   int                          `json:"passengers,omitempty" yaml:"passengers,omitempty" example:"60"`
+	Content      []PortableTemplateLineSchema `json:"content" yaml:"content"`
+}
+
+// PortableTemplateLineSchema описывает строку шаблона в переносимом документе
+type PortableTemplateLineSchema struct {
+	SKU      string `json:"sku" yaml:"sku" example:"WATER-05"`                  // Артикул продукта
+	Quantity int    `json:"quantity" yaml:"quantity" example:"10"`              // Количество; при экспорте — в базовых единицах продукта
+	Unit     string `json:"unit,omitempty" yaml:"unit,omitempty" example:"box"` // Упаковка, в которой задано количество; только при импорте
+}
+
+// ExportTemplatesRequest представляет собой запрос на экспорт шаблонов
+// @Description Запрос на экспорт шаблонов в переносимый документ
+type ExportTemplatesRequest struct {
+	TemplateIDs []int64 `json:"-"` // ID шаблонов из параметров id; пустой список — все шаблоны
+	Format      string  `json:"-"` // Формат документа: json или yaml
+}
+
+// ExportTemplatesResponse представляет собой ответ на запрос на экспорт шаблонов
+// @Description Ответ на запрос на экспорт шаблонов
+type ExportTemplatesResponse struct {
+	Document TemplateDocumentSchema
+	Format   string `json:"-"` // Формат документа: json или yaml
+}
+
+// ImportTemplatesRequest представляет собой запрос на импорт шаблонов
+// @Description Запрос на импорт шаблонов из переносимого документа
+type ImportTemplatesRequest struct {
+	Document TemplateDocumentSchema
+}
+
+// ImportTemplatesResponse представляет собой ответ на запрос на импорт шаблонов
+// @Description Ответ на запрос на импорт шаблонов
+type ImportTemplatesResponse struct {
+	Templates []ImportedTemplateSchema `json:"templates"` // Результаты в порядке шаблонов документа
+}
+
+// ImportedTemplateSchema описывает результат импорта одного шаблона
+type ImportedTemplateSchema struct {
+	ID           int64  `json:"id"`           // ID шаблона в этой базе данных
+	TemplateName string `json:"templateName"` // Название шаблона
+	Version      int64  `json:"version"`      // Версия шаблона после импорта
+	Created      bool   `json:"created"`      // true, если шаблон создан, false — если заменен существующий
+}
+
+// ListPriceListsRequest представляет собой запрос на получение всех прайс-листов
+// @Description Запрос на получение всех прайс-листов
+type ListPriceListsRequest struct {
+}
+
+// ListPriceListsResponse представляет собой ответ на запрос на получение всех прайс-листов
+// @Description Ответ на запрос на получение всех прайс-листов
+type ListPriceListsResponse struct {
+	PriceLists []PriceListSchema `json:"priceLists"`
+}
+
+// CreatePriceListRequest представляет собой запрос на создание прайс-листа
+// @Description Запрос на создание прайс-листа
+type CreatePriceListRequest struct {
+	PriceList PriceListSchema `json:"priceList"`
+}
+
+// CreatePriceListResponse представляет собой ответ на запрос на создание прайс-листа
+// @Description Ответ на запрос на создание прайс-листа
+type CreatePriceListResponse struct {
+	PriceListID int64 `json:"id"` // ID созданного прайс-листа
+}
+
+// SetPriceListPricesRequest представляет собой запрос на установку цен в прайс-листе
+// @Description Запрос на установку цен продуктов в прайс-листе
+type SetPriceListPricesRequest struct {
+	PriceListID int64                `json:"-"`
+	Prices      []ProductPriceSchema `json:"prices"`
+}
+
+// SetPriceListPricesResponse представляет собой ответ на запрос на установку цен в прайс-листе
+// @Description Ответ на запрос на установку цен продуктов в прайс-листе
+type SetPriceListPricesResponse struct {
+}
+
+// ProductImageSchema описывает ссылки на изображение продукта и его миниатюру
+type ProductImageSchema struct {
+	ImageURL     string `json:"imageurl" example:"/api/v1/product/image/product-1-9f86d081884c7d65.jpg"`
+	ThumbnailURL string `json:"thumbnailurl" example:"/api/v1/product/image/product-1-9f86d081884c7d65-thumb.jpg"`
+}
+
+// UploadProductImageRequest представляет собой запрос на загрузку изображения продукта
+// @Description Запрос на загрузку изображения продукта (multipart/form-data, поле image)
+type UploadProductImageRequest struct {
+	ProductID   int64  `json:"-"`
+	ContentType string `json:"-"`
+	Data        []byte `json:"-"`
+}
+
+// String не выводит содержимое файла, чтобы оно не попадало в логи.
+func (r UploadProductImageRequest) String() string {
+	return fmt.Sprintf("{ProductID:%d ContentType:%s Size:%d}", r.ProductID, r.ContentType, len(r.Data))
+}
+
+// UploadProductImageResponse представляет собой ответ на запрос на загрузку изображения продукта
+// @Description Ответ на запрос на загрузку изображения продукта
+type UploadProductImageResponse struct {
+	Image ProductImageSchema `json:"image"`
+}
+
+// GetImageRequest представляет собой запрос на получение изображения
+// @Description Запрос на получение изображения по имени файла
+type GetImageRequest struct {
+	Key string `json:"-"`
+}
+
+// GetImageResponse представляет собой ответ с содержимым изображения; кодируется как тело ответа, а не JSON
+type GetImageResponse struct {
+	ContentType string
+	Data        []byte
+}
+
+// String не выводит содержимое файла, чтобы оно не попадало в логи.
+func (r GetImageResponse) String() string {
+	return fmt.Sprintf("{ContentType:%s Size:%d}", r.ContentType, len(r.Data))
+}
+
+// Cacheable описывает ответ, который клиент может кэшировать и перепроверять условными запросами.
+type Cacheable interface {
+	// ETag возвращает сильный тег ответа или пустую строку, если тег нужно вычислить по телу ответа.
+	ETag() string
+	// LastModified возвращает время последнего изменения данных ответа или нулевое время, если оно неизвестно.
+	LastModified() time.Time
+}
+
+// VersionETag возвращает сильный ETag для версии строки.
+func VersionETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// RepresentationETag возвращает сильный ETag для версии строки в представлении, выбранном прайс-листом и языками.
+// Без прайс-листа и языков тег совпадает с VersionETag, иначе к версии через дефис добавляется хеш параметров,
+// так что тег по-прежнему начинается с версии и принимается в If-Match.
+func RepresentationETag(version int64, priceList string, locales []string) string {
+	if priceList == "" && len(locales) == 0 {
+		return VersionETag(version)
+	}
+	sum := sha256.Sum256([]byte(priceList + "\x00" + strings.Join(locales, ",")))
+	return strconv.Quote(strconv.FormatInt(version, 10) + "-" + hex.EncodeToString(sum[:4]))
+}
+
+// versionHeaders возвращает заголовки ответа с ETag для версии строки.
+func versionHeaders(version int64) http.Header {
+	return http.Header{"ETag": []string{VersionETag(version)}}
+}
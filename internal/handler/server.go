@@ -2,23 +2,32 @@ package handler
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	_ "github.com/Chaika-Team/ChaikaGoods/docs"
 	"github.com/Chaika-Team/ChaikaGoods/internal/handler/schemas"
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
 	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/Chaika-Team/ChaikaGoods/internal/service"
 
 	httpGoKit "github.com/go-kit/kit/transport/http"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v3"
 )
 
 // Constants for logging messages and endpoint
@@ -28,6 +37,10 @@ const (
 	// v1Prefix is the prefix for API v1 routes
 	v1Prefix            = apiPrefix + "/v1/product"
 	decoderReturningMsg = "decoder returning"
+	// imageFormField is the multipart form field carrying an uploaded product image
+	imageFormField = "image"
+	// multipartOverhead is the allowance for multipart boundaries and part headers on top of the image itself
+	multipartOverhead = 64 << 10
 )
 
 // NewHTTPServer initializes and returns a new HTTP server with all the necessary routes and middleware.
@@ -36,12 +49,13 @@ const (
 // Parameters:
 //   - logger: Logger instance for logging HTTP requests and errors
 //   - endpoints: Collection of service endpoints to be exposed via HTTP
+//   - cacheControl: Cache-Control header value for cacheable GET responses; empty to omit the header
 //
 // Returns:
 //   - http.Handler: Configured HTTP handler with all routes and middleware
-func NewHTTPServer(logger log.Logger, endpoints Endpoints) http.Handler {
+func NewHTTPServer(logger log.Logger, endpoints Endpoints, cacheControl string) http.Handler {
 	r := mux.NewRouter()
-	r.Use(HTTPLoggingMiddleware(logger), HeaderMiddleware)
+	r.Use(HTTPLoggingMiddleware(logger), HeaderMiddleware, AuthorMiddleware)
 
 	// API information endpoint
 	r.HandleFunc(apiPrefix, func(w http.ResponseWriter, r *http.Request) {
@@ -58,7 +72,7 @@ func NewHTTPServer(logger log.Logger, endpoints Endpoints) http.Handler {
 	}).Methods("GET")
 
 	// Register API v1 routes
-	registerV1Routes(logger, r, endpoints)
+	registerV1Routes(logger, r, endpoints, cacheControl)
 
 	// Future versions can be added here as needed
 	// registerV2Routes(logger, r, endpoints)
@@ -67,7 +81,7 @@ func NewHTTPServer(logger log.Logger, endpoints Endpoints) http.Handler {
 }
 
 // registerV1Routes registers all routes for the service using go-kit transport.
-func registerV1Routes(logger log.Logger, router *mux.Router, endpoints Endpoints) {
+func registerV1Routes(logger log.Logger, router *mux.Router, endpoints Endpoints, cacheControl string) {
 	// GetAllProducts    endpoint.Endpoint
 	// GetProductByID    endpoint.Endpoint
 	// For templates
@@ -90,7 +104,55 @@ func registerV1Routes(logger log.Logger, router *mux.Router, endpoints Endpoints
 	// Get all products
 	v1.Methods("GET").Path("").Handler(httpGoKit.NewServer(
 		endpoints.GetAllProducts,
-		decodeEmptyRequest[schemas.GetAllProductsRequest](),
+		decodeGetAllProductsRequest,
+		encodeResponse(logger),
+		append(cacheableOptions(cacheControl), httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)))...,
+	))
+
+	// List price lists (registered before /{id} so that "price-list" is not taken for a product ID)
+	v1.Methods("GET").Path("/price-list").Handler(httpGoKit.NewServer(
+		endpoints.ListPriceLists,
+		decodeEmptyRequest[schemas.ListPriceListsRequest](),
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Add price list
+	v1.Methods("POST").Path("/price-list").Handler(httpGoKit.NewServer(
+		endpoints.CreatePriceList,
+		decodeJSONRequest(&schemas.CreatePriceListRequest{}),
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Set price list prices
+	v1.Methods("PUT").Path("/price-list/{id}/prices").Handler(httpGoKit.NewServer(
+		endpoints.SetPriceListPrices,
+		decodeSetPriceListPricesRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Get products available for sale on a route
+	v1.Methods("GET").Path("/available").Handler(httpGoKit.NewServer(
+		endpoints.GetAvailableProducts,
+		decodeGetAvailableProductsRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Get product by barcode
+	v1.Methods("GET").Path("/barcode/{code}").Handler(httpGoKit.NewServer(
+		endpoints.GetProductByBarcode,
+		decodeGetProductByBarcodeRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// List Templates (registered before /{id} so that "template" is not taken for a product ID)
+	v1.Methods("GET").Path("/template").Handler(httpGoKit.NewServer(
+		endpoints.ListTemplates,
+		decodeListTemplatesRequest,
 		encodeResponse(logger),
 		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
@@ -100,7 +162,7 @@ func registerV1Routes(logger log.Logger, router *mux.Router, endpoints Endpoints
 		endpoints.GetProductByID,
 		decodeRequestWithID(logger, "id", &schemas.GetProductByIDRequest{}),
 		encodeResponse(logger),
-		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+		append(cacheableOptions(cacheControl), httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)))...,
 	))
 
 	// Search Template
@@ -111,6 +173,14 @@ func registerV1Routes(logger log.Logger, router *mux.Router, endpoints Endpoints
 		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 
+	// Compare Templates (registered before /template/{id} so that "diff" is not taken for a Template ID)
+	v1.Methods("GET").Path("/template/diff").Handler(httpGoKit.NewServer(
+		endpoints.DiffTemplates,
+		decodeDiffTemplatesRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
 	// Add Template
 	v1.Methods("POST").Path("/template").Handler(httpGoKit.NewServer(
 		endpoints.AddTemplate,
@@ -119,11 +189,123 @@ func registerV1Routes(logger log.Logger, router *mux.Router, endpoints Endpoints
 		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 
+	// Export Templates (registered before /template/{id} so that "export" is not taken for a Template ID)
+	v1.Methods("GET").Path("/template/export").Handler(httpGoKit.NewServer(
+		endpoints.ExportTemplates,
+		decodeExportTemplatesRequest,
+		encodeTemplateDocumentResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Import Templates
+	v1.Methods("POST").Path("/template/import").Handler(httpGoKit.NewServer(
+		endpoints.ImportTemplates,
+		decodeImportTemplatesRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
 	// Get Template by ID
 	v1.Methods("GET").Path("/template/{id}").Handler(httpGoKit.NewServer(
 		endpoints.GetTemplateByID,
 		decodeRequestWithID(logger, "id", &schemas.GetTemplateByIDRequest{}),
 		encodeResponse(logger),
+		append(cacheableOptions(cacheControl), httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)))...,
+	))
+
+	// Update Template
+	v1.Methods("PUT").Path("/template/{id}").Handler(httpGoKit.NewServer(
+		endpoints.UpdateTemplate,
+		decodeUpdateTemplateRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Delete Template
+	v1.Methods("DELETE").Path("/template/{id}").Handler(httpGoKit.NewServer(
+		endpoints.DeleteTemplate,
+		decodeRequestWithID(logger, "id", &schemas.DeleteTemplateRequest{}),
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Build pick list from several Templates
+	v1.Methods("POST").Path("/template/pick-list").Handler(httpGoKit.NewServer(
+		endpoints.BuildPickList,
+		decodeBuildPickListRequest,
+		encodePickListResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Clone Template
+	v1.Methods("POST").Path("/template/{id}/clone").Handler(httpGoKit.NewServer(
+		endpoints.CloneTemplate,
+		decodeCloneTemplateRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Add product to Template
+	v1.Methods("POST").Path("/template/{id}/content").Handler(httpGoKit.NewServer(
+		endpoints.AddTemplateLine,
+		decodeAddTemplateLineRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Change product quantity in Template
+	v1.Methods("PUT").Path("/template/{id}/content/{productID}").Handler(httpGoKit.NewServer(
+		endpoints.UpdateTemplateLine,
+		decodeUpdateTemplateLineRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Remove product from Template
+	v1.Methods("DELETE").Path("/template/{id}/content/{productID}").Handler(httpGoKit.NewServer(
+		endpoints.RemoveTemplateLine,
+		decodeRemoveTemplateLineRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Get Template total
+	v1.Methods("GET").Path("/template/{id}/total").Handler(httpGoKit.NewServer(
+		endpoints.GetTemplateTotal,
+		decodeRequestWithID(logger, "id", &schemas.GetTemplateTotalRequest{}),
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Scale Template
+	v1.Methods("GET").Path("/template/{id}/scaled").Handler(httpGoKit.NewServer(
+		endpoints.ScaleTemplate,
+		decodeRequestWithID(logger, "id", &schemas.ScaleTemplateRequest{}),
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// List Template revisions
+	v1.Methods("GET").Path("/template/{id}/revisions").Handler(httpGoKit.NewServer(
+		endpoints.ListTemplateRevisions,
+		decodeRequestWithID(logger, "id", &schemas.ListTemplateRevisionsRequest{}),
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Get Template revision
+	v1.Methods("GET").Path("/template/{id}/revisions/{revision}").Handler(httpGoKit.NewServer(
+		endpoints.GetTemplateRevision,
+		decodeGetTemplateRevisionRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Restore Template revision
+	v1.Methods("POST").Path("/template/{id}/revisions/{revision}/restore").Handler(httpGoKit.NewServer(
+		endpoints.RestoreTemplateRevision,
+		decodeRestoreTemplateRevisionRequest,
+		encodeResponse(logger),
 		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 
@@ -138,7 +320,7 @@ func registerV1Routes(logger log.Logger, router *mux.Router, endpoints Endpoints
 	// Update product
 	v1.Methods("PUT").Path("/{id}").Handler(httpGoKit.NewServer(
 		endpoints.UpdateProduct,
-		decodeJSONRequest(&schemas.UpdateProductRequest{}),
+		decodeUpdateProductRequest,
 		encodeResponse(logger),
 		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
@@ -150,16 +332,105 @@ func registerV1Routes(logger log.Logger, router *mux.Router, endpoints Endpoints
 		encodeResponse(logger),
 		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
+
+	// Upload product image
+	v1.Methods("POST").Path("/{id}/image").Handler(httpGoKit.NewServer(
+		endpoints.UploadProductImage,
+		decodeUploadProductImageRequest,
+		encodeResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	// Get image
+	v1.Methods("GET").Path("/image/{name}").Handler(httpGoKit.NewServer(
+		endpoints.GetImage,
+		decodeGetImageRequest,
+		encodeImageResponse(logger),
+		httpGoKit.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
 }
 
 // encodeResponse encodes the response as JSON.
+// Responses implementing httpGoKit.Headerer (for example, those carrying an ETag) also set their headers,
+// and responses implementing schemas.Cacheable are answered with 304 Not Modified when the client copy is fresh.
 func encodeResponse(_ log.Logger) httpGoKit.EncodeResponseFunc {
 	return func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-		// Common response encoding logic here
+		if headerer, ok := response.(httpGoKit.Headerer); ok {
+			for key, values := range headerer.Headers() {
+				for _, value := range values {
+					w.Header().Add(key, value)
+				}
+			}
+		}
+		if cacheable, ok := response.(schemas.Cacheable); ok {
+			return encodeCacheableResponse(ctx, w, cacheable)
+		}
 		return json.NewEncoder(w).Encode(response)
 	}
 }
 
+// encodeImageResponse writes the image bytes as the response body with their own content type.
+func encodeImageResponse(logger log.Logger) httpGoKit.EncodeResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		image, ok := response.(schemas.GetImageResponse)
+		if !ok {
+			return encodeResponse(logger)(ctx, w, response)
+		}
+		w.Header().Set("Content-Type", image.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(image.Data)))
+		// Every upload gets a new key, so the content behind a key never changes.
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		_, err := w.Write(image.Data)
+		return err
+	}
+}
+
+// encodePickListResponse writes the pick list as CSV when requested and as JSON otherwise.
+func encodePickListResponse(logger log.Logger) httpGoKit.EncodeResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		pickList, ok := response.(schemas.BuildPickListResponse)
+		if !ok || pickList.Format != schemas.PickListFormatCSV {
+			return encodeResponse(logger)(ctx, w, response)
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="pick-list.csv"`)
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{"product_id", "sku", "name", "unit", "quantity"}); err != nil {
+			return err
+		}
+		for _, item := range pickList.Items {
+			record := []string{strconv.FormatInt(item.ProductID, 10), item.SKU, item.Name, item.Unit, strconv.Itoa(item.Quantity)}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+}
+
+// encodeTemplateDocumentResponse writes the exported template document as a JSON or YAML attachment.
+func encodeTemplateDocumentResponse(logger log.Logger) httpGoKit.EncodeResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		export, ok := response.(schemas.ExportTemplatesResponse)
+		if !ok {
+			return encodeResponse(logger)(ctx, w, response)
+		}
+		if export.Format != schemas.TemplateDocumentFormatYAML {
+			w.Header().Set("Content-Disposition", `attachment; filename="templates.json"`)
+			return encodeResponse(logger)(ctx, w, export.Document)
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Header().Set("Content-Disposition", `attachment; filename="templates.yaml"`)
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(export.Document); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+}
+
 // encodeErrorResponse encodes the error response as JSON with appropriate status code.
 func encodeErrorResponse(logger log.Logger) httpGoKit.ErrorEncoder {
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
@@ -174,12 +445,19 @@ func encodeErrorResponse(logger log.Logger) httpGoKit.ErrorEncoder {
 				status = http.StatusNotFound
 			case myerr.ErrorTypeValidation:
 				status = http.StatusBadRequest
-			case myerr.ErrorTypeDuplicate:
+			case myerr.ErrorTypeDuplicate, myerr.ErrorTypeConflict:
 				status = http.StatusConflict
+			case myerr.ErrorTypePreconditionFailed:
+				status = http.StatusPreconditionFailed
+			case myerr.ErrorTypePreconditionRequired:
+				status = http.StatusPreconditionRequired
 			default:
 				status = http.StatusInternalServerError
 			}
 			response = map[string]string{"error": e.Message}
+			if fields := myerr.Fields(e); len(fields) > 0 {
+				response = detailedErrorResponse(status, e.Message, fields)
+			}
 		default:
 			status = http.StatusInternalServerError
 			_ = level.Error(logger).Log("msg", "handling error", "err", err)
@@ -191,6 +469,15 @@ func encodeErrorResponse(logger log.Logger) httpGoKit.ErrorEncoder {
 	}
 }
 
+// detailedErrorResponse перечисляет в ответе все проблемы с полями запроса, чтобы клиент исправил их за один раз.
+func detailedErrorResponse(status int, message string, fields []myerr.FieldError) schemas.DetailedErrorResponse {
+	response := schemas.DetailedErrorResponse{Code: status, Message: message, Errors: make([]schemas.FieldErrorSchema, len(fields))}
+	for i, field := range fields {
+		response.Errors[i] = schemas.FieldErrorSchema{Field: field.Field, Index: field.Index, Message: field.Message}
+	}
+	return response
+}
+
 func decodeJSONRequest(schema interface{}) httpGoKit.DecodeRequestFunc {
 	return func(ctx context.Context, req *http.Request) (interface{}, error) {
 		defer func(Body io.ReadCloser) {
@@ -217,22 +504,52 @@ func decodeRequestWithID(logger log.Logger, paramName string, schema interface{}
 			return nil, err
 		}
 
-		switch s := schema.(type) {
+		// Каждый запрос получает собственный экземпляр схемы, чтобы параллельные запросы не перетирали друг друга.
+		var decoded interface{}
+		switch schema.(type) {
 		case *schemas.GetProductByIDRequest:
-			s.ProductID = id
-			_ = level.Debug(logger).Log("msg", decoderReturningMsg, "type", fmt.Sprintf("%T", s))
-			return s, nil
+			decoded = &schemas.GetProductByIDRequest{ProductID: id, PriceList: req.URL.Query().Get("price_list"), Locales: requestLocales(req)}
 		case *schemas.GetTemplateByIDRequest:
-			s.TemplateID = id
-			_ = level.Debug(logger).Log("msg", decoderReturningMsg, "type", fmt.Sprintf("%T", s))
-			return s, nil
+			expand, err := queryExpand(req, schemas.ExpandProducts)
+			if err != nil {
+				return nil, err
+			}
+			decoded = &schemas.GetTemplateByIDRequest{TemplateID: id, Locales: requestLocales(req), Expand: expand, PriceList: req.URL.Query().Get("price_list")}
+		case *schemas.GetTemplateTotalRequest:
+			decoded = &schemas.GetTemplateTotalRequest{TemplateID: id, PriceList: req.URL.Query().Get("price_list")}
 		case *schemas.DeleteProductRequest:
-			s.ProductID = id
-			_ = level.Debug(logger).Log("msg", decoderReturningMsg, "type", fmt.Sprintf("%T", s))
-			return s, nil
+			version, err := ifMatchVersion(req)
+			if err != nil {
+				return nil, err
+			}
+			decoded = &schemas.DeleteProductRequest{ProductID: id, Version: version}
+		case *schemas.DeleteTemplateRequest:
+			dryRun, err := queryBool(req, "dry_run")
+			if err != nil {
+				return nil, err
+			}
+			version, err := ifMatchVersion(req)
+			if err != nil {
+				return nil, err
+			}
+			decoded = &schemas.DeleteTemplateRequest{TemplateID: id, DryRun: dryRun, Version: version}
+		case *schemas.ScaleTemplateRequest:
+			passengers, err := queryInt(req, "passengers")
+			if err != nil {
+				return nil, err
+			}
+			factor, err := queryFloat(req, "factor")
+			if err != nil {
+				return nil, err
+			}
+			decoded = &schemas.ScaleTemplateRequest{TemplateID: id, Passengers: passengers, Factor: factor, Locales: requestLocales(req)}
+		case *schemas.ListTemplateRevisionsRequest:
+			decoded = &schemas.ListTemplateRevisionsRequest{TemplateID: id}
 		default:
 			return nil, errors.New("unsupported schema type")
 		}
+		_ = level.Debug(logger).Log("msg", decoderReturningMsg, "type", fmt.Sprintf("%T", decoded))
+		return decoded, nil
 	}
 }
 
@@ -243,6 +560,439 @@ func decodeEmptyRequest[T any]() func(context.Context, *http.Request) (interface
 	}
 }
 
+// queryBool разбирает необязательный логический параметр запроса; отсутствующий параметр означает false.
+func queryBool(req *http.Request, name string) (bool, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, myerr.Validation(fmt.Sprintf("Invalid %s value %q, expected true or false", name, value), err)
+	}
+	return parsed, nil
+}
+
+// queryInt разбирает необязательный целочисленный параметр запроса; отсутствующий параметр означает 0.
+func queryInt(req *http.Request, name string) (int, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, myerr.Validation(fmt.Sprintf("Invalid %s value %q, expected an integer", name, value), err)
+	}
+	return parsed, nil
+}
+
+// queryFloat разбирает необязательный дробный параметр запроса; отсутствующий параметр означает 0.
+func queryFloat(req *http.Request, name string) (float64, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, myerr.Validation(fmt.Sprintf("Invalid %s value %q, expected a number", name, value), err)
+	}
+	return parsed, nil
+}
+
+// queryExpand разбирает параметр expand: значения перечисляются через запятую или повтором параметра.
+// Значение вне списка supported дает ошибку Validation, чтобы опечатка не возвращала молча нераскрытый ответ.
+func queryExpand(req *http.Request, supported ...string) ([]string, error) {
+	var expand []string
+	for _, value := range req.URL.Query()["expand"] {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.ToLower(strings.TrimSpace(item))
+			if item == "" {
+				continue
+			}
+			if !slices.Contains(supported, item) {
+				return nil, myerr.Validation(fmt.Sprintf("Unsupported expand value %q, expected one of %v", item, supported), nil)
+			}
+			expand = append(expand, item)
+		}
+	}
+	return expand, nil
+}
+
+// decodeListTemplatesRequest декодирует GET запрос со списком шаблонов и предпочитаемыми языками.
+func decodeListTemplatesRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	return &schemas.ListTemplatesRequest{Locales: requestLocales(req)}, nil
+}
+
+// decodeGetAllProductsRequest декодирует GET запрос с необязательными параметрами price_list и exclude_allergens.
+// Аллергены можно перечислить через запятую или повторить параметр несколько раз.
+func decodeGetAllProductsRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	query := req.URL.Query()
+	var allergens []string
+	for _, value := range query["exclude_allergens"] {
+		for _, allergen := range strings.Split(value, ",") {
+			if allergen = strings.TrimSpace(allergen); allergen != "" {
+				allergens = append(allergens, strings.ToLower(allergen))
+			}
+		}
+	}
+	return &schemas.GetAllProductsRequest{
+		PriceList:        query.Get("price_list"),
+		ExcludeAllergens: allergens,
+		Locales:          requestLocales(req),
+	}, nil
+}
+
+// decodeSetPriceListPricesRequest декодирует PUT запрос с ID прайс-листа в пути и ценами в теле.
+func decodeSetPriceListPricesRequest(ctx context.Context, req *http.Request) (interface{}, error) {
+	id, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJSONRequest(&schemas.SetPriceListPricesRequest{})(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request := decoded.(*schemas.SetPriceListPricesRequest)
+	request.PriceListID = id
+	return request, nil
+}
+
+// decodeUpdateProductRequest декодирует PUT запрос с ID продукта в пути, продуктом в теле и его версией в заголовке If-Match.
+func decodeUpdateProductRequest(ctx context.Context, req *http.Request) (interface{}, error) {
+	id, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	version, err := ifMatchVersion(req)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJSONRequest(&schemas.UpdateProductRequest{})(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request := decoded.(*schemas.UpdateProductRequest)
+	request.Product.ID = id
+	request.Version = version
+	return request, nil
+}
+
+// decodeUpdateTemplateRequest декодирует PUT запрос с ID шаблона в пути, шаблоном в теле и его версией в заголовке If-Match.
+func decodeUpdateTemplateRequest(ctx context.Context, req *http.Request) (interface{}, error) {
+	id, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	version, err := ifMatchVersion(req)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJSONRequest(&schemas.UpdateTemplateRequest{})(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request := decoded.(*schemas.UpdateTemplateRequest)
+	request.Template.ID = id
+	request.Version = version
+	return request, nil
+}
+
+// decodeDiffTemplatesRequest декодирует GET запрос с ID сравниваемых шаблонов в параметрах a и b.
+func decodeDiffTemplatesRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	ids := make([]int64, 2)
+	for i, name := range []string{"a", "b"} {
+		value := req.URL.Query().Get(name)
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, myerr.Validation(fmt.Sprintf("Invalid %s value %q, expected a template ID", name, value), err)
+		}
+		ids[i] = id
+	}
+	return &schemas.DiffTemplatesRequest{A: ids[0], B: ids[1], Locales: requestLocales(req)}, nil
+}
+
+// decodeBuildPickListRequest декодирует POST запрос со списком шаблонов в теле и форматом ответа в параметре format.
+func decodeBuildPickListRequest(ctx context.Context, req *http.Request) (interface{}, error) {
+	format := strings.ToLower(req.URL.Query().Get("format"))
+	switch format {
+	case "":
+		format = schemas.PickListFormatJSON
+	case schemas.PickListFormatJSON, schemas.PickListFormatCSV:
+	default:
+		return nil, myerr.Validation(fmt.Sprintf("Unsupported format %q, expected json or csv", format), nil)
+	}
+
+	decoded, err := decodeJSONRequest(&schemas.BuildPickListRequest{})(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request := decoded.(*schemas.BuildPickListRequest)
+	request.Format = format
+	request.Locales = requestLocales(req)
+	return request, nil
+}
+
+// decodeExportTemplatesRequest декодирует GET запрос с ID шаблонов в повторяющемся параметре id и форматом документа в параметре format.
+func decodeExportTemplatesRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	format := strings.ToLower(req.URL.Query().Get("format"))
+	switch format {
+	case "":
+		format = schemas.TemplateDocumentFormatJSON
+	case schemas.TemplateDocumentFormatJSON, schemas.TemplateDocumentFormatYAML:
+	default:
+		return nil, myerr.Validation(fmt.Sprintf("Unsupported format %q, expected json or yaml", format), nil)
+	}
+
+	values := req.URL.Query()["id"]
+	ids := make([]int64, len(values))
+	for i, value := range values {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, myerr.Validation(fmt.Sprintf("Invalid id value %q, expected a positive integer", value), err)
+		}
+		ids[i] = id
+	}
+	return &schemas.ExportTemplatesRequest{TemplateIDs: ids, Format: format}, nil
+}
+
+// decodeImportTemplatesRequest декодирует POST запрос с переносимым документом в теле.
+// Документ читается как YAML при Content-Type application/yaml (или text/yaml), иначе как JSON.
+func decodeImportTemplatesRequest(ctx context.Context, req *http.Request) (interface{}, error) {
+	var document schemas.TemplateDocumentSchema
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		defer func() { _ = req.Body.Close() }()
+		if err := yaml.NewDecoder(req.Body).Decode(&document); err != nil {
+			if err == io.EOF {
+				return nil, errors.New("empty request body")
+			}
+			return nil, myerr.Validation("Invalid YAML document", err)
+		}
+	default:
+		if _, err := decodeJSONRequest(&document)(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if document.FormatVersion != schemas.TemplateDocumentVersion {
+		return nil, myerr.Validation(fmt.Sprintf("Unsupported template document version %d, expected %d", document.FormatVersion, schemas.TemplateDocumentVersion), nil)
+	}
+	return &schemas.ImportTemplatesRequest{Document: document}, nil
+}
+
+// decodeCloneTemplateRequest декодирует POST запрос с ID исходного шаблона в пути и названием копии в теле.
+func decodeCloneTemplateRequest(ctx context.Context, req *http.Request) (interface{}, error) {
+	id, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJSONRequest(&schemas.CloneTemplateRequest{})(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request := decoded.(*schemas.CloneTemplateRequest)
+	request.TemplateID = id
+	return request, nil
+}
+
+// decodeAddTemplateLineRequest декодирует POST запрос с ID шаблона в пути, версией шаблона в заголовке If-Match
+// и строкой шаблона в теле.
+func decodeAddTemplateLineRequest(ctx context.Context, req *http.Request) (interface{}, error) {
+	id, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	version, err := ifMatchVersion(req)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJSONRequest(&schemas.AddTemplateLineRequest{})(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request := decoded.(*schemas.AddTemplateLineRequest)
+	request.TemplateID = id
+	request.Version = version
+	return request, nil
+}
+
+// decodeUpdateTemplateLineRequest декодирует PUT запрос с ID шаблона и продукта в пути, версией шаблона
+// в заголовке If-Match и новым количеством в теле.
+func decodeUpdateTemplateLineRequest(ctx context.Context, req *http.Request) (interface{}, error) {
+	templateID, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	productID, err := extractID(req, "productID")
+	if err != nil {
+		return nil, err
+	}
+	version, err := ifMatchVersion(req)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJSONRequest(&schemas.UpdateTemplateLineRequest{})(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request := decoded.(*schemas.UpdateTemplateLineRequest)
+	request.TemplateID = templateID
+	request.ProductID = productID
+	request.Version = version
+	return request, nil
+}
+
+// decodeRemoveTemplateLineRequest декодирует DELETE запрос с ID шаблона и продукта в пути и версией шаблона в заголовке If-Match.
+func decodeRemoveTemplateLineRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	templateID, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	productID, err := extractID(req, "productID")
+	if err != nil {
+		return nil, err
+	}
+	version, err := ifMatchVersion(req)
+	if err != nil {
+		return nil, err
+	}
+	return &schemas.RemoveTemplateLineRequest{TemplateID: templateID, ProductID: productID, Version: version}, nil
+}
+
+// decodeGetTemplateRevisionRequest декодирует GET запрос с ID шаблона и номером ревизии в пути.
+func decodeGetTemplateRevisionRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	templateID, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	revision, err := extractID(req, "revision")
+	if err != nil {
+		return nil, err
+	}
+	return &schemas.GetTemplateRevisionRequest{TemplateID: templateID, Revision: revision}, nil
+}
+
+// decodeRestoreTemplateRevisionRequest декодирует POST запрос с ID шаблона и номером ревизии в пути
+// и текущей версией шаблона в заголовке If-Match: восстановление заменяет шаблон целиком, как и PUT.
+func decodeRestoreTemplateRevisionRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	templateID, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	revision, err := extractID(req, "revision")
+	if err != nil {
+		return nil, err
+	}
+	version, err := ifMatchVersion(req)
+	if err != nil {
+		return nil, err
+	}
+	return &schemas.RestoreTemplateRevisionRequest{TemplateID: templateID, Revision: revision, Version: version}, nil
+}
+
+// ifMatchVersion извлекает ожидаемую версию строки из заголовка If-Match.
+// Заголовок обязателен, чтобы клиент не перезаписал чужие изменения по невнимательности;
+// If-Match: * отключает проверку и возвращает 0. Тег представления вида "7-1a2b3c4d" проверяется по версии до дефиса.
+func ifMatchVersion(req *http.Request) (int64, error) {
+	header := strings.TrimSpace(req.Header.Get("If-Match"))
+	switch header {
+	case "":
+		return 0, myerr.PreconditionRequired("If-Match header with the resource ETag is required", nil)
+	case "*":
+		return 0, nil
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag, err := strconv.Unquote(strings.TrimSpace(tag))
+		if err != nil {
+			continue
+		}
+		version, _, _ := strings.Cut(tag, "-")
+		if v, err := strconv.ParseInt(version, 10, 64); err == nil && v > 0 {
+			return v, nil
+		}
+	}
+	return 0, myerr.PreconditionFailed(fmt.Sprintf("If-Match %s does not match any version of the resource", header), nil)
+}
+
+// decodeUploadProductImageRequest декодирует multipart POST запрос с ID продукта в пути и файлом в поле image.
+// Размер тела ограничивается заранее; точный размер и тип файла проверяет сервис.
+func decodeUploadProductImageRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	id, err := extractID(req, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = http.MaxBytesReader(nil, req.Body, service.MaxImageSize+multipartOverhead)
+	file, header, err := req.FormFile(imageFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, myerr.Validation(fmt.Sprintf("Image size exceeds %d bytes", service.MaxImageSize), err)
+		}
+		return nil, myerr.Validation(fmt.Sprintf("Missing image file in form field %s", imageFormField), err)
+	}
+	defer func() {
+		_ = file.Close()
+		_ = req.MultipartForm.RemoveAll()
+	}()
+
+	// Читаем на байт больше лимита, чтобы сервис мог отличить слишком большой файл.
+	data, err := io.ReadAll(io.LimitReader(file, service.MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemas.UploadProductImageRequest{
+		ProductID:   id,
+		ContentType: header.Header.Get("Content-Type"),
+		Data:        data,
+	}, nil
+}
+
+// decodeGetImageRequest декодирует GET запрос с именем файла изображения в пути.
+func decodeGetImageRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	return &schemas.GetImageRequest{Key: mux.Vars(req)["name"]}, nil
+}
+
+// decodeGetProductByBarcodeRequest декодирует GET запрос со штрихкодом в пути и необязательным параметром price_list.
+func decodeGetProductByBarcodeRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	return &schemas.GetProductByBarcodeRequest{
+		Code:      mux.Vars(req)["code"],
+		PriceList: req.URL.Query().Get("price_list"),
+		Locales:   requestLocales(req),
+	}, nil
+}
+
+// decodeGetAvailableProductsRequest декодирует GET запрос с параметрами route, region, at, price_list и lang.
+// Момент продажи передается в RFC 3339 с местным смещением; без параметра at берется текущее время.
+func decodeGetAvailableProductsRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	query := req.URL.Query()
+
+	at := time.Now()
+	if value := query.Get("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, myerr.Validation(fmt.Sprintf("Invalid sale time %q, expected RFC 3339", value), err)
+		}
+		at = parsed
+	}
+
+	return &schemas.GetAvailableProductsRequest{
+		Route:     query.Get("route"),
+		Region:    query.Get("region"),
+		At:        at,
+		PriceList: query.Get("price_list"),
+		Locales:   requestLocales(req),
+	}, nil
+}
+
 // decodeSearchTemplatesRequest декодирует GET запрос с параметрами query, limit и offset.
 func decodeSearchTemplatesRequest(_ context.Context, req *http.Request) (interface{}, error) {
 	query := req.URL.Query()
@@ -258,9 +1008,52 @@ func decodeSearchTemplatesRequest(_ context.Context, req *http.Request) (interfa
 		return nil, errors.New("invalid or missing offset parameter")
 	}
 
+	expand, err := queryExpand(req, schemas.ExpandContent)
+	if err != nil {
+		return nil, err
+	}
+
 	return &schemas.SearchTemplatesRequest{
-		Query:  searchString,
-		Limit:  limit,
-		Offset: offset,
+		Query:   searchString,
+		Limit:   limit,
+		Offset:  offset,
+		Locales: requestLocales(req),
+		Expand:  expand,
 	}, nil
 }
+
+// requestLocales возвращает предпочитаемые языки запроса: сначала параметр lang, затем языки из заголовка
+// Accept-Language в порядке убывания веса. Языки с нулевым весом и "*" пропускаются.
+func requestLocales(req *http.Request) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+	var preferences []weighted
+	for _, part := range strings.Split(req.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if locale := models.NormalizeLocale(tag); q > 0 && models.ValidLocale(locale) {
+			preferences = append(preferences, weighted{locale: locale, q: q})
+		}
+	}
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].q > preferences[j].q })
+
+	var locales []string
+	if lang := models.NormalizeLocale(req.URL.Query().Get("lang")); lang != "" {
+		locales = append(locales, lang)
+	}
+	for _, p := range preferences {
+		if !slices.Contains(locales, p.locale) {
+			locales = append(locales, p.locale)
+		}
+	}
+	return locales
+}
@@ -1,15 +1,19 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Chaika-Team/ChaikaGoods/internal/handler/schemas"
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
 	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
 	"github.com/go-kit/log"
 	"github.com/gorilla/mux"
@@ -47,21 +51,91 @@ func TestNewHTTPServerRoutes(t *testing.T) {
 		CreateProduct: func(ctx context.Context, request interface{}) (interface{}, error) {
 			return map[string]string{"handler": "CreateProduct"}, nil
 		},
+		ListTemplates: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "ListTemplates"}, nil
+		},
+		DeleteTemplate: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "DeleteTemplate"}, nil
+		},
+		UpdateTemplate: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "UpdateTemplate"}, nil
+		},
+		DiffTemplates: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "DiffTemplates"}, nil
+		},
+		ExportTemplates: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "ExportTemplates"}, nil
+		},
+		ImportTemplates: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "ImportTemplates"}, nil
+		},
+		BuildPickList: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "BuildPickList"}, nil
+		},
+		ScaleTemplate: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "ScaleTemplate"}, nil
+		},
+		CloneTemplate: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "CloneTemplate"}, nil
+		},
+		AddTemplateLine: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "AddTemplateLine"}, nil
+		},
+		UpdateTemplateLine: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "UpdateTemplateLine"}, nil
+		},
+		RemoveTemplateLine: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "RemoveTemplateLine"}, nil
+		},
+		ListTemplateRevisions: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "ListTemplateRevisions"}, nil
+		},
+		GetTemplateRevision: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "GetTemplateRevision"}, nil
+		},
+		RestoreTemplateRevision: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "RestoreTemplateRevision"}, nil
+		},
 		UpdateProduct: func(ctx context.Context, request interface{}) (interface{}, error) {
 			return map[string]string{"handler": "UpdateProduct"}, nil
 		},
 		DeleteProduct: func(ctx context.Context, request interface{}) (interface{}, error) {
 			return map[string]string{"handler": "DeleteProduct"}, nil
 		},
+		GetTemplateTotal: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "GetTemplateTotal"}, nil
+		},
+		ListPriceLists: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "ListPriceLists"}, nil
+		},
+		CreatePriceList: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "CreatePriceList"}, nil
+		},
+		SetPriceListPrices: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "SetPriceListPrices"}, nil
+		},
+		UploadProductImage: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "UploadProductImage"}, nil
+		},
+		GetImage: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "GetImage"}, nil
+		},
+		GetProductByBarcode: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "GetProductByBarcode"}, nil
+		},
+		GetAvailableProducts: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return map[string]string{"handler": "GetAvailableProducts"}, nil
+		},
 	}
 	logger := log.NewNopLogger()
-	server := NewHTTPServer(logger, dummyEndpoints)
+	server := NewHTTPServer(logger, dummyEndpoints, "no-cache")
 
 	tests := []struct {
 		name       string
 		method     string
 		url        string
 		body       string
+		ifMatch    string
 		expHandler string
 		expStatus  int
 	}{
@@ -73,6 +147,22 @@ func TestNewHTTPServerRoutes(t *testing.T) {
 			expHandler: "GetProductByID",
 			expStatus:  http.StatusOK,
 		},
+		{
+			name:       "Get Product By Barcode",
+			method:     "GET",
+			url:        "/api/v1/product/barcode/4600000000008",
+			body:       "",
+			expHandler: "GetProductByBarcode",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Get Available Products",
+			method:     "GET",
+			url:        "/api/v1/product/available?route=MOW-SPB",
+			body:       "",
+			expHandler: "GetAvailableProducts",
+			expStatus:  http.StatusOK,
+		},
 		{
 			name:       "Get All Products",
 			method:     "GET",
@@ -113,22 +203,283 @@ func TestNewHTTPServerRoutes(t *testing.T) {
 			expHandler: "CreateProduct",
 			expStatus:  http.StatusOK,
 		},
+		{
+			name:       "List Templates",
+			method:     "GET",
+			url:        "/api/v1/product/template",
+			body:       "",
+			expHandler: "ListTemplates",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Delete Template",
+			method:     "DELETE",
+			url:        "/api/v1/product/template/7?dry_run=true",
+			body:       "",
+			ifMatch:    `"2"`,
+			expHandler: "DeleteTemplate",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Delete Template With Invalid dry_run",
+			method:    "DELETE",
+			url:       "/api/v1/product/template/7?dry_run=maybe",
+			body:      "",
+			ifMatch:   `"2"`,
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "Delete Template Without If-Match",
+			method:    "DELETE",
+			url:       "/api/v1/product/template/7",
+			body:      "",
+			expStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "Update Template",
+			method:     "PUT",
+			url:        "/api/v1/product/template/7",
+			body:       `{"template":{"templateName":"Breakfast"}}`,
+			ifMatch:    `"2"`,
+			expHandler: "UpdateTemplate",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Diff Templates",
+			method:     "GET",
+			url:        "/api/v1/product/template/diff?a=1&b=2",
+			body:       "",
+			expHandler: "DiffTemplates",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Diff Templates Without b",
+			method:    "GET",
+			url:       "/api/v1/product/template/diff?a=1",
+			body:      "",
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Build Pick List",
+			method:     "POST",
+			url:        "/api/v1/product/template/pick-list",
+			body:       `{"templates":[{"id":1,"multiplier":3}]}`,
+			expHandler: "BuildPickList",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Build Pick List With Unsupported format",
+			method:    "POST",
+			url:       "/api/v1/product/template/pick-list?format=xml",
+			body:      `{"templates":[{"id":1,"multiplier":3}]}`,
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Scale Template",
+			method:     "GET",
+			url:        "/api/v1/product/template/7/scaled?passengers=300",
+			body:       "",
+			expHandler: "ScaleTemplate",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Scale Template With Invalid factor",
+			method:    "GET",
+			url:       "/api/v1/product/template/7/scaled?factor=double",
+			body:      "",
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Clone Template",
+			method:     "POST",
+			url:        "/api/v1/product/template/7/clone",
+			body:       `{"templateName":"Summer"}`,
+			expHandler: "CloneTemplate",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Add Template Line",
+			method:     "POST",
+			url:        "/api/v1/product/template/7/content",
+			body:       `{"content":{"productID":1,"quantity":2}}`,
+			ifMatch:    `"3"`,
+			expHandler: "AddTemplateLine",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Add Template Line Without If-Match",
+			method:    "POST",
+			url:       "/api/v1/product/template/7/content",
+			body:      `{"content":{"productID":1,"quantity":2}}`,
+			expStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "Update Template Line",
+			method:     "PUT",
+			url:        "/api/v1/product/template/7/content/1",
+			body:       `{"quantity":3}`,
+			ifMatch:    `"4"`,
+			expHandler: "UpdateTemplateLine",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Update Template Line Without If-Match",
+			method:    "PUT",
+			url:       "/api/v1/product/template/7/content/1",
+			body:      `{"quantity":3}`,
+			expStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "Remove Template Line",
+			method:     "DELETE",
+			url:        "/api/v1/product/template/7/content/1",
+			body:       "",
+			ifMatch:    "*",
+			expHandler: "RemoveTemplateLine",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Remove Template Line Without If-Match",
+			method:    "DELETE",
+			url:       "/api/v1/product/template/7/content/1",
+			body:      "",
+			expStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "Export Templates",
+			method:     "GET",
+			url:        "/api/v1/product/template/export?id=1&id=2",
+			body:       "",
+			expHandler: "ExportTemplates",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Export Templates with unsupported format",
+			method:    "GET",
+			url:       "/api/v1/product/template/export?format=xml",
+			body:      "",
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Import Templates",
+			method:     "POST",
+			url:        "/api/v1/product/template/import",
+			body:       `{"formatVersion":1,"templates":[{"templateName":"Breakfast","content":[{"sku":"SKU1","quantity":2}]}]}`,
+			expHandler: "ImportTemplates",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Import Templates with unsupported version",
+			method:    "POST",
+			url:       "/api/v1/product/template/import",
+			body:      `{"formatVersion":2,"templates":[]}`,
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "List Template Revisions",
+			method:     "GET",
+			url:        "/api/v1/product/template/7/revisions",
+			body:       "",
+			expHandler: "ListTemplateRevisions",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Get Template Revision",
+			method:     "GET",
+			url:        "/api/v1/product/template/7/revisions/3",
+			body:       "",
+			expHandler: "GetTemplateRevision",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Restore Template Revision",
+			method:     "POST",
+			url:        "/api/v1/product/template/7/revisions/3/restore",
+			body:       "",
+			ifMatch:    `"5"`,
+			expHandler: "RestoreTemplateRevision",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:      "Restore Template Revision without If-Match",
+			method:    "POST",
+			url:       "/api/v1/product/template/7/revisions/3/restore",
+			body:      "",
+			expStatus: http.StatusPreconditionRequired,
+		},
 		{
 			name:       "Update Product",
 			method:     "PUT",
 			url:        "/api/v1/product/789",
 			body:       `{"dummy":"data"}`,
+			ifMatch:    `"3"`,
 			expHandler: "UpdateProduct",
 			expStatus:  http.StatusOK,
 		},
+		{
+			name:      "Update Product Without If-Match",
+			method:    "PUT",
+			url:       "/api/v1/product/789",
+			body:      `{"dummy":"data"}`,
+			expStatus: http.StatusPreconditionRequired,
+		},
 		{
 			name:       "Delete Product",
 			method:     "DELETE",
 			url:        "/api/v1/product/101",
 			body:       "",
+			ifMatch:    "*",
 			expHandler: "DeleteProduct",
 			expStatus:  http.StatusOK,
 		},
+		{
+			name:      "Delete Product With Weak ETag",
+			method:    "DELETE",
+			url:       "/api/v1/product/101",
+			body:      "",
+			ifMatch:   `W/"3"`,
+			expStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "Get Template Total",
+			method:     "GET",
+			url:        "/api/v1/product/template/456/total?price_list=first_class",
+			body:       "",
+			expHandler: "GetTemplateTotal",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "List Price Lists",
+			method:     "GET",
+			url:        "/api/v1/product/price-list",
+			body:       "",
+			expHandler: "ListPriceLists",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Create Price List",
+			method:     "POST",
+			url:        "/api/v1/product/price-list",
+			body:       `{"dummy":"data"}`,
+			expHandler: "CreatePriceList",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Set Price List Prices",
+			method:     "PUT",
+			url:        "/api/v1/product/price-list/7/prices",
+			body:       `{"prices":[]}`,
+			expHandler: "SetPriceListPrices",
+			expStatus:  http.StatusOK,
+		},
+		{
+			name:       "Get Image",
+			method:     "GET",
+			url:        "/api/v1/product/image/product-1-abc.jpg",
+			body:       "",
+			expHandler: "GetImage",
+			expStatus:  http.StatusOK,
+		},
 		{
 			name:      "Swagger Docs",
 			method:    "GET",
@@ -147,6 +498,9 @@ func TestNewHTTPServerRoutes(t *testing.T) {
 			} else {
 				req = httptest.NewRequest(tc.method, tc.url, nil)
 			}
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			rec := httptest.NewRecorder()
 			server.ServeHTTP(rec, req)
@@ -185,6 +539,20 @@ func TestEncodeResponseCauseEffect(t *testing.T) {
 	assert.JSONEq(t, string(expected), rec.Body.String())
 }
 
+// Техника тест-дизайна: Причинно-следственный анализ
+// Описание:
+//   - Тест для функции encodeResponse с ответом, реализующим httpGoKit.Headerer.
+//   - Причина: ответ несет версию ресурса; следствие: в заголовке ETag передается версия в кавычках.
+func TestEncodeResponseHeaders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	response := schemas.GetProductByIDResponse{Product: schemas.ProductSchema{ID: 1, Version: 42}}
+
+	err := encodeResponse(log.NewNopLogger())(context.Background(), rec, response)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"42"`, rec.Header().Get("ETag"))
+}
+
 // -----------------------------------
 // Тест для EncodeErrorResponse
 // -----------------------------------
@@ -219,6 +587,24 @@ func TestEncodeErrorResponseDecisionTable(t *testing.T) {
 			expectedStatus: http.StatusConflict,
 			expectedMsg:    "duplicate resource",
 		},
+		{
+			name:           "Conflict error",
+			err:            &myerr.AppError{Type: myerr.ErrorTypeConflict, Message: "conflicting state"},
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "conflicting state",
+		},
+		{
+			name:           "PreconditionFailed error",
+			err:            &myerr.AppError{Type: myerr.ErrorTypePreconditionFailed, Message: "version mismatch"},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedMsg:    "version mismatch",
+		},
+		{
+			name:           "PreconditionRequired error",
+			err:            &myerr.AppError{Type: myerr.ErrorTypePreconditionRequired, Message: "If-Match required"},
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedMsg:    "If-Match required",
+		},
 		{
 			name:           "Unknown error",
 			err:            &myerr.AppError{Type: myerr.ErrorTypeUnknown, Message: internalServerError},
@@ -249,6 +635,31 @@ func TestEncodeErrorResponseDecisionTable(t *testing.T) {
 	}
 }
 
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции encodeErrorResponse с ошибкой проверки, содержащей проблемы полей.
+//   - Все проблемы возвращаются в детализированном ответе вместе с индексами строк.
+func TestEncodeErrorResponseFieldErrors(t *testing.T) {
+	line := 2
+	err := myerr.ValidationFields("Template has 2 problem(s)", []myerr.FieldError{
+		{Field: "templateName", Message: "Template name must not be empty"},
+		{Field: "quantity", Index: &line, Message: "Quantity of product with ID 3 must be positive"},
+	})
+	rec := httptest.NewRecorder()
+
+	encodeErrorResponse(log.NewNopLogger())(context.Background(), err, rec)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var resp schemas.DetailedErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, "Template has 2 problem(s)", resp.Message)
+	assert.Equal(t, []schemas.FieldErrorSchema{
+		{Field: "templateName", Message: "Template name must not be empty"},
+		{Field: "quantity", Index: &line, Message: "Quantity of product with ID 3 must be positive"},
+	}, resp.Errors)
+}
+
 // -----------------------------------
 // Тесты для DecodeJSONRequest
 // -----------------------------------
@@ -342,6 +753,18 @@ func TestDecodeRequestWithIDDecisionTableValidSchemas(t *testing.T) {
 			schema:     &schemas.DeleteProductRequest{},
 			expectedID: 303,
 		},
+		{
+			name:       "DeleteTemplateRequest",
+			url:        "/api/v1/product/template/505?dry_run=true",
+			schema:     &schemas.DeleteTemplateRequest{},
+			expectedID: 505,
+		},
+		{
+			name:       "ScaleTemplateRequest",
+			url:        "/api/v1/product/template/606/scaled?factor=2.5",
+			schema:     &schemas.ScaleTemplateRequest{},
+			expectedID: 606,
+		},
 		{
 			name:       "AddTemplateRequest",
 			url:        "/api/v1/template/404",
@@ -354,8 +777,9 @@ func TestDecodeRequestWithIDDecisionTableValidSchemas(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			req := httptest.NewRequest("GET", tc.url, nil)
 			parts := strings.Split(tc.url, "/")
-			idStr := parts[len(parts)-1]
+			idStr, _, _ := strings.Cut(parts[len(parts)-1], "?")
 			req = mux.SetURLVars(req, map[string]string{"id": idStr})
+			req.Header.Set("If-Match", `"1"`)
 			decoder := decodeRequestWithID(logger, "id", tc.schema)
 			result, err := decoder(context.Background(), req)
 
@@ -369,6 +793,15 @@ func TestDecodeRequestWithIDDecisionTableValidSchemas(t *testing.T) {
 			case *schemas.DeleteProductRequest:
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedID, s.ProductID)
+			case *schemas.DeleteTemplateRequest:
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedID, s.TemplateID)
+				assert.True(t, s.DryRun)
+				assert.Equal(t, int64(1), s.Version)
+			case *schemas.ScaleTemplateRequest:
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedID, s.TemplateID)
+				assert.Equal(t, 2.5, s.Factor)
 			default:
 				assert.Error(t, err)
 			}
@@ -402,6 +835,22 @@ func TestDecodeSearchTemplatesRequestDecisionTable(t *testing.T) {
 				Offset: 0,
 			},
 		},
+		{
+			name:          "Expand content",
+			queryParams:   "limit=5&offset=0&expand=content",
+			expectedError: "",
+			expectedRequest: &schemas.SearchTemplatesRequest{
+				Limit:  5,
+				Offset: 0,
+				Expand: []string{"content"},
+			},
+		},
+		{
+			name:            "Unsupported expand",
+			queryParams:     "limit=5&offset=0&expand=products",
+			expectedError:   `Unsupported expand value "products", expected one of [content]`,
+			expectedRequest: nil,
+		},
 		{
 			name:            "Invalid limit (zero)",
 			queryParams:     "query=example&limit=0&offset=10",
@@ -436,7 +885,502 @@ func TestDecodeSearchTemplatesRequestDecisionTable(t *testing.T) {
 				assert.Equal(t, tc.expectedRequest.Query, stReq.Query)
 				assert.Equal(t, tc.expectedRequest.Limit, stReq.Limit)
 				assert.Equal(t, tc.expectedRequest.Offset, stReq.Offset)
+				assert.Equal(t, tc.expectedRequest.Expand, stReq.Expand)
+			}
+		})
+	}
+}
+
+// -----------------------------------
+// Тесты для загрузки и выдачи изображений
+// -----------------------------------
+
+// newMultipartImageRequest формирует multipart-запрос с файлом в указанном поле формы.
+func newMultipartImageRequest(t *testing.T, field string, data []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "image.png")
+	assert.NoError(t, err)
+	_, err = part.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest("POST", "/api/v1/product/5/image", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return mux.SetURLVars(req, map[string]string{"id": "5"})
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции decodeUploadProductImageRequest.
+//   - Классы эквивалентности: файл передан в поле image, файл передан в другом поле.
+func TestDecodeUploadProductImageRequest(t *testing.T) {
+	data := []byte("\x89PNG\r\n\x1a\nfake")
+
+	decoded, err := decodeUploadProductImageRequest(context.Background(), newMultipartImageRequest(t, imageFormField, data))
+	assert.NoError(t, err)
+	req, ok := decoded.(*schemas.UploadProductImageRequest)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), req.ProductID)
+	assert.Equal(t, data, req.Data)
+
+	_, err = decodeUploadProductImageRequest(context.Background(), newMultipartImageRequest(t, "file", data))
+	assert.Error(t, err)
+	assert.True(t, myerr.IsValidation(err), "Expected error to be of type Validation")
+}
+
+// Техника тест-дизайна: Причинно-следственный анализ
+// Описание:
+//   - Тест для функции encodeImageResponse.
+//   - Изображение записывается в тело как есть, с его собственным типом содержимого.
+func TestEncodeImageResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	encoder := encodeImageResponse(log.NewNopLogger())
+
+	err := encoder(context.Background(), rec, schemas.GetImageResponse{ContentType: "image/png", Data: []byte("png")})
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "png", rec.Body.String())
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции decodeGetAllProductsRequest.
+//   - Классы эквивалентности: аллергены через запятую, повторяющийся параметр, пустые элементы и регистр.
+func TestDecodeGetAllProductsRequestAllergens(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/product?price_list=BYN&exclude_allergens=Gluten,%20nuts,&exclude_allergens=lactose", nil)
+
+	decoded, err := decodeGetAllProductsRequest(context.Background(), req)
+	assert.NoError(t, err)
+	request, ok := decoded.(*schemas.GetAllProductsRequest)
+	assert.True(t, ok)
+	assert.Equal(t, "BYN", request.PriceList)
+	assert.Equal(t, []string{"gluten", "nuts", "lactose"}, request.ExcludeAllergens)
+}
+
+// Техника тест-дизайна: Таблица решений
+// Описание:
+//   - Тест для функции requestLocales.
+//   - Условия: параметр lang, заголовок Accept-Language с весами, нулевой вес, "*", повторяющиеся языки.
+func TestRequestLocales(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		acceptLanguage string
+		expected       []string
+	}{
+		{name: "Без предпочтений", url: "/api/v1/product", expected: nil},
+		{name: "Только lang", url: "/api/v1/product?lang=EN", expected: []string{"en"}},
+		{name: "Accept-Language по весам", url: "/api/v1/product", acceptLanguage: "zh-CN;q=0.5, en-US, ru;q=0.8", expected: []string{"en", "ru", "zh"}},
+		{name: "lang важнее заголовка", url: "/api/v1/product?lang=zh", acceptLanguage: "en, zh;q=0.9", expected: []string{"zh", "en"}},
+		{name: "Нулевой вес и звездочка", url: "/api/v1/product", acceptLanguage: "*, de;q=0, en;q=0.1", expected: []string{"en"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.url, nil)
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
 			}
+			assert.Equal(t, tc.expected, requestLocales(req))
 		})
 	}
 }
+
+// Техника тест-дизайна: Таблица решений
+// Описание:
+//   - Тест для функции ifMatchVersion.
+//   - Условия: заголовок отсутствует, "*", одна версия, список версий, слабый ETag, тег представления, мусор.
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  string
+		expected int64
+		check    func(error) bool
+	}{
+		{name: "Заголовок отсутствует", check: myerr.IsPreconditionRequired},
+		{name: "Звездочка отключает проверку", ifMatch: "*", expected: 0},
+		{name: "Одна версия", ifMatch: `"7"`, expected: 7},
+		{name: "Список версий", ifMatch: `W/"1", "12"`, expected: 12},
+		{name: "Слабый ETag", ifMatch: `W/"7"`, check: myerr.IsPreconditionFailed},
+		{name: "Тег представления", ifMatch: `"7-1a2b3c4d"`, expected: 7},
+		{name: "Версия без кавычек", ifMatch: "7", check: myerr.IsPreconditionFailed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", "/api/v1/product/1", nil)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			version, err := ifMatchVersion(req)
+
+			if tc.check != nil {
+				assert.True(t, tc.check(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, version)
+		})
+	}
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции decodeUpdateProductRequest.
+//   - ID продукта берется из пути, а не из тела, версия — из заголовка If-Match.
+func TestDecodeUpdateProductRequest(t *testing.T) {
+	req := httptest.NewRequest("PUT", "/api/v1/product/5", strings.NewReader(`{"product":{"id":99,"name":"Tea"}}`))
+	req = mux.SetURLVars(req, map[string]string{"id": "5"})
+	req.Header.Set("If-Match", `"3"`)
+
+	decoded, err := decodeUpdateProductRequest(context.Background(), req)
+
+	assert.NoError(t, err)
+	request, ok := decoded.(*schemas.UpdateProductRequest)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), request.Product.ID)
+	assert.Equal(t, "Tea", request.Product.Name)
+	assert.Equal(t, int64(3), request.Version)
+}
+
+// Техника тест-дизайна: Эквивалентное разбиение
+// Описание:
+//   - Тест для функции queryExpand.
+//   - Классы: параметр не задан, значения через запятую и повтором параметра, регистр и пробелы, неподдерживаемое значение.
+func TestQueryExpand(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "Без параметра", query: "", expected: nil},
+		{name: "Одно значение", query: "expand=products", expected: []string{"products"}},
+		{name: "Регистр и пробелы", query: "expand=%20Products%20,", expected: []string{"products"}},
+		{name: "Повтор параметра", query: "expand=products&expand=products", expected: []string{"products", "products"}},
+		{name: "Неподдерживаемое значение", query: "expand=prices", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/product/template/1?"+tc.query, nil)
+
+			expand, err := queryExpand(req, schemas.ExpandProducts)
+
+			if tc.wantErr {
+				assert.True(t, myerr.IsValidation(err), "Expected error to be of type Validation")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, expand)
+		})
+	}
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции decodeUpdateTemplateRequest.
+//   - ID шаблона берется из пути, а не из тела, версия — из заголовка If-Match; без заголовка запрос отклоняется.
+func TestDecodeUpdateTemplateRequest(t *testing.T) {
+	body := `{"template":{"id":99,"templateName":"Breakfast","content":[{"productID":1,"quantity":2}]}}`
+	req := httptest.NewRequest("PUT", "/api/v1/product/template/7", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"id": "7"})
+	req.Header.Set("If-Match", `"2"`)
+
+	decoded, err := decodeUpdateTemplateRequest(context.Background(), req)
+
+	assert.NoError(t, err)
+	request, ok := decoded.(*schemas.UpdateTemplateRequest)
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), request.Template.ID)
+	assert.Equal(t, "Breakfast", request.Template.TemplateName)
+	assert.Len(t, request.Template.Content, 1)
+	assert.Equal(t, int64(2), request.Version)
+
+	req = httptest.NewRequest("PUT", "/api/v1/product/template/7", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"id": "7"})
+	_, err = decodeUpdateTemplateRequest(context.Background(), req)
+	assert.True(t, myerr.IsPreconditionRequired(err), "Expected error to be of type PreconditionRequired")
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции decodeImportTemplatesRequest.
+//   - Документ в формате YAML читается по Content-Type и дает тот же запрос, что и JSON.
+func TestDecodeImportTemplatesRequest(t *testing.T) {
+	const body = `formatVersion: 1
+templates:
+  - templateName: Breakfast
+    translations:
+      en:
+        name: Breakfast
+    passengers: 60
+    content:
+      - sku: SKU1
+        quantity: 2
+      - sku: SKU2
+        quantity: 1
+        unit: box
+`
+	expected := schemas.TemplateDocumentSchema{
+		FormatVersion: 1,
+		Templates: []schemas.PortableTemplateSchema{{
+			TemplateName: "Breakfast",
+			Translations: map[string]schemas.TranslationSchema{"en": {Name: "Breakfast"}},
+			Passengers:   60,
+			Content:      []schemas.PortableTemplateLineSchema{{SKU: "SKU1", Quantity: 2}, {SKU: "SKU2", Quantity: 1, Unit: "box"}},
+		}},
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/product/template/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/yaml; charset=utf-8")
+	decoded, err := decodeImportTemplatesRequest(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &schemas.ImportTemplatesRequest{Document: expected}, decoded)
+
+	jsonBody, err := json.Marshal(expected)
+	assert.NoError(t, err)
+	req = httptest.NewRequest("POST", "/api/v1/product/template/import", bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	decoded, err = decodeImportTemplatesRequest(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &schemas.ImportTemplatesRequest{Document: expected}, decoded)
+
+	req = httptest.NewRequest("POST", "/api/v1/product/template/import", strings.NewReader("templates: [}"))
+	req.Header.Set("Content-Type", "text/yaml")
+	_, err = decodeImportTemplatesRequest(context.Background(), req)
+	assert.True(t, myerr.IsValidation(err), "Expected error to be of type Validation")
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции encodeTemplateDocumentResponse.
+//   - При format=yaml документ кодируется как YAML, иначе — как JSON; в обоих случаях без обертки ответа.
+func TestEncodeTemplateDocumentResponse(t *testing.T) {
+	logger := log.NewNopLogger()
+	document := schemas.TemplateDocumentSchema{
+		FormatVersion: 1,
+		Templates: []schemas.PortableTemplateSchema{{
+			TemplateName: "Breakfast",
+			Content:      []schemas.PortableTemplateLineSchema{{SKU: "SKU1", Quantity: 2}},
+		}},
+	}
+
+	rec := httptest.NewRecorder()
+	err := encodeTemplateDocumentResponse(logger)(context.Background(), rec, schemas.ExportTemplatesResponse{Document: document, Format: schemas.TemplateDocumentFormatYAML})
+	assert.NoError(t, err)
+	assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "formatVersion: 1\ntemplates:\n  - templateName: Breakfast\n    content:\n      - sku: SKU1\n        quantity: 2\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	err = encodeTemplateDocumentResponse(logger)(context.Background(), rec, schemas.ExportTemplatesResponse{Document: document, Format: schemas.TemplateDocumentFormatJSON})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"formatVersion":1,"templates":[{"templateName":"Breakfast","content":[{"sku":"SKU1","quantity":2}]}]}`, rec.Body.String())
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для AuthorMiddleware.
+//   - Классы эквивалентности: автор передан, автор не передан, слишком длинное имя автора обрезается.
+func TestAuthorMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "Автор передан", header: " ivanov ", want: "ivanov"},
+		{name: "Автор не передан", header: "", want: ""},
+		{name: "Длинное имя", header: strings.Repeat("я", maxAuthorLength+10), want: strings.Repeat("я", maxAuthorLength)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			handler := AuthorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = models.AuthorFromContext(r.Context())
+			}))
+			req := httptest.NewRequest("POST", "/api/v1/product/template/7/content", nil)
+			if tc.header != "" {
+				req.Header.Set("X-User", tc.header)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции encodePickListResponse.
+//   - При format=csv список кодируется как CSV с заголовком, иначе — как JSON.
+func TestEncodePickListResponse(t *testing.T) {
+	items := []schemas.PickListItemSchema{
+		{ProductID: 2, SKU: "SKU2", Name: "Coffee, ground", Unit: "pcs", Quantity: 3},
+		{ProductID: 1, SKU: "SKU1", Name: "Tea", Unit: "pcs", Quantity: 16},
+	}
+	encoder := encodePickListResponse(log.NewNopLogger())
+
+	rec := httptest.NewRecorder()
+	err := encoder(context.Background(), rec, schemas.BuildPickListResponse{Items: items, Format: schemas.PickListFormatCSV})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "product_id,sku,name,unit,quantity\n2,SKU2,\"Coffee, ground\",pcs,3\n1,SKU1,Tea,pcs,16\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	err = encoder(context.Background(), rec, schemas.BuildPickListResponse{Items: items, Format: schemas.PickListFormatJSON})
+
+	assert.NoError(t, err)
+	var resp schemas.BuildPickListResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, items, resp.Items)
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функции decodeUpdateTemplateLineRequest.
+//   - ID шаблона и продукта берутся из пути, версия — из If-Match, количество и упаковка — из тела;
+//     неверный ID продукта отклоняется.
+func TestDecodeUpdateTemplateLineRequest(t *testing.T) {
+	req := httptest.NewRequest("PUT", "/api/v1/product/template/7/content/2", strings.NewReader(`{"quantity":3,"unit":"box"}`))
+	req = mux.SetURLVars(req, map[string]string{"id": "7", "productID": "2"})
+	req.Header.Set("If-Match", `"4"`)
+
+	decoded, err := decodeUpdateTemplateLineRequest(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &schemas.UpdateTemplateLineRequest{TemplateID: 7, ProductID: 2, Quantity: 3, Unit: "box", Version: 4}, decoded)
+
+	req = httptest.NewRequest("PUT", "/api/v1/product/template/7/content/abc", strings.NewReader(`{"quantity":3}`))
+	req = mux.SetURLVars(req, map[string]string{"id": "7", "productID": "abc"})
+	_, err = decodeUpdateTemplateLineRequest(context.Background(), req)
+	assert.Error(t, err)
+}
+
+// Техника тест-дизайна: Эквивалентное разбиение
+// Описание:
+//   - Тест для функции decodeGetAvailableProductsRequest.
+//   - Классы: момент продажи с местным смещением, без параметра at (текущее время) и в неверном формате.
+func TestDecodeGetAvailableProductsRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/product/available?route=MOW-SPB&region=RU-MOW&at=2024-06-03T08:30:00%2B03:00", nil)
+
+	decoded, err := decodeGetAvailableProductsRequest(context.Background(), req)
+
+	assert.NoError(t, err)
+	request, ok := decoded.(*schemas.GetAvailableProductsRequest)
+	assert.True(t, ok)
+	assert.Equal(t, "MOW-SPB", request.Route)
+	assert.Equal(t, "RU-MOW", request.Region)
+	assert.Equal(t, "08:30", request.At.Format("15:04"), "Expected local time of the sale to be kept")
+
+	req = httptest.NewRequest("GET", "/api/v1/product/available?route=MOW-SPB", nil)
+	decoded, err = decodeGetAvailableProductsRequest(context.Background(), req)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), decoded.(*schemas.GetAvailableProductsRequest).At, time.Minute)
+
+	req = httptest.NewRequest("GET", "/api/v1/product/available?route=MOW-SPB&at=tomorrow", nil)
+	_, err = decodeGetAvailableProductsRequest(context.Background(), req)
+	assert.True(t, myerr.IsValidation(err), "Expected error to be of type Validation")
+}
+
+// Техника тест-дизайна: Таблица решений
+// Описание:
+//   - Тест для функции notModified.
+//   - Условия: совпадение ETag (в том числе слабое и в списке), "*", приоритет If-None-Match над If-Modified-Since,
+//     время изменения до и после If-Modified-Since, некорректная дата.
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		cond     conditions
+		expected bool
+	}{
+		{name: "Без условий", cond: conditions{}, expected: false},
+		{name: "Совпадающий ETag", cond: conditions{ifNoneMatch: `"3"`}, expected: true},
+		{name: "Слабое сравнение ETag", cond: conditions{ifNoneMatch: `"1", W/"3"`}, expected: true},
+		{name: "Звездочка", cond: conditions{ifNoneMatch: "*"}, expected: true},
+		{name: "Другой ETag важнее свежей даты", cond: conditions{ifNoneMatch: `"2"`, ifModifiedSince: modified.Format(http.TimeFormat)}, expected: false},
+		{name: "Не изменялся с указанной даты", cond: conditions{ifModifiedSince: modified.Format(http.TimeFormat)}, expected: true},
+		{name: "Изменился после указанной даты", cond: conditions{ifModifiedSince: modified.Add(-time.Second).Format(http.TimeFormat)}, expected: false},
+		{name: "Некорректная дата", cond: conditions{ifModifiedSince: "yesterday"}, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, notModified(tc.cond, `"3"`, modified))
+		})
+	}
+}
+
+// Техника тест-дизайна: Причинно-следственный анализ
+// Описание:
+//   - Тест условного GET через маршруты NewHTTPServer.
+//   - Причина: клиент присылает ETag из предыдущего ответа; следствие: 304 без тела с Cache-Control из конфигурации.
+//   - Для списка без собственного ETag тег вычисляется по телу и меняется вместе с ним; Last-Modified у списка нет,
+//     так как удаление продукта не сдвигает время изменения остальных.
+//   - Тег продукта зависит от прайс-листа и языков, но начинается с версии и принимается в If-Match.
+func TestConditionalGET(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	price := "1.00"
+	endpoints := Endpoints{
+		GetProductByID: func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(*schemas.GetProductByIDRequest)
+			return schemas.GetProductByIDResponse{Product: schemas.ProductSchema{ID: 1, Version: 3}, Modified: modified, PriceList: req.PriceList, Locales: req.Locales}, nil
+		},
+		GetAllProducts: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return schemas.GetAllProductsResponse{Products: []schemas.ProductSchema{{ID: 1, Name: price}}}, nil
+		},
+	}
+	server := NewHTTPServer(log.NewNopLogger(), endpoints, "private, max-age=60")
+
+	get := func(url, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := get("/api/v1/product/1", "")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, `"3"`, first.Header().Get("ETag"))
+	assert.Equal(t, modified.Format(http.TimeFormat), first.Header().Get("Last-Modified"))
+	assert.Equal(t, "private, max-age=60", first.Header().Get("Cache-Control"))
+
+	cached := get("/api/v1/product/1", `"3"`)
+	assert.Equal(t, http.StatusNotModified, cached.Code)
+	assert.Empty(t, cached.Body.String())
+	assert.Equal(t, "private, max-age=60", cached.Header().Get("Cache-Control"))
+
+	list := get("/api/v1/product", "")
+	assert.Equal(t, http.StatusOK, list.Code)
+	listETag := list.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(listETag, `W/"`))
+	assert.Empty(t, list.Header().Get("Last-Modified"))
+	assert.Equal(t, http.StatusNotModified, get("/api/v1/product", listETag).Code)
+
+	price = "2.00"
+	assert.Equal(t, http.StatusOK, get("/api/v1/product", listETag).Code)
+
+	localized := get("/api/v1/product/1?lang=en", `"3"`)
+	assert.Equal(t, http.StatusOK, localized.Code)
+	localizedETag := localized.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(localizedETag, `"3-`))
+	assert.Equal(t, http.StatusNotModified, get("/api/v1/product/1?lang=en", localizedETag).Code)
+	priced := get("/api/v1/product/1?lang=en&price_list=airport", localizedETag)
+	assert.Equal(t, http.StatusOK, priced.Code)
+	assert.NotEqual(t, localizedETag, priced.Header().Get("ETag"))
+
+	req := httptest.NewRequest("DELETE", "/api/v1/product/1", nil)
+	req.Header.Set("If-Match", localizedETag)
+	version, err := ifMatchVersion(req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), version)
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DateLayout — формат дат в периодах продаж.
+	DateLayout = "2006-01-02"
+	// ClockLayout — формат времени суток в окнах продаж.
+	ClockLayout = "15:04"
+)
+
+// Weekday — день недели в правилах доступности.
+type Weekday string
+
+const (
+	Monday    Weekday = "mon"
+	Tuesday   Weekday = "tue"
+	Wednesday Weekday = "wed"
+	Thursday  Weekday = "thu"
+	Friday    Weekday = "fri"
+	Saturday  Weekday = "sat"
+	Sunday    Weekday = "sun"
+)
+
+// Weekdays перечисляет дни недели, начиная с понедельника.
+var Weekdays = []Weekday{Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday}
+
+// Valid сообщает, входит ли день недели в перечень допустимых.
+func (d Weekday) Valid() bool {
+	return slices.Contains(Weekdays, d)
+}
+
+// weekdayOf возвращает день недели момента t в его часовом поясе.
+func weekdayOf(t time.Time) Weekday {
+	return Weekdays[(int(t.Weekday())+6)%7]
+}
+
+// TimeWindow описывает окно продаж по дням недели, например завтрак по будням с 07:00 до 11:00.
+// Время задается как ЧЧ:ММ по местному времени продажи, конец в окно не входит.
+// Окно, конец которого раньше начала, переходит через полночь и относится к дню своего начала.
+type TimeWindow struct {
+	Days  []Weekday `json:"days"`  // Дни недели; пустой список означает все дни
+	Start string    `json:"start"` // Начало окна, ЧЧ:ММ
+	End   string    `json:"end"`   // Конец окна, ЧЧ:ММ
+}
+
+// contains сообщает, попадает ли момент t в окно.
+func (w TimeWindow) contains(t time.Time) bool {
+	clock := t.Format(ClockLayout)
+	onDay := func(d Weekday) bool { return len(w.Days) == 0 || slices.Contains(w.Days, d) }
+	if w.Start < w.End {
+		return onDay(weekdayOf(t)) && clock >= w.Start && clock < w.End
+	}
+	return (onDay(weekdayOf(t)) && clock >= w.Start) || (onDay(weekdayOf(t.AddDate(0, 0, -1))) && clock < w.End)
+}
+
+// AccessList описывает списки разрешенных и запрещенных значений, например кодов маршрутов или регионов.
+// Запрет сильнее разрешения; пустой список разрешенных значений разрешает все, что не запрещено.
+type AccessList struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// allows сообщает, разрешено ли значение. Сравнение не учитывает регистр.
+func (l AccessList) allows(value string) bool {
+	equal := func(item string) bool { return strings.EqualFold(item, value) }
+	if slices.ContainsFunc(l.Deny, equal) {
+		return false
+	}
+	return len(l.Allow) == 0 || slices.ContainsFunc(l.Allow, equal)
+}
+
+// DateRange описывает период продаж; обе даты в формате ГГГГ-ММ-ДД входят в период.
+type DateRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Availability описывает правила, когда и где товар можно продавать. Незаданные правила продажу не ограничивают.
+type Availability struct {
+	Windows []TimeWindow `json:"windows,omitempty"` // Окна продаж; товар продается, если момент попадает хотя бы в одно
+	Routes  AccessList   `json:"routes"`            // Маршруты, на которых товар можно или нельзя продавать
+	Regions AccessList   `json:"regions"`           // Регионы, в которых товар можно или нельзя продавать
+	Periods []DateRange  `json:"periods,omitempty"` // Периоды продаж; товар продается, если дата попадает хотя бы в один
+}
+
+// IsZero сообщает, что правила не заданы и товар можно продавать всегда и везде.
+func (a Availability) IsZero() bool {
+	return len(a.Windows) == 0 && len(a.Periods) == 0 &&
+		len(a.Routes.Allow) == 0 && len(a.Routes.Deny) == 0 &&
+		len(a.Regions.Allow) == 0 && len(a.Regions.Deny) == 0
+}
+
+// SaleContext описывает, где и когда происходит продажа.
+type SaleContext struct {
+	Route  string    // Код маршрута
+	Region string    // Код региона; пустая строка, если регион неизвестен
+	At     time.Time // Местное время продажи: день недели и время суток берутся в его часовом поясе
+}
+
+// Allows сообщает, можно ли продавать товар в указанных месте и время.
+// Если заданы разрешенные регионы, а регион продажи неизвестен, продажа запрещена.
+func (a Availability) Allows(sale SaleContext) bool {
+	if !a.Routes.allows(sale.Route) || !a.Regions.allows(sale.Region) {
+		return false
+	}
+	if len(a.Periods) > 0 {
+		date := sale.At.Format(DateLayout)
+		if !slices.ContainsFunc(a.Periods, func(p DateRange) bool { return date >= p.From && date <= p.To }) {
+			return false
+		}
+	}
+	if len(a.Windows) > 0 {
+		return slices.ContainsFunc(a.Windows, func(w TimeWindow) bool { return w.contains(sale.At) })
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// gtinLength — длина GTIN-14, к которой приводятся все штрихкоды для хранения и поиска.
+const gtinLength = 14
+
+// NormalizeBarcode проверяет штрихкод EAN-8, UPC-A (GTIN-12), EAN-13 или GTIN-14 и возвращает его в виде GTIN-14.
+// Один и тот же товар может быть закодирован как EAN-13 и как GTIN-14 с ведущим нулем, поэтому сравнивать
+// штрихкоды нужно в нормализованном виде.
+func NormalizeBarcode(code string) (string, error) {
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return "", myerr.Validation(fmt.Sprintf("Barcode %q must have 8, 12, 13 or 14 digits", code), nil)
+	}
+	if !isDigits(code) {
+		return "", myerr.Validation(fmt.Sprintf("Barcode %q must contain only digits", code), nil)
+	}
+	if checkDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return "", myerr.Validation(fmt.Sprintf("Barcode %q has an invalid check digit", code), nil)
+	}
+
+	gtin := make([]byte, gtinLength)
+	for i := range gtinLength - len(code) {
+		gtin[i] = '0'
+	}
+	copy(gtin[gtinLength-len(code):], code)
+	return string(gtin), nil
+}
+
+// checkDigit вычисляет контрольную цифру GS1: цифры справа налево умножаются попеременно на 3 и 1.
+func checkDigit(payload string) byte {
+	sum := 0
+	for i := len(payload) - 1; i >= 0; i-- {
+		digit := int(payload[i] - '0')
+		if (len(payload)-1-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
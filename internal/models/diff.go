@@ -0,0 +1,19 @@
+package models
+
+// TemplateDiff описывает структурные различия двух шаблонов.
+// Строки в списках идут в порядке шаблона, в котором они встречаются, и содержат продукты для отображения названий.
+type TemplateDiff struct {
+	A       Template           // Первый шаблон без состава
+	B       Template           // Второй шаблон без состава
+	OnlyInA []TemplateContent  // Продукты, которые есть только в первом шаблоне
+	OnlyInB []TemplateContent  // Продукты, которые есть только во втором шаблоне
+	Changed []TemplateLineDiff // Продукты, которые есть в обоих шаблонах в разных количествах
+}
+
+// TemplateLineDiff описывает продукт, количество которого в двух шаблонах различается.
+type TemplateLineDiff struct {
+	ProductID int64
+	Product   *Product
+	QuantityA int
+	QuantityB int
+}
@@ -0,0 +1,91 @@
+package models
+
+import "strings"
+
+// DefaultLocale — язык, на котором заданы основные поля Name, Description и TemplateName.
+const DefaultLocale = "ru"
+
+// Translation описывает перевод названия и описания на один язык. Пустое поле означает, что перевода нет.
+type Translation struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// NormalizeLocale приводит языковой тег вида "en-US" или "EN" к коду языка "en".
+func NormalizeLocale(tag string) string {
+	language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	return strings.ToLower(language)
+}
+
+// ValidLocale сообщает, является ли строка кодом языка ISO 639 из двух или трех строчных латинских букв.
+func ValidLocale(locale string) bool {
+	if len(locale) < 2 || len(locale) > 3 {
+		return false
+	}
+	for _, r := range locale {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// pickTranslation возвращает перевод на первый из предпочитаемых языков, для которого он есть.
+// Если раньше встретится язык по умолчанию, перевод не нужен и возвращается false.
+func pickTranslation(translations map[string]Translation, locales []string) (Translation, bool) {
+	for _, locale := range locales {
+		if locale == DefaultLocale {
+			return Translation{}, false
+		}
+		if t, ok := translations[locale]; ok {
+			return t, true
+		}
+	}
+	return Translation{}, false
+}
+
+// Localize возвращает копию продукта с названием и описанием на первом доступном из предпочитаемых языков.
+// Непереведенные поля остаются на языке по умолчанию. Варианты локализуются так же.
+func (p Product) Localize(locales []string) Product {
+	if t, ok := pickTranslation(p.Translations, locales); ok {
+		if t.Name != "" {
+			p.Name = t.Name
+		}
+		if t.Description != "" {
+			p.Description = t.Description
+		}
+	}
+	if len(p.Variants) > 0 {
+		variants := make([]Product, len(p.Variants))
+		for i, v := range p.Variants {
+			variants[i] = v.Localize(locales)
+		}
+		p.Variants = variants
+	}
+	return p
+}
+
+// Localize возвращает копию шаблона с названием и описанием на первом доступном из предпочитаемых языков.
+// Раскрытые продукты строк локализуются так же.
+func (t Template) Localize(locales []string) Template {
+	if tr, ok := pickTranslation(t.Translations, locales); ok {
+		if tr.Name != "" {
+			t.TemplateName = tr.Name
+		}
+		if tr.Description != "" {
+			t.Description = tr.Description
+		}
+	}
+	if len(t.Content) > 0 && len(locales) > 0 {
+		content := make([]TemplateContent, len(t.Content))
+		for i, c := range t.Content {
+			if c.Product != nil {
+				product := c.Product.Localize(locales)
+				c.Product = &product
+			}
+			content[i] = c
+		}
+		t.Content = content
+	}
+	return t
+}
@@ -1,13 +1,85 @@
 package models
 
+import "time"
+
+// BaseCurrency — валюта базового прайс-листа, цены которого хранятся в колонке price таблицы product.
+const BaseCurrency = "RUB"
+
 // Product описывает товар.
 type Product struct {
-	ID          int64   `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	ImageURL    string  `json:"imageurl"`
-	SKU         string  `json:"sku"`
+	ID           int64             `json:"id"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Price        Money             `json:"price"`
+	Currency     string            `json:"currency"`
+	VATRate      VATRate           `json:"vat_rate"`
+	ImageURL     string            `json:"imageurl"`
+	ThumbnailURL string            `json:"thumbnailurl"`
+	SKU          string            `json:"sku"`
+	ParentID     int64             `json:"parent_id"` // ID родительского продукта; 0, если продукт не является вариантом
+	Options      map[string]string `json:"options"`   // Значения опций варианта, например {"flavor": "green", "volume": "0.5 L"}
+	Variants     []Product         `json:"variants"`  // Варианты родительского продукта; заполняется только при чтении
+	Barcodes     []string          `json:"barcodes"`  // Штрихкоды EAN-8, EAN-13 или GTIN в том виде, в котором их ввели
+	Unit         string            `json:"unit"`      // Базовая единица измерения, в которой товар продается и учитывается в шаблонах
+	Packaging    []Packaging       `json:"packaging"` // Уровни упаковки, в которых товар поставляется
+	Nutrition    *Nutrition        `json:"nutrition"` // Пищевая ценность на 100 г; nil для непродовольственных товаров
+	Allergens    []Allergen        `json:"allergens"` // Аллергены, которые содержит товар
+	// Translations — переводы названия и описания по кодам языков; язык по умолчанию хранится в Name и Description
+	Translations map[string]Translation `json:"translations"`
+	Status       ProductStatus          `json:"status"`       // Этап жизненного цикла; пустой статус при изменении сохраняет текущий
+	Availability Availability           `json:"availability"` // Правила, когда и где товар можно продавать
+	// Version — версия строки для оптимистической блокировки, увеличивается при каждом изменении.
+	// В запросах на изменение это версия, которую видел клиент; 0 отключает проверку.
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"` // Время последнего изменения; заполняется только при чтении
+}
+
+// IsVariant сообщает, является ли продукт вариантом другого продукта.
+func (p Product) IsVariant() bool {
+	return p.ParentID != 0
+}
+
+// LastModified возвращает время последнего изменения продукта с учетом его вариантов.
+func (p Product) LastModified() time.Time {
+	modified := p.UpdatedAt
+	for _, v := range p.Variants {
+		if v.UpdatedAt.After(modified) {
+			modified = v.UpdatedAt
+		}
+	}
+	return modified
+}
+
+// PriceBreakdown возвращает разложение цены продукта по его ставке НДС.
+func (p Product) PriceBreakdown() PriceBreakdown {
+	return p.VATRate.Breakdown(p.Price)
+}
+
+// ProductImage описывает ссылки на загруженное изображение продукта и его миниатюру.
+type ProductImage struct {
+	ImageURL     string `json:"imageurl"`
+	ThumbnailURL string `json:"thumbnailurl"`
+}
+
+// Blob описывает двоичный объект хранилища вместе с его MIME-типом.
+type Blob struct {
+	ContentType string
+	Data        []byte
+}
+
+// ProductQuery описывает параметры чтения продуктов.
+type ProductQuery struct {
+	PriceList        string     // Имя прайс-листа; пустая строка означает базовый прайс-лист
+	ExcludeAllergens []Allergen // Аллергены, продукты с которыми не попадают в список
+	Locales          []string   // Предпочитаемые языки в порядке убывания приоритета
+}
+
+// TemplateQuery описывает параметры чтения шаблонов.
+type TemplateQuery struct {
+	Locales        []string // Предпочитаемые языки в порядке убывания приоритета
+	ExpandProducts bool     // Подставить в строки шаблона продукты с ценами и посчитать итоги
+	WithContent    bool     // Загрузить состав шаблонов в списках и результатах поиска
+	PriceList      string   // Имя прайс-листа для цен раскрытых продуктов; пустая строка — базовый прайс-лист
 }
 
 // Template описывает шаблон товаров.
@@ -16,10 +88,58 @@ type Template struct {
 	TemplateName string            `json:"template_name"`
 	Description  string            `json:"description"`
 	Content      []TemplateContent `json:"content"`
+	// Translations — переводы названия и описания по кодам языков; язык по умолчанию хранится в TemplateName и Description
+	Translations map[string]Translation `json:"translations"`
+	// Passengers — число пассажиров, на которое рассчитан состав; 0, если шаблон нельзя масштабировать по числу пассажиров
+	Passengers int `json:"passengers"`
+	// Version — версия строки для оптимистической блокировки, увеличивается при каждом изменении.
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"` // Время последнего изменения; заполняется только при чтении
+	// Total — итоги шаблона; заполняются только при раскрытии продуктов
+	Total *TemplateTotal `json:"total,omitempty"`
+}
+
+// LastModified возвращает время последнего изменения шаблона или раскрытых в нем продуктов.
+func (t Template) LastModified() time.Time {
+	modified := t.UpdatedAt
+	for _, c := range t.Content {
+		if c.Product != nil && c.Product.UpdatedAt.After(modified) {
+			modified = c.Product.UpdatedAt
+		}
+	}
+	return modified
 }
 
 // TemplateContent описывает одно содержимое шаблона.
+// Шаблоны хранят количество в базовых единицах продукта; Unit задается только во входящих запросах.
 type TemplateContent struct {
+	ProductID int64  `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	Unit      string `json:"unit"` // Упаковка, в которой задано количество; пустая строка — базовая единица продукта
+	// Product — продукт строки с ценой; заполняется только при раскрытии продуктов
+	Product *Product `json:"product,omitempty"`
+}
+
+// PriceList описывает именованный прайс-лист со своей валютой.
+type PriceList struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+}
+
+// ProductPrice описывает цену продукта в прайс-листе.
+type ProductPrice struct {
 	ProductID int64 `json:"product_id"`
-	Quantity  int   `json:"quantity"`
+	Price     Money `json:"price"`
+}
+
+// TemplateTotal описывает стоимость шаблона по выбранному прайс-листу.
+type TemplateTotal struct {
+	TemplateID int64  `json:"template_id"`
+	PriceList  string `json:"price_list"`
+	Currency   string `json:"currency"`
+	Items      int    `json:"items"` // Число единиц товара во всех строках
+	Net        Money  `json:"net"`
+	VAT        Money  `json:"vat"`
+	Total      Money  `json:"total"` // Сумма с НДС
 }
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// moneyFractionDigits — количество знаков после запятой, совпадает с numeric(10,2) в БД.
+const moneyFractionDigits = 2
+
+// moneyScale — число минимальных единиц (копеек) в одной денежной единице.
+const moneyScale = 100
+
+// moneyMaxUnits — граница целой части суммы: numeric(10,2) в БД хранит не более 8 цифр до запятой.
+const moneyMaxUnits = 100_000_000
+
+// Money описывает денежную сумму в минимальных единицах валюты (копейках).
+// В JSON сумма кодируется строкой вида "149.99", чтобы избежать ошибок округления float64.
+type Money int64
+
+// ParseMoney разбирает десятичную строку вида "149.99" в Money.
+// Допускается не более двух знаков после запятой; модуль суммы должен быть меньше 10^8, как в numeric(10,2).
+func ParseMoney(s string) (Money, error) {
+	raw := strings.TrimSpace(s)
+	str := raw
+	negative := false
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		negative = str[0] == '-'
+		str = str[1:]
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(str, ".")
+	if intPart == "" || (hasDot && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, myerr.Validation(fmt.Sprintf("Invalid money amount %q", raw), nil)
+	}
+	if len(fracPart) > moneyFractionDigits {
+		return 0, myerr.Validation(fmt.Sprintf("Money amount %q has more than %d fraction digits", raw, moneyFractionDigits), nil)
+	}
+	fracPart += strings.Repeat("0", moneyFractionDigits-len(fracPart))
+
+	// Целая часть ограничена до умножения, поэтому units*moneyScale+cents не может переполнить int64
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || units >= moneyMaxUnits {
+		return 0, myerr.Validation(fmt.Sprintf("Money amount %q is out of range", raw), nil)
+	}
+	cents, _ := strconv.ParseInt(fracPart, 10, 64)
+
+	amount := units*moneyScale + cents
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+// isDigits проверяет, что строка состоит только из десятичных цифр.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Mul возвращает сумму, умноженную на целое количество.
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// String возвращает сумму в виде десятичной строки с двумя знаками после запятой.
+func (m Money) String() string {
+	sign := ""
+	amount := int64(m)
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/moneyScale, amount%moneyScale)
+}
+
+// MarshalJSON кодирует сумму строкой.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON принимает сумму как строку ("149.99") или как число (149.99),
+// разбирая её текстовое представление без потери точности.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var str string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &str); err != nil {
+			return myerr.Validation("Invalid money amount", err)
+		}
+	} else {
+		str = string(data)
+	}
+
+	parsed, err := ParseMoney(str)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan реализует sql.Scanner для чтения значений numeric из БД.
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case string:
+		return m.scanString(v)
+	case []byte:
+		return m.scanString(string(v))
+	case int64:
+		*m = Money(v * moneyScale)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+}
+
+// scanString разбирает текстовое представление numeric, пришедшее из БД.
+func (m *Money) scanString(s string) error {
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into Money: %w", s, err)
+	}
+	*m = parsed
+	return nil
+}
+
+// Value реализует driver.Valuer для записи суммы в колонку numeric.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
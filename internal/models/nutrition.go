@@ -0,0 +1,55 @@
+package models
+
+// Nutrition описывает пищевую ценность продукта на 100 г.
+type Nutrition struct {
+	Kcal          float64 `json:"kcal"`          // Энергетическая ценность, ккал
+	Proteins      float64 `json:"proteins"`      // Белки, г
+	Fats          float64 `json:"fats"`          // Жиры, г
+	Carbohydrates float64 `json:"carbohydrates"` // Углеводы, г
+}
+
+// Allergen — аллерген, о наличии которого нужно предупреждать покупателя.
+type Allergen string
+
+const (
+	AllergenGluten    Allergen = "gluten"
+	AllergenLactose   Allergen = "lactose"
+	AllergenNuts      Allergen = "nuts"
+	AllergenPeanuts   Allergen = "peanuts"
+	AllergenEggs      Allergen = "eggs"
+	AllergenFish      Allergen = "fish"
+	AllergenShellfish Allergen = "shellfish"
+	AllergenSoy       Allergen = "soy"
+	AllergenSesame    Allergen = "sesame"
+	AllergenCelery    Allergen = "celery"
+	AllergenMustard   Allergen = "mustard"
+	AllergenSulphites Allergen = "sulphites"
+)
+
+// Allergens перечисляет все допустимые аллергены.
+var Allergens = []Allergen{
+	AllergenGluten, AllergenLactose, AllergenNuts, AllergenPeanuts, AllergenEggs, AllergenFish,
+	AllergenShellfish, AllergenSoy, AllergenSesame, AllergenCelery, AllergenMustard, AllergenSulphites,
+}
+
+// Valid сообщает, входит ли аллерген в перечень допустимых.
+func (a Allergen) Valid() bool {
+	for _, allergen := range Allergens {
+		if a == allergen {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAnyAllergen сообщает, содержит ли продукт хотя бы один из перечисленных аллергенов.
+func (p Product) ContainsAnyAllergen(allergens []Allergen) bool {
+	for _, contained := range p.Allergens {
+		for _, allergen := range allergens {
+			if contained == allergen {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package models
+
+// Порядок строк сводного списка комплектации.
+const (
+	PickListSortName = "name" // По названию продукта
+	PickListSortSKU  = "sku"  // По артикулу продукта
+)
+
+// PickListSorts перечисляет допустимые порядки строк сводного списка комплектации.
+var PickListSorts = []string{PickListSortName, PickListSortSKU}
+
+// PickListEntry задает шаблон, входящий в сводный список комплектации, и число его комплектов (например, вагонов).
+type PickListEntry struct {
+	TemplateID int64
+	Multiplier int
+}
+
+// PickListQuery задает порядок строк и язык названий сводного списка комплектации.
+type PickListQuery struct {
+	SortBy  string   // Порядок строк; пустая строка — по названию
+	Locales []string // Предпочитаемые языки в порядке убывания приоритета
+}
+
+// PickListItem — строка сводного списка комплектации: продукт и его общее количество в базовых единицах по всем шаблонам.
+type PickListItem struct {
+	Product  Product
+	Quantity int
+}
@@ -8,24 +8,61 @@ import (
 type ProductRepository interface {
 	GetProductByID(ctx context.Context, id int64) (Product, error)
 	GetAllProducts(ctx context.Context) ([]Product, error)
+	GetProductsByIDs(ctx context.Context, ids []int64) ([]Product, error)
+	GetProductsBySKUs(ctx context.Context, skus []string) ([]Product, error)
+	GetProductVariants(ctx context.Context, parentIDs []int64) ([]Product, error)
+	GetProductIDByBarcode(ctx context.Context, gtin string) (int64, error)
 	CreateProduct(ctx context.Context, p *Product) (int64, error)
 	UpdateProduct(ctx context.Context, p *Product) error
-	DeleteProduct(ctx context.Context, id int64) error
+	DeleteProduct(ctx context.Context, id int64, version int64) error
+	SetProductImage(ctx context.Context, id int64, image ProductImage) error
 }
 
 // TemplateRepository defines methods for template-related database operations.
 type TemplateRepository interface {
 	GetTemplateByID(ctx context.Context, id int64) (Template, error)
 	GetProductsByTemplateID(ctx context.Context, templateID int64) ([]TemplateContent, error)
+	GetTemplateContents(ctx context.Context, templateIDs []int64) (map[int64][]TemplateContent, error)
+	GetTemplateContentProducts(ctx context.Context, templateIDs []int64) (map[int64][]TemplateContent, error)
 	ListTemplates(ctx context.Context) ([]Template, error)
 	CreateTemplate(ctx context.Context, template *Template) error
-	DeleteTemplate(ctx context.Context, templateID int64) error
+	UpdateTemplate(ctx context.Context, template *Template) error
+	CloneTemplate(ctx context.Context, templateID int64, name string) (int64, error)
+	ImportTemplates(ctx context.Context, templates []Template) ([]TemplateImportResult, error)
+	AddTemplateContent(ctx context.Context, templateID int64, content TemplateContent, version int64) (int64, error)
+	UpdateTemplateContent(ctx context.Context, templateID int64, content TemplateContent, version int64) (int64, error)
+	DeleteTemplateContent(ctx context.Context, templateID int64, productID int64, version int64) (int64, error)
+	DeleteTemplate(ctx context.Context, templateID int64, version int64) error
+	ListTemplateRevisions(ctx context.Context, templateID int64) ([]TemplateRevision, error)
+	GetTemplateRevision(ctx context.Context, templateID int64, revision int64) (TemplateRevision, error)
 	SearchTemplates(ctx context.Context, searchString string, limit int64, offset int64) ([]Template, error)
 	GetAllTemplates(ctx context.Context, limit int64, offset int64) ([]Template, error)
 }
 
-// GoodsRepository объединяет репозитории для продуктов и шаблонов.
+// PriceListRepository defines methods for price-list-related database operations.
+type PriceListRepository interface {
+	GetPriceListByName(ctx context.Context, name string) (PriceList, error)
+	ListPriceLists(ctx context.Context) ([]PriceList, error)
+	CreatePriceList(ctx context.Context, priceList *PriceList) (int64, error)
+	SetPriceListPrices(ctx context.Context, priceListID int64, prices []ProductPrice) error
+	GetPriceListPrices(ctx context.Context, priceListID int64, productIDs []int64) (map[int64]Money, error)
+}
+
+// GoodsRepository объединяет репозитории для продуктов, шаблонов и прайс-листов.
 type GoodsRepository interface {
 	ProductRepository
 	TemplateRepository
+	PriceListRepository
+}
+
+// BlobStore defines methods for storing binary objects such as product images.
+type BlobStore interface {
+	// Put saves the object under the given key, replacing an existing one.
+	Put(ctx context.Context, key string, blob Blob) error
+	// Get returns the object stored under the given key.
+	Get(ctx context.Context, key string) (Blob, error)
+	// Delete removes the object stored under the given key. Missing objects are ignored.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of the object stored under the given key.
+	URL(key string) string
 }
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// TemplateRevision описывает неизменяемый снимок шаблона, сохраненный после одного его изменения.
+type TemplateRevision struct {
+	TemplateID int64
+	// Revision — версия шаблона, которую получил шаблон в результате изменения
+	Revision int64
+	// Template — название, описание, переводы, число пассажиров и состав на момент изменения; в списках ревизий состав не загружается
+	Template  Template
+	Author    string    // Автор изменения; пустая строка, если автор не передан
+	CreatedAt time.Time // Время изменения
+	Deleted   bool      // Ревизия фиксирует удаление шаблона; ее состав — состав шаблона перед удалением
+}
+
+type authorKey struct{}
+
+// WithAuthor возвращает контекст с автором изменений, который попадет в ревизии шаблонов.
+func WithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+// AuthorFromContext возвращает автора изменений из контекста или пустую строку.
+func AuthorFromContext(ctx context.Context) string {
+	author, _ := ctx.Value(authorKey{}).(string)
+	return author
+}
@@ -0,0 +1,43 @@
+package models
+
+import "slices"
+
+// ProductStatus описывает этап жизненного цикла товара.
+type ProductStatus string
+
+const (
+	ProductStatusDraft        ProductStatus = "draft"        // Черновик: не виден в публичных списках и не попадает в шаблоны
+	ProductStatusActive       ProductStatus = "active"       // Товар в продаже
+	ProductStatusDiscontinued ProductStatus = "discontinued" // Снят с продажи: читается, но не добавляется в новые шаблоны
+)
+
+// ProductStatuses перечисляет все допустимые статусы товара.
+var ProductStatuses = []ProductStatus{ProductStatusDraft, ProductStatusActive, ProductStatusDiscontinued}
+
+// statusTransitions перечисляет разрешенные переходы между статусами.
+// В черновик товар не возвращается: он мог уже попасть в шаблоны и публичные списки.
+var statusTransitions = map[ProductStatus][]ProductStatus{
+	ProductStatusDraft:        {ProductStatusActive},
+	ProductStatusActive:       {ProductStatusDiscontinued},
+	ProductStatusDiscontinued: {ProductStatusActive},
+}
+
+// Valid сообщает, входит ли статус в перечень допустимых.
+func (s ProductStatus) Valid() bool {
+	return slices.Contains(ProductStatuses, s)
+}
+
+// CanTransitionTo сообщает, можно ли перевести товар из статуса s в статус next. Сохранение статуса разрешено всегда.
+func (s ProductStatus) CanTransitionTo(next ProductStatus) bool {
+	return s == next || slices.Contains(statusTransitions[s], next)
+}
+
+// IsDraft сообщает, является ли продукт черновиком.
+func (p Product) IsDraft() bool {
+	return p.Status == ProductStatusDraft
+}
+
+// IsActive сообщает, продается ли продукт.
+func (p Product) IsActive() bool {
+	return p.Status == ProductStatusActive
+}
@@ -0,0 +1,26 @@
+package models
+
+// PortableTemplate описывает шаблон для переноса между базами данных: вместо внутренних ID шаблон определяется
+// названием, а продукты в строках — артикулами.
+type PortableTemplate struct {
+	TemplateName string
+	Description  string
+	Translations map[string]Translation
+	Passengers   int
+	Content      []PortableTemplateLine
+}
+
+// PortableTemplateLine описывает строку переносимого шаблона.
+type PortableTemplateLine struct {
+	SKU      string
+	Quantity int
+	Unit     string // Упаковка, в которой задано количество; при экспорте всегда пустая — количество в базовых единицах
+}
+
+// TemplateImportResult описывает, что импорт сделал с одним шаблоном.
+type TemplateImportResult struct {
+	TemplateID   int64
+	TemplateName string
+	Version      int64 // Версия шаблона после импорта
+	Created      bool  // true, если шаблон создан, false — если заменен существующий шаблон с тем же названием
+}
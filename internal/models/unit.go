@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// UnitPiece — базовая единица измерения по умолчанию: товар продается поштучно.
+const UnitPiece = "pcs"
+
+// Packaging описывает уровень упаковки продукта, например коробку из 24 штук или паллету из 1440 штук.
+// Вложенность уровней задается числом базовых единиц, поэтому паллета из 60 коробок по 24 штуки описывается как 1440 штук.
+type Packaging struct {
+	Name     string `json:"name"`     // Название упаковки, например "box" или "pallet"
+	Quantity int    `json:"quantity"` // Число базовых единиц продукта в одной упаковке
+	// RoundScaled — количества масштабированного шаблона округляются вверх до целого числа таких упаковок
+	RoundScaled bool `json:"round_scaled,omitempty"`
+}
+
+// BaseQuantity переводит количество, заданное в упаковке unit, в базовые единицы продукта.
+// Пустая строка и базовая единица продукта означают, что количество уже задано в базовых единицах.
+func (p Product) BaseQuantity(quantity int, unit string) (int, error) {
+	if unit == "" || unit == p.Unit {
+		return quantity, nil
+	}
+	for _, packaging := range p.Packaging {
+		if packaging.Name != unit {
+			continue
+		}
+		if quantity > math.MaxInt32/packaging.Quantity {
+			return 0, myerr.Validation(fmt.Sprintf("Quantity %d %s of product with ID %d is too large", quantity, unit, p.ID), nil)
+		}
+		return quantity * packaging.Quantity, nil
+	}
+	return 0, myerr.Validation(fmt.Sprintf("Product with ID %d has no packaging %q", p.ID, unit), nil)
+}
+
+// ScaledQuantity умножает количество строки шаблона на factor и округляет результат вверх до целых базовых единиц,
+// а если у продукта есть упаковка с RoundScaled — до целого числа таких упаковок.
+func (p Product) ScaledQuantity(quantity int, factor float64) (int, error) {
+	// Погрешность отбрасывается, чтобы 10 * 1.1 не превратилось в 12 из-за двоичного представления дробей.
+	scaled := math.Ceil(float64(quantity)*factor - 1e-9)
+	if scaled > math.MaxInt32 {
+		return 0, myerr.Validation(fmt.Sprintf("Scaled quantity of product with ID %d is too large", p.ID), nil)
+	}
+	for _, packaging := range p.Packaging {
+		if packaging.RoundScaled && packaging.Quantity > 0 {
+			boxes := (int(scaled) + packaging.Quantity - 1) / packaging.Quantity
+			return boxes * packaging.Quantity, nil
+		}
+	}
+	return int(scaled), nil
+}
+
+// TemplateScale задает масштабирование шаблона: либо число пассажиров, либо множитель.
+type TemplateScale struct {
+	Passengers int     // Число пассажиров, для которого пересчитывается состав; множитель — отношение к базовому числу шаблона
+	Factor     float64 // Множитель количеств, если число пассажиров не задано
+}
@@ -0,0 +1,70 @@
+package models
+
+// VATRate описывает ставку НДС товара.
+type VATRate string
+
+const (
+	VATRate20 VATRate = "20"     // Основная ставка 20%
+	VATRate10 VATRate = "10"     // Льготная ставка 10%
+	VATRate0  VATRate = "0"      // Нулевая ставка
+	VATExempt VATRate = "exempt" // Без НДС
+)
+
+// VATRates перечисляет все допустимые ставки НДС.
+var VATRates = []VATRate{VATRate20, VATRate10, VATRate0, VATExempt}
+
+// Valid сообщает, входит ли ставка в перечень допустимых.
+func (r VATRate) Valid() bool {
+	for _, rate := range VATRates {
+		if r == rate {
+			return true
+		}
+	}
+	return false
+}
+
+// percent возвращает ставку в процентах; для товаров без НДС и нулевой ставки это 0.
+func (r VATRate) percent() int64 {
+	switch r {
+	case VATRate20:
+		return 20
+	case VATRate10:
+		return 10
+	default:
+		return 0
+	}
+}
+
+// PriceBreakdown описывает разложение цены на сумму без НДС, НДС и сумму с НДС.
+type PriceBreakdown struct {
+	Net   Money `json:"net"`
+	VAT   Money `json:"vat"`
+	Gross Money `json:"gross"`
+}
+
+// Add возвращает сумму двух разложений.
+func (b PriceBreakdown) Add(other PriceBreakdown) PriceBreakdown {
+	return PriceBreakdown{
+		Net:   b.Net + other.Net,
+		VAT:   b.VAT + other.VAT,
+		Gross: b.Gross + other.Gross,
+	}
+}
+
+// Breakdown выделяет НДС из цены с НДС. Сумма НДС округляется до копейки по правилам
+// арифметического округления, сумма без НДС получается вычитанием, поэтому Net + VAT == Gross.
+func (r VATRate) Breakdown(gross Money) PriceBreakdown {
+	percent := r.percent()
+	if percent == 0 {
+		return PriceBreakdown{Net: gross, Gross: gross}
+	}
+
+	divisor := 100 + percent
+	amount := int64(gross)
+	sign := int64(1)
+	if amount < 0 {
+		sign, amount = -1, -amount
+	}
+	vat := Money(sign * ((amount*percent*2 + divisor) / (divisor * 2)))
+	return PriceBreakdown{Net: gross - vat, VAT: vat, Gross: gross}
+}
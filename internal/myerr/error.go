@@ -17,6 +17,10 @@ const (
 	ErrorTypeForbidden    ErrorType = "FORBIDDEN"
 	ErrorTypeConflict     ErrorType = "CONFLICT"
 	ErrorTypeUnknown      ErrorType = "UNKNOWN"
+	// ErrorTypePreconditionFailed means the resource changed since the client read it.
+	ErrorTypePreconditionFailed ErrorType = "PRECONDITION_FAILED"
+	// ErrorTypePreconditionRequired means the client must state which version it is changing.
+	ErrorTypePreconditionRequired ErrorType = "PRECONDITION_REQUIRED"
 )
 
 // AppError represents a structured error with type and optional context
@@ -100,6 +104,14 @@ func Unknown(message string, cause error) *AppError {
 	return New(ErrorTypeUnknown, message, cause, nil)
 }
 
+func PreconditionFailed(message string, cause error) *AppError {
+	return New(ErrorTypePreconditionFailed, message, cause, nil)
+}
+
+func PreconditionRequired(message string, cause error) *AppError {
+	return New(ErrorTypePreconditionRequired, message, cause, nil)
+}
+
 // WithContext adds context to an existing AppError
 func WithContext(err error, context map[string]interface{}) *AppError {
 	if appErr, ok := IsAppError(err); ok {
@@ -114,6 +126,31 @@ func WithContext(err error, context map[string]interface{}) *AppError {
 	return New(ErrorTypeUnknown, "unknown error with context", err, context)
 }
 
+// ContextKeyFields is the AppError context key holding the field-level problems of a request
+const ContextKeyFields = "fields"
+
+// FieldError describes a problem with a single field of a request.
+// Index points to the offending list element (for example, a template line) and is nil for top-level fields.
+type FieldError struct {
+	Field   string `json:"field"`
+	Index   *int   `json:"index,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationFields creates a validation error reporting all field-level problems at once
+func ValidationFields(message string, fields []FieldError) *AppError {
+	return New(ErrorTypeValidation, message, nil, map[string]interface{}{ContextKeyFields: fields})
+}
+
+// Fields returns the field-level problems attached to an error, if any
+func Fields(err error) []FieldError {
+	if appErr, ok := IsAppError(err); ok {
+		fields, _ := appErr.Context[ContextKeyFields].([]FieldError)
+		return fields
+	}
+	return nil
+}
+
 // IsType checks if an error is of a specific type
 func IsType(err error, errType ErrorType) bool {
 	if appErr, ok := IsAppError(err); ok {
@@ -153,3 +190,11 @@ func IsConflict(err error) bool {
 func IsUnknown(err error) bool {
 	return IsType(err, ErrorTypeUnknown)
 }
+
+func IsPreconditionFailed(err error) bool {
+	return IsType(err, ErrorTypePreconditionFailed)
+}
+
+func IsPreconditionRequired(err error) bool {
+	return IsType(err, ErrorTypePreconditionRequired)
+}
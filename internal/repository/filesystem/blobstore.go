@@ -0,0 +1,112 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// defaultContentType is returned for objects whose extension has no known MIME type.
+const defaultContentType = "application/octet-stream"
+
+// BlobStore implements the models.BlobStore interface on top of a local directory.
+// Every object is stored as a separate file named after its key; the MIME type is derived from the key extension.
+type BlobStore struct {
+	root    string
+	baseURL string
+}
+
+// NewBlobStore creates a new instance of BlobStore, creating the root directory if necessary.
+// baseURL is the public URL prefix under which stored objects are served.
+func NewBlobStore(root string, baseURL string) (models.BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory %s: %w", root, err)
+	}
+	return &BlobStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// Put writes the object to a temporary file and renames it, so readers never see a partially written object.
+func (s *BlobStore) Put(_ context.Context, key string, blob models.Blob) (err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.root, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(blob.Data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get reads the object stored under the given key.
+func (s *BlobStore) Get(_ context.Context, key string) (models.Blob, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return models.Blob{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return models.Blob{}, myerr.NotFound(fmt.Sprintf("Object %s not found", key), nil)
+		}
+		return models.Blob{}, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+	return models.Blob{ContentType: contentType, Data: data}, nil
+}
+
+// Delete removes the object stored under the given key.
+func (s *BlobStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL returns the public URL of the object stored under the given key.
+func (s *BlobStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path maps a key to a file inside the root directory. Keys are flat file names;
+// anything that could escape the root directory is rejected as not found.
+func (s *BlobStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, ".") || strings.ContainsAny(key, `/\`) {
+		return "", myerr.NotFound(fmt.Sprintf("Object %s not found", key), nil)
+	}
+	return filepath.Join(s.root, key), nil
+}
@@ -0,0 +1,51 @@
+package filesystem_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/Chaika-Team/ChaikaGoods/internal/repository/filesystem"
+)
+
+// Техника тест-дизайна: Причинно-следственный анализ
+// Описание:
+//   - Тест для методов Put, Get, Delete и URL.
+//   - Сохраненный объект читается с типом, определенным по расширению; после удаления он не находится.
+func TestBlobStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := filesystem.NewBlobStore(t.TempDir(), "/api/v1/product/image/")
+	assert.NoError(t, err)
+
+	key := "product-1-abc.png"
+	assert.NoError(t, store.Put(ctx, key, models.Blob{ContentType: "image/png", Data: []byte("png")}))
+
+	blob, err := store.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", blob.ContentType)
+	assert.Equal(t, []byte("png"), blob.Data)
+	assert.Equal(t, "/api/v1/product/image/"+key, store.URL(key))
+
+	assert.NoError(t, store.Delete(ctx, key))
+	assert.NoError(t, store.Delete(ctx, key), "Deleting a missing object must not fail")
+
+	_, err = store.Get(ctx, key)
+	assert.True(t, myerr.IsNotFound(err), "Expected error to be of type NotFound")
+}
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для метода Get с ключами, выходящими за пределы корневого каталога.
+func TestBlobStoreRejectsPathTraversal(t *testing.T) {
+	ctx := context.Background()
+	store, err := filesystem.NewBlobStore(t.TempDir(), "")
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "../secret", "dir/file.png", `..\secret`, ".hidden"} {
+		_, err := store.Get(ctx, key)
+		assert.True(t, myerr.IsNotFound(err), "key %q must not be found", key)
+	}
+}
@@ -0,0 +1,137 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// constraintPriceListItemProduct is the foreign key from pricelistitem to product.
+const constraintPriceListItemProduct = "pricelistitem_productid_fkey"
+
+// ---------- PriceListRepository Implementation ----------
+
+// GetPriceListByName returns a price list by its unique name.
+func (r *GoodsPGRepository) GetPriceListByName(ctx context.Context, name string) (models.PriceList, error) {
+	const sql = `SELECT pricelistid, name, currency FROM pricelist WHERE name = $1;`
+
+	var pl models.PriceList
+	if err := r.client.QueryRow(ctx, sql, name).Scan(&pl.ID, &pl.Name, &pl.Currency); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pl, myerr.NotFound(fmt.Sprintf("Price list %s not found", name), nil)
+		}
+		return pl, err
+	}
+	return pl, nil
+}
+
+// ListPriceLists returns all price lists.
+func (r *GoodsPGRepository) ListPriceLists(ctx context.Context) ([]models.PriceList, error) {
+	const sql = `SELECT pricelistid, name, currency FROM pricelist ORDER BY name;`
+	rows, err := r.client.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var priceLists []models.PriceList
+	for rows.Next() {
+		var pl models.PriceList
+		if err := rows.Scan(&pl.ID, &pl.Name, &pl.Currency); err != nil {
+			return nil, err
+		}
+		priceLists = append(priceLists, pl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return priceLists, nil
+}
+
+// CreatePriceList creates a new price list.
+func (r *GoodsPGRepository) CreatePriceList(ctx context.Context, pl *models.PriceList) (int64, error) {
+	const sql = `INSERT INTO pricelist (name, currency) VALUES ($1, $2) RETURNING pricelistid;`
+	if err := r.client.QueryRow(ctx, sql, pl.Name, pl.Currency).Scan(&pl.ID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return 0, myerr.Conflict(fmt.Sprintf("Price list with name %s already exists", pl.Name), err)
+		}
+		return 0, err
+	}
+	return pl.ID, nil
+}
+
+// SetPriceListPrices inserts or updates product prices of a price list in a single transaction.
+func (r *GoodsPGRepository) SetPriceListPrices(ctx context.Context, priceListID int64, prices []models.ProductPrice) (err error) {
+	const (
+		sqlLockPriceList = `SELECT pricelistid FROM pricelist WHERE pricelistid = $1 FOR UPDATE;`
+		sqlUpsertPrice   = `INSERT INTO pricelistitem (pricelistid, productid, price) VALUES ($1, $2, $3)
+			ON CONFLICT (pricelistid, productid) DO UPDATE SET price = EXCLUDED.price;`
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var id int64
+	if err = tx.QueryRow(ctx, sqlLockPriceList, priceListID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerr.NotFound(fmt.Sprintf("Price list with ID %d not found", priceListID), nil)
+		}
+		return err
+	}
+
+	for _, price := range prices {
+		if _, err = tx.Exec(ctx, sqlUpsertPrice, priceListID, price.ProductID, price.Price); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == constraintPriceListItemProduct {
+				return myerr.NotFound(fmt.Sprintf(fmtProductNotFound, price.ProductID), err)
+			}
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetPriceListPrices returns prices of the given products in a price list keyed by product ID.
+// Products without a price in the list are absent from the result.
+func (r *GoodsPGRepository) GetPriceListPrices(ctx context.Context, priceListID int64, productIDs []int64) (map[int64]models.Money, error) {
+	const sql = `SELECT productid, price FROM pricelistitem WHERE pricelistid = $1 AND productid = ANY($2);`
+	rows, err := r.client.Query(ctx, sql, priceListID, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make(map[int64]models.Money, len(productIDs))
+	for rows.Next() {
+		var (
+			productID int64
+			price     models.Money
+		)
+		if err := rows.Scan(&productID, &price); err != nil {
+			return nil, err
+		}
+		prices[productID] = price
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
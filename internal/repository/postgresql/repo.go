@@ -15,10 +15,41 @@ import (
 )
 
 const (
-	msgFailedToScanTemplate = "Failed to scan template"
-	fmtProductNotFound      = "Product with ID %d not found"
+	msgFailedToScanTemplate  = "Failed to scan template"
+	fmtProductNotFound       = "Product with ID %d not found"
+	fmtParentProductNotFound = "Parent product with ID %d not found"
+	fmtProductInTemplate     = "Product with ID %d already exists in template"
+	fmtTemplateNotFound      = "Template with ID %d not found"
+	sqlProductVersion        = `SELECT rowversion FROM product WHERE id = $1;`
+	sqlTemplateVersion       = `SELECT rowversion FROM package WHERE packageid = $1;`
+	// constraintProductParent is the foreign key from a variant to its parent product.
+	constraintProductParent = "product_parentid_fkey"
 )
 
+// productColumns lists the product columns in the order expected by scanProduct.
+// Barcodes are aggregated from productbarcode so that a product is always read with a single query.
+const productColumns = `id, name, description, price, vatrate, imageurl, thumbnailurl, sku, COALESCE(parentid, 0), options, unit, packaging, nutrition, allergens, translations, status, availability, rowversion, updatedat,
+	ARRAY(SELECT barcode FROM productbarcode WHERE productbarcode.productid = product.id ORDER BY barcode)`
+
+// scanProduct scans a row selected with productColumns into p.
+func scanProduct(row pgx.Row, p *models.Product) error {
+	return row.Scan(productFields(p)...)
+}
+
+// productFields returns scan destinations for productColumns, for rows that select more than a product.
+func productFields(p *models.Product) []any {
+	return []any{&p.ID, &p.Name, &p.Description, &p.Price, &p.VATRate, &p.ImageURL, &p.ThumbnailURL, &p.SKU, &p.ParentID, &p.Options, &p.Unit, &p.Packaging,
+		&p.Nutrition, &p.Allergens, &p.Translations, &p.Status, &p.Availability, &p.Version, &p.UpdatedAt, &p.Barcodes}
+}
+
+// templateColumns lists the template columns in the order expected by scanTemplate.
+const templateColumns = `packageid, packagename, description, translations, rowversion, updatedat, passengers`
+
+// scanTemplate scans a row selected with templateColumns into t.
+func scanTemplate(row pgx.Row, t *models.Template) error {
+	return row.Scan(&t.ID, &t.TemplateName, &t.Description, &t.Translations, &t.Version, &t.UpdatedAt, &t.Passengers)
+}
+
 // GoodsPGRepository implements the GoodsRepository interface using PostgreSQL.
 type GoodsPGRepository struct {
 	client Client
@@ -37,11 +68,11 @@ func NewGoodsRepository(client Client, logger log.Logger) models.GoodsRepository
 
 // GetProductByID returns a product by its ID.
 func (r *GoodsPGRepository) GetProductByID(ctx context.Context, id int64) (models.Product, error) {
-	const sql = `SELECT id, name, description, price, imageurl, sku FROM product WHERE id = $1;`
+	const sql = "SELECT " + productColumns + " FROM product WHERE id = $1;"
 	row := r.client.QueryRow(ctx, sql, id)
 
 	var p models.Product
-	if err := row.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.ImageURL, &p.SKU); err != nil {
+	if err := scanProduct(row, &p); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return p, myerr.NotFound(fmt.Sprintf(fmtProductNotFound, id), nil)
 		}
@@ -53,7 +84,7 @@ func (r *GoodsPGRepository) GetProductByID(ctx context.Context, id int64) (model
 
 // GetAllProducts returns a list of all products.
 func (r *GoodsPGRepository) GetAllProducts(ctx context.Context) ([]models.Product, error) {
-	const sql = `SELECT id, name, description, price, imageurl, sku FROM product;`
+	const sql = "SELECT " + productColumns + " FROM product;"
 	rows, err := r.client.Query(ctx, sql)
 	if err != nil {
 		return nil, err
@@ -63,7 +94,7 @@ func (r *GoodsPGRepository) GetAllProducts(ctx context.Context) ([]models.Produc
 	var products []models.Product
 	for rows.Next() {
 		var p models.Product
-		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.ImageURL, &p.SKU); err != nil {
+		if err := scanProduct(rows, &p); err != nil {
 			_ = r.logger.Log("warning", "Failed to scan product", "err", err)
 			continue // Пропускаем некорректную строку, но продолжаем обработку остальных.
 		}
@@ -76,48 +107,239 @@ func (r *GoodsPGRepository) GetAllProducts(ctx context.Context) ([]models.Produc
 	return products, nil
 }
 
-// CreateProduct creates a new product in the database.
-func (r *GoodsPGRepository) CreateProduct(ctx context.Context, p *models.Product) (int64, error) {
-	const sql = `INSERT INTO product (name, description, price, imageurl, sku) VALUES ($1, $2, $3, $4, $5) RETURNING id;`
-	if err := r.client.QueryRow(ctx, sql, p.Name, p.Description, p.Price, p.ImageURL, p.SKU).Scan(&p.ID); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
-			return 0, myerr.Conflict(fmt.Sprintf("Product with SKU %s already exists", p.SKU), err)
+// GetProductsByIDs returns the products with the given IDs. Missing IDs are silently skipped.
+func (r *GoodsPGRepository) GetProductsByIDs(ctx context.Context, ids []int64) ([]models.Product, error) {
+	const sql = "SELECT " + productColumns + " FROM product WHERE id = ANY($1);"
+	rows, err := r.client.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []models.Product
+	for rows.Next() {
+		var p models.Product
+		if err := scanProduct(rows, &p); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetProductsBySKUs returns the products with the given SKUs. Missing SKUs are silently skipped.
+func (r *GoodsPGRepository) GetProductsBySKUs(ctx context.Context, skus []string) ([]models.Product, error) {
+	const sql = "SELECT " + productColumns + " FROM product WHERE sku = ANY($1);"
+	rows, err := r.client.Query(ctx, sql, skus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []models.Product
+	for rows.Next() {
+		var p models.Product
+		if err := scanProduct(rows, &p); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetProductVariants returns the variants of the given parent products ordered by parent and ID.
+func (r *GoodsPGRepository) GetProductVariants(ctx context.Context, parentIDs []int64) ([]models.Product, error) {
+	const sql = "SELECT " + productColumns + " FROM product WHERE parentid = ANY($1) ORDER BY parentid, id;"
+	rows, err := r.client.Query(ctx, sql, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []models.Product
+	for rows.Next() {
+		var p models.Product
+		if err := scanProduct(rows, &p); err != nil {
+			return nil, err
+		}
+		variants = append(variants, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+// CreateProduct creates a new product together with its barcodes in a single transaction.
+func (r *GoodsPGRepository) CreateProduct(ctx context.Context, p *models.Product) (id int64, err error) {
+	const sql = `INSERT INTO product (name, description, price, vatrate, imageurl, thumbnailurl, sku, parentid, options, unit, packaging, nutrition, allergens,
+		translations, status, availability)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, 0), COALESCE($9, '{}'::jsonb), $10, COALESCE($11, '[]'::jsonb), $12, COALESCE($13, '[]'::jsonb),
+		COALESCE($14, '{}'::jsonb), $15, $16)
+		RETURNING id;`
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
 		}
+	}()
+
+	if err = tx.QueryRow(ctx, sql, p.Name, p.Description, p.Price, p.VATRate, p.ImageURL, p.ThumbnailURL, p.SKU, p.ParentID, p.Options, p.Unit, p.Packaging,
+		p.Nutrition, p.Allergens, p.Translations, p.Status, p.Availability).Scan(&p.ID); err != nil {
+		return 0, mapProductWriteError(p, err, "Product with SKU %s already exists")
+	}
+	if err = r.replaceProductBarcodes(ctx, tx, p.ID, p.Barcodes); err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(ctx); err != nil {
 		return 0, err
 	}
 	return p.ID, nil
 }
 
-// UpdateProduct updates an existing product in the database.
-func (r *GoodsPGRepository) UpdateProduct(ctx context.Context, p *models.Product) error {
-	const sql = `UPDATE product SET name = $1, description = $2, price = $3, imageurl = $4, sku = $5 WHERE id = $6;`
-	ct, err := r.client.Exec(ctx, sql, p.Name, p.Description, p.Price, p.ImageURL, p.SKU, p.ID)
+// UpdateProduct updates an existing product and replaces its barcodes in a single transaction.
+// The update only applies if the stored row version still equals p.Version (unless it is 0);
+// on success p.Version is set to the new row version.
+func (r *GoodsPGRepository) UpdateProduct(ctx context.Context, p *models.Product) (err error) {
+	const sql = `UPDATE product SET name = $1, description = $2, price = $3, vatrate = $4, imageurl = $5, thumbnailurl = $6, sku = $7,
+		parentid = NULLIF($8, 0), options = COALESCE($9, '{}'::jsonb), unit = $10, packaging = COALESCE($11, '[]'::jsonb),
+		nutrition = $12, allergens = COALESCE($13, '[]'::jsonb), translations = COALESCE($14, '{}'::jsonb), status = $15,
+		availability = $16, rowversion = rowversion + 1 WHERE id = $17 AND ($18 = 0 OR rowversion = $18) RETURNING rowversion;`
+
+	tx, err := r.client.Begin(ctx)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
-			return myerr.Conflict(fmt.Sprintf("Updated data conflicts with existing product with SKU %s", p.SKU), err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var version int64
+	err = tx.QueryRow(ctx, sql, p.Name, p.Description, p.Price, p.VATRate, p.ImageURL, p.ThumbnailURL, p.SKU, p.ParentID, p.Options, p.Unit, p.Packaging,
+		p.Nutrition, p.Allergens, p.Translations, p.Status, p.Availability, p.ID, p.Version).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = versionMismatch(tx.QueryRow(ctx, sqlProductVersion, p.ID), "Product", p.ID, p.Version)
+		return err
+	} else if err != nil {
+		return mapProductWriteError(p, err, "Updated data conflicts with existing product with SKU %s")
+	}
+	if err = r.replaceProductBarcodes(ctx, tx, p.ID, p.Barcodes); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	p.Version = version
+	return nil
+}
+
+// versionMismatch explains why a conditional write matched no rows: either the row does not exist
+// or its version differs from the expected one. row must select the current row version.
+func versionMismatch(row pgx.Row, entity string, id int64, expected int64) error {
+	var current int64
+	if err := row.Scan(&current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerr.NotFound(fmt.Sprintf("%s with ID %d not found", entity, id), nil)
+		}
+		return err
+	}
+	return myerr.PreconditionFailed(fmt.Sprintf("%s with ID %d was modified concurrently: current version is %d, expected %d", entity, id, current, expected), nil)
+}
+
+// mapProductWriteError converts constraint violations on product insert or update into application errors.
+func mapProductWriteError(p *models.Product, err error, fmtSKUConflict string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return myerr.Conflict(fmt.Sprintf(fmtSKUConflict, p.SKU), err)
+	} else if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == constraintProductParent {
+		return myerr.NotFound(fmt.Sprintf(fmtParentProductNotFound, p.ParentID), err)
+	}
+	return err
+}
+
+// replaceProductBarcodes replaces all barcodes of a product within the given transaction.
+// Barcodes are unique by their GTIN-14 form across all products.
+func (r *GoodsPGRepository) replaceProductBarcodes(ctx context.Context, tx pgx.Tx, productID int64, barcodes []string) error {
+	const (
+		sqlDeleteBarcodes = `DELETE FROM productbarcode WHERE productid = $1;`
+		sqlInsertBarcode  = `INSERT INTO productbarcode (gtin, barcode, productid) VALUES ($1, $2, $3);`
+	)
+
+	if _, err := tx.Exec(ctx, sqlDeleteBarcodes, productID); err != nil {
+		return err
+	}
+	for _, barcode := range barcodes {
+		gtin, err := models.NormalizeBarcode(barcode)
+		if err != nil {
+			return err
 		}
+		if _, err := tx.Exec(ctx, sqlInsertBarcode, gtin, barcode, productID); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return myerr.Conflict(fmt.Sprintf("Barcode %s is already assigned to another product", barcode), err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// GetProductIDByBarcode returns the ID of the product with the given barcode in GTIN-14 form.
+func (r *GoodsPGRepository) GetProductIDByBarcode(ctx context.Context, gtin string) (int64, error) {
+	const sql = `SELECT productid FROM productbarcode WHERE gtin = $1;`
+	var id int64
+	if err := r.client.QueryRow(ctx, sql, gtin).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, myerr.NotFound(fmt.Sprintf("Product with barcode %s not found", gtin), nil)
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// SetProductImage replaces the image and thumbnail URLs of a product.
+func (r *GoodsPGRepository) SetProductImage(ctx context.Context, id int64, image models.ProductImage) error {
+	const sql = `UPDATE product SET imageurl = $1, thumbnailurl = $2, rowversion = rowversion + 1 WHERE id = $3;`
+	ct, err := r.client.Exec(ctx, sql, image.ImageURL, image.ThumbnailURL, id)
+	if err != nil {
 		return err
 	}
 	if ct.RowsAffected() == 0 {
-		return myerr.NotFound(fmt.Sprintf(fmtProductNotFound, p.ID), nil)
+		return myerr.NotFound(fmt.Sprintf(fmtProductNotFound, id), nil)
 	}
 	return nil
 }
 
-// DeleteProduct deletes a product from the database by its ID.
-func (r *GoodsPGRepository) DeleteProduct(ctx context.Context, id int64) error {
-	const sql = `DELETE FROM product WHERE id = $1;`
-	ct, err := r.client.Exec(ctx, sql, id)
+// DeleteProduct deletes a product from the database by its ID if its row version equals version (unless it is 0).
+func (r *GoodsPGRepository) DeleteProduct(ctx context.Context, id int64, version int64) error {
+	const sql = `DELETE FROM product WHERE id = $1 AND ($2 = 0 OR rowversion = $2);`
+	ct, err := r.client.Exec(ctx, sql, id, version)
 	if err != nil {
+		var pgErr *pgconn.PgError
 		if errors.Is(err, pgx.ErrNoRows) {
 			return myerr.NotFound(fmt.Sprintf(fmtProductNotFound, id), nil)
+		} else if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return myerr.Conflict(fmt.Sprintf("Product with ID %d is still used by its variants or templates", id), err)
 		}
 		return err
 	}
 	if ct.RowsAffected() == 0 {
-		return myerr.NotFound(fmt.Sprintf(fmtProductNotFound, id), nil)
+		return versionMismatch(r.client.QueryRow(ctx, sqlProductVersion, id), "Product", id, version)
 	}
 	return nil
 }
@@ -127,15 +349,15 @@ func (r *GoodsPGRepository) DeleteProduct(ctx context.Context, id int64) error {
 // GetTemplateByID retrieves template details along with its contents.
 func (r *GoodsPGRepository) GetTemplateByID(ctx context.Context, id int64) (models.Template, error) {
 	var template models.Template
-	const sqlTemplate = `SELECT packageid, packagename, description FROM package WHERE packageid = $1;`
-	if err := r.client.QueryRow(ctx, sqlTemplate, id).Scan(&template.ID, &template.TemplateName, &template.Description); err != nil {
+	const sqlTemplate = "SELECT " + templateColumns + " FROM package WHERE packageid = $1;"
+	if err := scanTemplate(r.client.QueryRow(ctx, sqlTemplate, id), &template); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return template, myerr.NotFound(fmt.Sprintf("Template with ID %d not found", id), nil)
 		}
 		return template, err
 	}
 
-	// Get template contents
+	// Get template contents; they are read after the row version, so they are never older than template.Version
 	const sqlContents = `SELECT productid, quantity FROM packagecontent WHERE packageid = $1;`
 	rows, err := r.client.Query(ctx, sqlContents, id)
 	if err != nil {
@@ -182,9 +404,68 @@ func (r *GoodsPGRepository) GetProductsByTemplateID(ctx context.Context, templat
 	return contents, nil
 }
 
+// GetTemplateContents retrieves the contents of the given templates in a single query, keyed by template ID.
+// Lines keep the order in which they were added to the template.
+func (r *GoodsPGRepository) GetTemplateContents(ctx context.Context, templateIDs []int64) (map[int64][]models.TemplateContent, error) {
+	const sql = `SELECT packageid, productid, quantity FROM packagecontent WHERE packageid = ANY($1) ORDER BY packageid, packagecontentid;`
+	rows, err := r.client.Query(ctx, sql, templateIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contents := make(map[int64][]models.TemplateContent, len(templateIDs))
+	for rows.Next() {
+		var (
+			templateID int64
+			c          models.TemplateContent
+		)
+		if err := rows.Scan(&templateID, &c.ProductID, &c.Quantity); err != nil {
+			return nil, err
+		}
+		contents[templateID] = append(contents[templateID], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+// GetTemplateContentProducts retrieves the contents of the given templates together with their products
+// in a single join, keyed by template ID. Lines keep the order in which they were added to the template.
+func (r *GoodsPGRepository) GetTemplateContentProducts(ctx context.Context, templateIDs []int64) (map[int64][]models.TemplateContent, error) {
+	const sql = `SELECT packagecontent.packageid, packagecontent.quantity, ` + productColumns + `
+		FROM packagecontent JOIN product ON product.id = packagecontent.productid
+		WHERE packagecontent.packageid = ANY($1) ORDER BY packagecontent.packageid, packagecontent.packagecontentid;`
+	rows, err := r.client.Query(ctx, sql, templateIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contents := make(map[int64][]models.TemplateContent, len(templateIDs))
+	for rows.Next() {
+		var (
+			templateID int64
+			c          models.TemplateContent
+			p          models.Product
+		)
+		if err := rows.Scan(append([]any{&templateID, &c.Quantity}, productFields(&p)...)...); err != nil {
+			return nil, err
+		}
+		c.ProductID = p.ID
+		c.Product = &p
+		contents[templateID] = append(contents[templateID], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
 // ListTemplates returns a list of all templates.
 func (r *GoodsPGRepository) ListTemplates(ctx context.Context) ([]models.Template, error) {
-	const sql = `SELECT packageid, packagename, description FROM package;`
+	const sql = "SELECT " + templateColumns + " FROM package ORDER BY packageid;"
 	rows, err := r.client.Query(ctx, sql)
 	if err != nil {
 		return nil, err
@@ -194,7 +475,7 @@ func (r *GoodsPGRepository) ListTemplates(ctx context.Context) ([]models.Templat
 	var templates []models.Template
 	for rows.Next() {
 		var p models.Template
-		if err := rows.Scan(&p.ID, &p.TemplateName, &p.Description); err != nil {
+		if err := scanTemplate(rows, &p); err != nil {
 			_ = r.logger.Log("warning", msgFailedToScanTemplate, "err", err)
 			continue // Можно решить, нужно ли пропускать или возвращать ошибку
 		}
@@ -209,8 +490,6 @@ func (r *GoodsPGRepository) ListTemplates(ctx context.Context) ([]models.Templat
 
 // CreateTemplate adds a new template to the database along with its contents.
 func (r *GoodsPGRepository) CreateTemplate(ctx context.Context, template *models.Template) (err error) {
-	const sqlInsertTemplate = `INSERT INTO package (packagename, description) VALUES ($1, $2) RETURNING packageid;`
-
 	tx, err := r.client.Begin(ctx)
 	if err != nil {
 		return err
@@ -229,8 +508,17 @@ func (r *GoodsPGRepository) CreateTemplate(ctx context.Context, template *models
 		}
 	}()
 
+	err = r.createTemplate(ctx, tx, template)
+	return err
+}
+
+// createTemplate inserts a template and its contents within the given transaction, sets template.ID
+// and records the first revision.
+func (r *GoodsPGRepository) createTemplate(ctx context.Context, tx pgx.Tx, template *models.Template) error {
+	const sqlInsertTemplate = `INSERT INTO package (packagename, description, translations, passengers) VALUES ($1, $2, COALESCE($3, '{}'::jsonb), $4) RETURNING packageid;`
+
 	// Insert template
-	if err = tx.QueryRow(ctx, sqlInsertTemplate, template.TemplateName, template.Description).Scan(&template.ID); err != nil {
+	if err := tx.QueryRow(ctx, sqlInsertTemplate, template.TemplateName, template.Description, template.Translations, template.Passengers).Scan(&template.ID); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
 			return myerr.Conflict(fmt.Sprintf("Template with name %s already exists", template.TemplateName), err)
@@ -240,12 +528,12 @@ func (r *GoodsPGRepository) CreateTemplate(ctx context.Context, template *models
 
 	// Insert template contents
 	for _, content := range template.Content {
-		if err = r.createProductToTemplate(ctx, tx, template.ID, content); err != nil {
+		if err := r.createProductToTemplate(ctx, tx, template.ID, content); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return recordTemplateRevision(ctx, tx, template.ID)
 }
 
 // createProductToTemplate adds a single template content entry.
@@ -257,15 +545,298 @@ func (r *GoodsPGRepository) createProductToTemplate(ctx context.Context, tx pgx.
 		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
 			return myerr.NotFound(fmt.Sprintf(fmtProductNotFound, content.ProductID), err)
 		} else if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
-			return myerr.Conflict(fmt.Sprintf("Product with ID %d already exists in template", content.ProductID), err)
+			return myerr.Conflict(fmt.Sprintf(fmtProductInTemplate, content.ProductID), err)
 		}
 		return err
 	}
 	return nil
 }
 
-// DeleteTemplate deletes a template and its contents from the database by template ID.
-func (r *GoodsPGRepository) DeleteTemplate(ctx context.Context, packageid int64) error {
+// UpdateTemplate updates the name, description and translations of a template and replaces its contents
+// in a single transaction. The update only applies if the stored row version still equals template.Version
+// (unless it is 0); on success template.Version is set to the new row version.
+// Contents are diffed against the stored rows: only removed, added and re-quantified products are written.
+func (r *GoodsPGRepository) UpdateTemplate(ctx context.Context, template *models.Template) (err error) {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	version, err := r.updateTemplate(ctx, tx, template)
+	if err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	template.Version = version
+	return nil
+}
+
+// updateTemplate applies UpdateTemplate within the given transaction, records a revision
+// and returns the new row version without changing template.
+func (r *GoodsPGRepository) updateTemplate(ctx context.Context, tx pgx.Tx, template *models.Template) (int64, error) {
+	const (
+		sqlUpdateTemplate = `UPDATE package SET packagename = $1, description = $2, translations = COALESCE($3, '{}'::jsonb),
+			passengers = $6, rowversion = rowversion + 1 WHERE packageid = $4 AND ($5 = 0 OR rowversion = $5) RETURNING rowversion;`
+		sqlSelectContents = `SELECT productid, quantity FROM packagecontent WHERE packageid = $1;`
+		sqlDeleteContents = `DELETE FROM packagecontent WHERE packageid = $1 AND productid = ANY($2);`
+		sqlUpdateContent  = `UPDATE packagecontent SET quantity = $3 WHERE packageid = $1 AND productid = $2;`
+	)
+
+	var version int64
+	err := tx.QueryRow(ctx, sqlUpdateTemplate, template.TemplateName, template.Description, template.Translations, template.ID, template.Version, template.Passengers).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, versionMismatch(tx.QueryRow(ctx, sqlTemplateVersion, template.ID), "Template", template.ID, template.Version)
+	} else if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return 0, myerr.Conflict(fmt.Sprintf("Template with name %s already exists", template.TemplateName), err)
+		}
+		return 0, err
+	}
+
+	// Load current contents; the template row is locked by the update above
+	rows, err := tx.Query(ctx, sqlSelectContents, template.ID)
+	if err != nil {
+		return 0, err
+	}
+	current := make(map[int64]int)
+	for rows.Next() {
+		var c models.TemplateContent
+		if err = rows.Scan(&c.ProductID, &c.Quantity); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		current[c.ProductID] = c.Quantity
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	// Add new products and change quantities of kept ones
+	kept := make(map[int64]bool, len(template.Content))
+	for _, content := range template.Content {
+		if kept[content.ProductID] {
+			return 0, myerr.Conflict(fmt.Sprintf(fmtProductInTemplate, content.ProductID), nil)
+		}
+		kept[content.ProductID] = true
+
+		quantity, exists := current[content.ProductID]
+		switch {
+		case !exists:
+			err = r.createProductToTemplate(ctx, tx, template.ID, content)
+		case quantity != content.Quantity:
+			_, err = tx.Exec(ctx, sqlUpdateContent, template.ID, content.ProductID, content.Quantity)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Remove products that are no longer in the template
+	var removed []int64
+	for productID := range current {
+		if !kept[productID] {
+			removed = append(removed, productID)
+		}
+	}
+	if len(removed) > 0 {
+		if _, err = tx.Exec(ctx, sqlDeleteContents, template.ID, removed); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = recordTemplateRevision(ctx, tx, template.ID); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// CloneTemplate copies the description and all contents of a template into a new template with the given name
+// in a single transaction and returns the new template ID. Translations are not copied because they carry
+// the localized name of the source template.
+func (r *GoodsPGRepository) CloneTemplate(ctx context.Context, templateID int64, name string) (id int64, err error) {
+	const (
+		sqlCloneTemplate = `INSERT INTO package (packagename, description, passengers) SELECT $2, description, passengers FROM package WHERE packageid = $1 RETURNING packageid;`
+		sqlCloneContents = `INSERT INTO packagecontent (packageid, productid, quantity)
+			SELECT $2, productid, quantity FROM packagecontent WHERE packageid = $1 ORDER BY packagecontentid;`
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = tx.QueryRow(ctx, sqlCloneTemplate, templateID, name).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, myerr.NotFound(fmt.Sprintf(fmtTemplateNotFound, templateID), err)
+		} else if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return 0, myerr.Conflict(fmt.Sprintf("Template with name %s already exists", name), err)
+		}
+		return 0, err
+	}
+	if _, err = tx.Exec(ctx, sqlCloneContents, templateID, id); err != nil {
+		return 0, err
+	}
+	if err = recordTemplateRevision(ctx, tx, id); err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// ImportTemplates creates or replaces templates matched by name in a single transaction: either all templates
+// are written or none. A template whose name is already taken is replaced regardless of its row version.
+// The IDs of the given templates are ignored.
+func (r *GoodsPGRepository) ImportTemplates(ctx context.Context, templates []models.Template) (results []models.TemplateImportResult, err error) {
+	const sqlFindTemplate = `SELECT packageid FROM package WHERE packagename = $1 FOR UPDATE;`
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	results = make([]models.TemplateImportResult, len(templates))
+	for i, template := range templates {
+		template.Version = 0
+		err = tx.QueryRow(ctx, sqlFindTemplate, template.TemplateName).Scan(&template.ID)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			if err = r.createTemplate(ctx, tx, &template); err != nil {
+				return nil, err
+			}
+			results[i] = models.TemplateImportResult{TemplateID: template.ID, TemplateName: template.TemplateName, Version: 1, Created: true}
+		case err != nil:
+			return nil, err
+		default:
+			var version int64
+			if version, err = r.updateTemplate(ctx, tx, &template); err != nil {
+				return nil, err
+			}
+			results[i] = models.TemplateImportResult{TemplateID: template.ID, TemplateName: template.TemplateName, Version: version}
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// AddTemplateContent adds a single line to a template if the template row version equals expected
+// (unless it is 0) and returns the new template row version.
+func (r *GoodsPGRepository) AddTemplateContent(ctx context.Context, templateID int64, content models.TemplateContent, expected int64) (version int64, err error) {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if version, err = touchTemplate(ctx, tx, templateID, expected); err != nil {
+		return 0, err
+	}
+	if err = r.createProductToTemplate(ctx, tx, templateID, content); err != nil {
+		return 0, err
+	}
+	if err = recordTemplateRevision(ctx, tx, templateID); err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// UpdateTemplateContent changes the quantity of a template line if the template row version equals expected
+// (unless it is 0) and returns the new template row version.
+func (r *GoodsPGRepository) UpdateTemplateContent(ctx context.Context, templateID int64, content models.TemplateContent, expected int64) (version int64, err error) {
+	const sql = `UPDATE packagecontent SET quantity = $3 WHERE packageid = $1 AND productid = $2;`
+	return r.changeTemplateContent(ctx, templateID, content.ProductID, expected, sql, content.Quantity)
+}
+
+// DeleteTemplateContent removes the line with the given product from a template if the template row version
+// equals expected (unless it is 0) and returns the new template row version.
+func (r *GoodsPGRepository) DeleteTemplateContent(ctx context.Context, templateID int64, productID int64, expected int64) (version int64, err error) {
+	const sql = `DELETE FROM packagecontent WHERE packageid = $1 AND productid = $2;`
+	return r.changeTemplateContent(ctx, templateID, productID, expected, sql)
+}
+
+// changeTemplateContent executes a statement on an existing template line within a transaction that bumps
+// the template row version from expected. The statement receives the template ID, the product ID and then args.
+func (r *GoodsPGRepository) changeTemplateContent(ctx context.Context, templateID int64, productID int64, expected int64, sql string, args ...any) (version int64, err error) {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if version, err = touchTemplate(ctx, tx, templateID, expected); err != nil {
+		return 0, err
+	}
+	ct, err := tx.Exec(ctx, sql, append([]any{templateID, productID}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+	if ct.RowsAffected() == 0 {
+		err = myerr.NotFound(fmt.Sprintf("Product with ID %d is not in template with ID %d", productID, templateID), nil)
+		return 0, err
+	}
+	if err = recordTemplateRevision(ctx, tx, templateID); err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// touchTemplate bumps the row version of a template whose contents are about to change and locks its row.
+// The version is bumped only if it equals expected (unless it is 0), otherwise a precondition error is returned.
+// packagecontent has no trigger, so every change of template lines must go through it.
+func touchTemplate(ctx context.Context, tx pgx.Tx, templateID int64, expected int64) (int64, error) {
+	const sql = `UPDATE package SET rowversion = rowversion + 1 WHERE packageid = $1 AND ($2 = 0 OR rowversion = $2) RETURNING rowversion;`
+	var version int64
+	if err := tx.QueryRow(ctx, sql, templateID, expected).Scan(&version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, versionMismatch(tx.QueryRow(ctx, sqlTemplateVersion, templateID), "Template", templateID, expected)
+		}
+		return 0, err
+	}
+	return version, nil
+}
+
+// DeleteTemplate deletes a template and its contents from the database by template ID
+// if its row version equals version (unless it is 0). The final state of the template is kept
+// as a revision marked as the deletion, with the author taken from the context.
+func (r *GoodsPGRepository) DeleteTemplate(ctx context.Context, packageid int64, version int64) error {
 	const (
 		sqlDeleteContents = `DELETE FROM packagecontent WHERE packageid = $1;`
 		sqlDeleteTemplate = `DELETE FROM package WHERE packageid = $1;`
@@ -281,19 +852,23 @@ func (r *GoodsPGRepository) DeleteTemplate(ctx context.Context, packageid int64)
 		}
 	}()
 
+	// Check the version and record the deletion in the history before the template is gone
+	if _, err = touchTemplate(ctx, tx, packageid, version); err != nil {
+		return err
+	}
+	if err = recordTemplateDeletion(ctx, tx, packageid); err != nil {
+		return err
+	}
+
 	// Delete template contents
 	if _, err = tx.Exec(ctx, sqlDeleteContents, packageid); err != nil {
 		return err
 	}
 
 	// Delete template
-	ct, err := tx.Exec(ctx, sqlDeleteTemplate, packageid)
-	if err != nil {
+	if _, err = tx.Exec(ctx, sqlDeleteTemplate, packageid); err != nil {
 		return err
 	}
-	if ct.RowsAffected() == 0 {
-		return myerr.NotFound(fmt.Sprintf("Template with id %d not found", packageid), nil)
-	}
 
 	// Commit transaction
 	if err = tx.Commit(ctx); err != nil {
@@ -306,8 +881,8 @@ func (r *GoodsPGRepository) DeleteTemplate(ctx context.Context, packageid int64)
 // SearchTemplates searches for templates by name or description with pagination.
 func (r *GoodsPGRepository) SearchTemplates(ctx context.Context, searchString string, limit int64, offset int64) ([]models.Template, error) {
 	searchPattern := "%" + searchString + "%"
-	const sql = `SELECT packageid, packagename, description FROM package
-	        WHERE package.packagename ILIKE $1 OR description ILIKE $1 
+	const sql = "SELECT " + templateColumns + ` FROM package
+	        WHERE package.packagename ILIKE $1 OR description ILIKE $1 OR translations::text ILIKE $1
 	        LIMIT $2 OFFSET $3;`
 
 	rows, err := r.client.Query(ctx, sql, searchPattern, limit, offset)
@@ -319,7 +894,7 @@ func (r *GoodsPGRepository) SearchTemplates(ctx context.Context, searchString st
 	var templates []models.Template
 	for rows.Next() {
 		var p models.Template
-		if err := rows.Scan(&p.ID, &p.TemplateName, &p.Description); err != nil {
+		if err := scanTemplate(rows, &p); err != nil {
 			_ = r.logger.Log("warning", msgFailedToScanTemplate, "err", err)
 			continue // Можно решить, нужно ли пропускать или возвращать ошибку
 		}
@@ -335,7 +910,7 @@ func (r *GoodsPGRepository) SearchTemplates(ctx context.Context, searchString st
 
 // GetAllTemplates returns all templates with pagination.
 func (r *GoodsPGRepository) GetAllTemplates(ctx context.Context, limit int64, offset int64) ([]models.Template, error) {
-	const sql = `SELECT packageid, packagename, description FROM package LIMIT $1 OFFSET $2;`
+	const sql = "SELECT " + templateColumns + " FROM package LIMIT $1 OFFSET $2;"
 	rows, err := r.client.Query(ctx, sql, limit, offset)
 	if err != nil {
 		return nil, err
@@ -345,7 +920,7 @@ func (r *GoodsPGRepository) GetAllTemplates(ctx context.Context, limit int64, of
 	var templates []models.Template
 	for rows.Next() {
 		var p models.Template
-		if err := rows.Scan(&p.ID, &p.TemplateName, &p.Description); err != nil {
+		if err := scanTemplate(rows, &p); err != nil {
 			_ = r.logger.Log("warning", msgFailedToScanTemplate, "err", err)
 			continue // Можно решить, нужно ли пропускать или возвращать ошибку
 		}
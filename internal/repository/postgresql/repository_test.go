@@ -3,7 +3,9 @@ package postgresql_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/jackc/pgerrcode"
@@ -26,6 +28,12 @@ func newTestRepo() (*postgresql.MockClient, models.GoodsRepository, context.Cont
 	return mockClient, repo, ctx
 }
 
+// expectRevision ожидает запись ревизии шаблона с указанным автором в конце транзакции.
+func expectRevision(mockTx *postgresql.MockTx, templateID int64, author string) {
+	mockTx.On("Exec", mock.Anything, mock.MatchedBy(func(sql string) bool { return strings.Contains(sql, "packagerevision") }), templateID, author, false).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+}
+
 // Техника тест-дизайна: Классы эквивалентности
 // Автор: safr
 // Описание:
@@ -61,7 +69,8 @@ func TestGetProductByID(t *testing.T) {
 		ID:          productID,
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       100.0,
+		Price:       models.Money(10000),
+		VATRate:     models.VATRate20,
 		ImageURL:    "http://test.com/image.jpg",
 		SKU:         "SKU123",
 	}
@@ -70,15 +79,33 @@ func TestGetProductByID(t *testing.T) {
 	mockRow := new(postgresql.MockRows)
 	mockClient.On("QueryRow", mock.Anything, mock.Anything, productID).Return(mockRow)
 	mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"),
-		mock.AnythingOfType("*string"), mock.AnythingOfType("*float64"),
-		mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*models.Money"),
+		mock.AnythingOfType("*models.VATRate"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*map[string]string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*[]models.Packaging"),
+		mock.AnythingOfType("**models.Nutrition"), mock.AnythingOfType("*[]models.Allergen"), mock.AnythingOfType("*map[string]models.Translation"),
+		mock.AnythingOfType("*models.ProductStatus"), mock.AnythingOfType("*models.Availability"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*[]string")).
 		Run(func(args mock.Arguments) {
 			*(args[0].(*int64)) = expectedProduct.ID
 			*(args[1].(*string)) = expectedProduct.Name
 			*(args[2].(*string)) = expectedProduct.Description
-			*(args[3].(*float64)) = expectedProduct.Price
-			*(args[4].(*string)) = expectedProduct.ImageURL
-			*(args[5].(*string)) = expectedProduct.SKU
+			*(args[3].(*models.Money)) = expectedProduct.Price
+			*(args[4].(*models.VATRate)) = expectedProduct.VATRate
+			*(args[5].(*string)) = expectedProduct.ImageURL
+			*(args[6].(*string)) = expectedProduct.ThumbnailURL
+			*(args[7].(*string)) = expectedProduct.SKU
+			*(args[8].(*int64)) = expectedProduct.ParentID
+			*(args[9].(*map[string]string)) = expectedProduct.Options
+			*(args[10].(*string)) = expectedProduct.Unit
+			*(args[11].(*[]models.Packaging)) = expectedProduct.Packaging
+			*(args[12].(**models.Nutrition)) = expectedProduct.Nutrition
+			*(args[13].(*[]models.Allergen)) = expectedProduct.Allergens
+			*(args[14].(*map[string]models.Translation)) = expectedProduct.Translations
+			*(args[15].(*models.ProductStatus)) = expectedProduct.Status
+			*(args[16].(*models.Availability)) = expectedProduct.Availability
+			*(args[17].(*int64)) = expectedProduct.Version
+			*(args[18].(*time.Time)) = expectedProduct.UpdatedAt
+			*(args[19].(*[]string)) = expectedProduct.Barcodes
 		}).
 		Return(nil)
 
@@ -102,8 +129,8 @@ func TestGetAllProducts(t *testing.T) {
 	mockClient, repo, ctx := newTestRepo()
 
 	expectedProducts := []models.Product{
-		{ID: 1, Name: "Product 1", Description: "Desc 1", Price: 10.0, ImageURL: "http://test.com/1.jpg", SKU: "SKU1"},
-		{ID: 2, Name: "Product 2", Description: "Desc 2", Price: 20.0, ImageURL: "http://test.com/2.jpg", SKU: "SKU2"},
+		{ID: 1, Name: "Product 1", Description: "Desc 1", Price: models.Money(1000), VATRate: models.VATRate20, ImageURL: "http://test.com/1.jpg", SKU: "SKU1"},
+		{ID: 2, Name: "Product 2", Description: "Desc 2", Price: models.Money(2000), VATRate: models.VATRate10, ImageURL: "http://test.com/2.jpg", SKU: "SKU2"},
 	}
 
 	// Создаем мокированные строки
@@ -113,29 +140,65 @@ func TestGetAllProducts(t *testing.T) {
 	// Ожидаем вызовы Next()
 	mockRows.On("Next").Return(true).Once()
 	mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"),
-		mock.AnythingOfType("*string"), mock.AnythingOfType("*float64"),
-		mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*models.Money"),
+		mock.AnythingOfType("*models.VATRate"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*map[string]string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*[]models.Packaging"),
+		mock.AnythingOfType("**models.Nutrition"), mock.AnythingOfType("*[]models.Allergen"), mock.AnythingOfType("*map[string]models.Translation"),
+		mock.AnythingOfType("*models.ProductStatus"), mock.AnythingOfType("*models.Availability"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*[]string")).
 		Run(func(args mock.Arguments) {
 			*(args[0].(*int64)) = expectedProducts[0].ID
 			*(args[1].(*string)) = expectedProducts[0].Name
 			*(args[2].(*string)) = expectedProducts[0].Description
-			*(args[3].(*float64)) = expectedProducts[0].Price
-			*(args[4].(*string)) = expectedProducts[0].ImageURL
-			*(args[5].(*string)) = expectedProducts[0].SKU
+			*(args[3].(*models.Money)) = expectedProducts[0].Price
+			*(args[4].(*models.VATRate)) = expectedProducts[0].VATRate
+			*(args[5].(*string)) = expectedProducts[0].ImageURL
+			*(args[6].(*string)) = expectedProducts[0].ThumbnailURL
+			*(args[7].(*string)) = expectedProducts[0].SKU
+			*(args[8].(*int64)) = expectedProducts[0].ParentID
+			*(args[9].(*map[string]string)) = expectedProducts[0].Options
+			*(args[10].(*string)) = expectedProducts[0].Unit
+			*(args[11].(*[]models.Packaging)) = expectedProducts[0].Packaging
+			*(args[12].(**models.Nutrition)) = expectedProducts[0].Nutrition
+			*(args[13].(*[]models.Allergen)) = expectedProducts[0].Allergens
+			*(args[14].(*map[string]models.Translation)) = expectedProducts[0].Translations
+			*(args[15].(*models.ProductStatus)) = expectedProducts[0].Status
+			*(args[16].(*models.Availability)) = expectedProducts[0].Availability
+			*(args[17].(*int64)) = expectedProducts[0].Version
+			*(args[18].(*time.Time)) = expectedProducts[0].UpdatedAt
+			*(args[19].(*[]string)) = expectedProducts[0].Barcodes
 		}).
 		Return(nil).Once()
 
 	mockRows.On("Next").Return(true).Once()
 	mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"),
-		mock.AnythingOfType("*string"), mock.AnythingOfType("*float64"),
-		mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*models.Money"),
+		mock.AnythingOfType("*models.VATRate"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*map[string]string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*[]models.Packaging"),
+		mock.AnythingOfType("**models.Nutrition"), mock.AnythingOfType("*[]models.Allergen"), mock.AnythingOfType("*map[string]models.Translation"),
+		mock.AnythingOfType("*models.ProductStatus"), mock.AnythingOfType("*models.Availability"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*[]string")).
 		Run(func(args mock.Arguments) {
 			*(args[0].(*int64)) = expectedProducts[1].ID
 			*(args[1].(*string)) = expectedProducts[1].Name
 			*(args[2].(*string)) = expectedProducts[1].Description
-			*(args[3].(*float64)) = expectedProducts[1].Price
-			*(args[4].(*string)) = expectedProducts[1].ImageURL
-			*(args[5].(*string)) = expectedProducts[1].SKU
+			*(args[3].(*models.Money)) = expectedProducts[1].Price
+			*(args[4].(*models.VATRate)) = expectedProducts[1].VATRate
+			*(args[5].(*string)) = expectedProducts[1].ImageURL
+			*(args[6].(*string)) = expectedProducts[1].ThumbnailURL
+			*(args[7].(*string)) = expectedProducts[1].SKU
+			*(args[8].(*int64)) = expectedProducts[1].ParentID
+			*(args[9].(*map[string]string)) = expectedProducts[1].Options
+			*(args[10].(*string)) = expectedProducts[1].Unit
+			*(args[11].(*[]models.Packaging)) = expectedProducts[1].Packaging
+			*(args[12].(**models.Nutrition)) = expectedProducts[1].Nutrition
+			*(args[13].(*[]models.Allergen)) = expectedProducts[1].Allergens
+			*(args[14].(*map[string]models.Translation)) = expectedProducts[1].Translations
+			*(args[15].(*models.ProductStatus)) = expectedProducts[1].Status
+			*(args[16].(*models.Availability)) = expectedProducts[1].Availability
+			*(args[17].(*int64)) = expectedProducts[1].Version
+			*(args[18].(*time.Time)) = expectedProducts[1].UpdatedAt
+			*(args[19].(*[]string)) = expectedProducts[1].Barcodes
 		}).
 		Return(nil).Once()
 
@@ -164,51 +227,93 @@ func TestCreateProduct(t *testing.T) {
 	product := &models.Product{
 		Name:        "New Product",
 		Description: "Description",
-		Price:       99.99,
+		Price:       models.Money(9999),
+		VATRate:     models.VATRate20,
 		ImageURL:    "http://example.com/image.jpg",
 		SKU:         "SKU123",
+		Barcodes:    []string{"4006381333931"},
 	}
 
 	t.Run("Успешное создание продукта", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
 		mockRow := new(postgresql.MockRow)
-		mockClient.On("QueryRow", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.ImageURL, product.SKU).
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.VATRate, product.ImageURL, product.ThumbnailURL, product.SKU, product.ParentID, product.Options, product.Unit, product.Packaging, product.Nutrition, product.Allergens, product.Translations, product.Status, product.Availability).
 			Return(mockRow).Once()
 		mockRow.On("Scan", mock.AnythingOfType("*int64")).
 			Run(func(args mock.Arguments) {
 				*(args[0].(*int64)) = 1
 			}).
 			Return(nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(1)).
+			Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+		mockTx.On("Exec", mock.Anything, mock.Anything, "04006381333931", "4006381333931", int64(1)).
+			Return(pgconn.NewCommandTag("INSERT 1"), nil).Once()
+		mockTx.On("Commit", mock.Anything).Return(nil).Once()
 
 		id, err := repo.CreateProduct(ctx, product)
 
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), id)
+		mockTx.AssertExpectations(t)
 	})
 
 	t.Run("Ошибка UniqueViolation (SKU уже существует)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
 		mockRow := new(postgresql.MockRow)
-		mockClient.On("QueryRow", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.ImageURL, product.SKU).
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.VATRate, product.ImageURL, product.ThumbnailURL, product.SKU, product.ParentID, product.Options, product.Unit, product.Packaging, product.Nutrition, product.Allergens, product.Translations, product.Status, product.Availability).
 			Return(mockRow).Once()
 		mockRow.On("Scan", mock.AnythingOfType("*int64")).
 			Return(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.CreateProduct(ctx, product)
+
+		assert.Error(t, err)
+		assert.True(t, myerr.IsConflict(err))
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка UniqueViolation (штрихкод принадлежит другому продукту)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockRow := new(postgresql.MockRow)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.VATRate, product.ImageURL, product.ThumbnailURL, product.SKU, product.ParentID, product.Options, product.Unit, product.Packaging, product.Nutrition, product.Allergens, product.Translations, product.Status, product.Availability).
+			Return(mockRow).Once()
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) {
+				*(args[0].(*int64)) = 2
+			}).
+			Return(nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(2)).
+			Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+		mockTx.On("Exec", mock.Anything, mock.Anything, "04006381333931", "4006381333931", int64(2)).
+			Return(pgconn.NewCommandTag("INSERT 0"), &pgconn.PgError{Code: pgerrcode.UniqueViolation}).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
 
 		_, err := repo.CreateProduct(ctx, product)
 
 		assert.Error(t, err)
 		assert.True(t, myerr.IsConflict(err))
+		mockTx.AssertExpectations(t)
 	})
 
 	t.Run("Ошибка БД", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
 		mockRow := new(postgresql.MockRow)
-		mockClient.On("QueryRow", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.ImageURL, product.SKU).
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.VATRate, product.ImageURL, product.ThumbnailURL, product.SKU, product.ParentID, product.Options, product.Unit, product.Packaging, product.Nutrition, product.Allergens, product.Translations, product.Status, product.Availability).
 			Return(mockRow).Once()
 		mockRow.On("Scan", mock.AnythingOfType("*int64")).
 			Return(errors.New("db error"))
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
 
 		_, err := repo.CreateProduct(ctx, product)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "db error") // Вместо "Failed to create product"
+		mockTx.AssertExpectations(t)
 	})
 }
 
@@ -216,7 +321,8 @@ func TestCreateProduct(t *testing.T) {
 // Автор: safr
 // Описание:
 //   - Тест для метода UpdateProduct.
-//   - Классы эквивалентности: успешное обновление, конфликт из-за существующего SKU, отсутствие продукта в БД.
+//   - Классы эквивалентности: успешное обновление, конфликт из-за существующего SKU, отсутствие продукта в БД,
+//     устаревшая версия продукта.
 func TestUpdateProduct(t *testing.T) {
 	mockClient, repo, ctx := newTestRepo()
 
@@ -224,38 +330,90 @@ func TestUpdateProduct(t *testing.T) {
 		ID:          1,
 		Name:        "Updated Product",
 		Description: "Updated Description",
-		Price:       150.0,
+		Price:       models.Money(15000),
+		VATRate:     models.VATRate10,
 		ImageURL:    "http://example.com/updated.jpg",
 		SKU:         "SKU123",
+		Version:     3,
+	}
+
+	expectUpdate := func(mockTx *postgresql.MockTx, row pgx.Row) {
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.VATRate, product.ImageURL, product.ThumbnailURL, product.SKU, product.ParentID, product.Options, product.Unit, product.Packaging, product.Nutrition, product.Allergens, product.Translations, product.Status, product.Availability, product.ID, product.Version).
+			Return(row).Once()
 	}
 
 	t.Run("Успешное обновление продукта", func(t *testing.T) {
-		mockClient.On("Exec", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.ImageURL, product.SKU, product.ID).
-			Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+		mockTx := new(postgresql.MockTx)
+		mockRow := new(postgresql.MockRow)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, mockRow)
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = 4 }).
+			Return(nil).Once()
+		mockTx.On("Exec", mock.Anything, mock.Anything, product.ID).
+			Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+		mockTx.On("Commit", mock.Anything).Return(nil).Once()
 
-		err := repo.UpdateProduct(ctx, product)
+		updated := *product
+		err := repo.UpdateProduct(ctx, &updated)
 
 		assert.NoError(t, err)
+		assert.Equal(t, int64(4), updated.Version)
+		mockTx.AssertExpectations(t)
 	})
 
 	t.Run("Ошибка UniqueViolation (SKU уже существует)", func(t *testing.T) {
-		mockClient.On("Exec", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.ImageURL, product.SKU, product.ID).
-			Return(pgconn.NewCommandTag("UPDATE 0"), &pgconn.PgError{Code: pgerrcode.UniqueViolation}).Once()
+		mockTx := new(postgresql.MockTx)
+		mockRow := new(postgresql.MockRow)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, mockRow)
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Return(&pgconn.PgError{Code: pgerrcode.UniqueViolation}).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
 
 		err := repo.UpdateProduct(ctx, product)
 
 		assert.Error(t, err)
 		assert.True(t, myerr.IsConflict(err))
+		mockTx.AssertExpectations(t)
 	})
 
 	t.Run("Ошибка NotFound (Продукт не найден)", func(t *testing.T) {
-		mockClient.On("Exec", mock.Anything, mock.Anything, product.Name, product.Description, product.Price, product.ImageURL, product.SKU, product.ID).
-			Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+		mockTx := new(postgresql.MockTx)
+		updateRow := new(postgresql.MockRow)
+		versionRow := new(postgresql.MockRow)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, updateRow)
+		updateRow.On("Scan", mock.AnythingOfType("*int64")).Return(pgx.ErrNoRows).Once()
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, product.ID).Return(versionRow).Once()
+		versionRow.On("Scan", mock.AnythingOfType("*int64")).Return(pgx.ErrNoRows).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
 
 		err := repo.UpdateProduct(ctx, product)
 
 		assert.Error(t, err)
 		assert.True(t, myerr.IsNotFound(err))
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка PreconditionFailed (версия устарела)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		updateRow := new(postgresql.MockRow)
+		versionRow := new(postgresql.MockRow)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, updateRow)
+		updateRow.On("Scan", mock.AnythingOfType("*int64")).Return(pgx.ErrNoRows).Once()
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, product.ID).Return(versionRow).Once()
+		versionRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = 5 }).
+			Return(nil).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		err := repo.UpdateProduct(ctx, product)
+
+		assert.Error(t, err)
+		assert.True(t, myerr.IsPreconditionFailed(err))
+		mockTx.AssertExpectations(t)
 	})
 
 	// Проверяем вызовы
@@ -266,36 +424,55 @@ func TestUpdateProduct(t *testing.T) {
 // Автор: safr
 // Описание:
 //   - Тест для метода DeleteProduct.
-//   - Классы эквивалентности: успешное удаление, отсутствие продукта, ошибка БД.
+//   - Классы эквивалентности: успешное удаление, отсутствие продукта, устаревшая версия, ошибка БД.
 
 func TestDeleteProduct(t *testing.T) {
 	mockClient, repo, ctx := newTestRepo()
 	productID := int64(1)
+	version := int64(2)
 
 	t.Run("Успешное удаление продукта", func(t *testing.T) {
-		mockClient.On("Exec", mock.Anything, mock.Anything, productID).
+		mockClient.On("Exec", mock.Anything, mock.Anything, productID, version).
 			Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
 
-		err := repo.DeleteProduct(ctx, productID)
+		err := repo.DeleteProduct(ctx, productID, version)
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("Ошибка NotFound (продукт не найден)", func(t *testing.T) {
-		mockClient.On("Exec", mock.Anything, mock.Anything, productID).
+		mockRow := new(postgresql.MockRow)
+		mockClient.On("Exec", mock.Anything, mock.Anything, productID, version).
 			Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+		mockClient.On("QueryRow", mock.Anything, mock.Anything, productID).Return(mockRow).Once()
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).Return(pgx.ErrNoRows).Once()
 
-		err := repo.DeleteProduct(ctx, productID)
+		err := repo.DeleteProduct(ctx, productID, version)
 
 		assert.Error(t, err)
 		assert.True(t, myerr.IsNotFound(err))
 	})
 
+	t.Run("Ошибка PreconditionFailed (версия устарела)", func(t *testing.T) {
+		mockRow := new(postgresql.MockRow)
+		mockClient.On("Exec", mock.Anything, mock.Anything, productID, version).
+			Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+		mockClient.On("QueryRow", mock.Anything, mock.Anything, productID).Return(mockRow).Once()
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = version + 1 }).
+			Return(nil).Once()
+
+		err := repo.DeleteProduct(ctx, productID, version)
+
+		assert.Error(t, err)
+		assert.True(t, myerr.IsPreconditionFailed(err))
+	})
+
 	t.Run("Ошибка БД при удалении", func(t *testing.T) {
-		mockClient.On("Exec", mock.Anything, mock.Anything, productID).
+		mockClient.On("Exec", mock.Anything, mock.Anything, productID, version).
 			Return(pgconn.NewCommandTag("DELETE 0"), errors.New("db error")).Once()
 
-		err := repo.DeleteProduct(ctx, productID)
+		err := repo.DeleteProduct(ctx, productID, version)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "db error")
@@ -329,7 +506,7 @@ func TestGetTemplateByID(t *testing.T) {
 		mockRow := new(postgresql.MockRow)
 		mockClient.On("QueryRow", mock.Anything, mock.Anything, templateID).
 			Return(mockRow).Once()
-		mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Run(func(args mock.Arguments) {
 				*(args[0].(*int64)) = expectedTemplate.ID
 				*(args[1].(*string)) = expectedTemplate.TemplateName
@@ -370,7 +547,7 @@ func TestGetTemplateByID(t *testing.T) {
 		mockRow := new(postgresql.MockRow)
 		mockClient.On("QueryRow", mock.Anything, mock.Anything, templateID).
 			Return(mockRow).Once()
-		mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Return(pgx.ErrNoRows)
 
 		_, err := repo.GetTemplateByID(ctx, templateID)
@@ -383,7 +560,7 @@ func TestGetTemplateByID(t *testing.T) {
 		mockRow := new(postgresql.MockRow)
 		mockClient.On("QueryRow", mock.Anything, mock.Anything, templateID).
 			Return(mockRow).Once()
-		mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Return(errors.New("db error"))
 
 		_, err := repo.GetTemplateByID(ctx, templateID)
@@ -396,7 +573,7 @@ func TestGetTemplateByID(t *testing.T) {
 		mockRow := new(postgresql.MockRow)
 		mockClient.On("QueryRow", mock.Anything, mock.Anything, templateID).
 			Return(mockRow).Once()
-		mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRow.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Run(func(args mock.Arguments) {
 				*(args[0].(*int64)) = expectedTemplate.ID
 				*(args[1].(*string)) = expectedTemplate.TemplateName
@@ -455,6 +632,119 @@ func TestGetProductsByTemplateID(t *testing.T) {
 
 }
 
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода GetTemplateContents.
+//   - Классы эквивалентности: строки страницы шаблонов читаются одним запросом и группируются по шаблону, ошибка сканирования.
+func TestGetTemplateContents(t *testing.T) {
+	mockClient, repo, ctx := newTestRepo()
+	templateIDs := []int64{1, 2, 3}
+
+	expectLine := func(rows *postgresql.MockRows, templateID, productID int64, quantity int) {
+		rows.On("Next").Return(true).Once()
+		rows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*int")).
+			Run(func(args mock.Arguments) {
+				*(args[0].(*int64)) = templateID
+				*(args[1].(*int64)) = productID
+				*(args[2].(*int)) = quantity
+			}).Return(nil).Once()
+	}
+
+	t.Run("Успешное получение состава страницы", func(t *testing.T) {
+		mockRows := new(postgresql.MockRows)
+		mockClient.On("Query", mock.Anything, mock.Anything, templateIDs).Return(mockRows, nil).Once()
+		expectLine(mockRows, 1, 10, 2)
+		expectLine(mockRows, 1, 11, 1)
+		expectLine(mockRows, 3, 10, 4)
+		mockRows.On("Next").Return(false).Once()
+		mockRows.On("Err").Return(nil).Once()
+
+		contents, err := repo.GetTemplateContents(ctx, templateIDs)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.TemplateContent{{ProductID: 10, Quantity: 2}, {ProductID: 11, Quantity: 1}}, contents[1])
+		assert.Empty(t, contents[2])
+		assert.Equal(t, []models.TemplateContent{{ProductID: 10, Quantity: 4}}, contents[3])
+	})
+
+	t.Run("Ошибка сканирования", func(t *testing.T) {
+		mockRows := new(postgresql.MockRows)
+		mockClient.On("Query", mock.Anything, mock.Anything, templateIDs).Return(mockRows, nil).Once()
+		mockRows.On("Next").Return(true).Once()
+		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*int")).
+			Return(errors.New("scan error")).Once()
+
+		contents, err := repo.GetTemplateContents(ctx, templateIDs)
+
+		assert.EqualError(t, err, "scan error")
+		assert.Nil(t, contents)
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода GetTemplateContentProducts.
+//   - Классы эквивалентности: строки нескольких шаблонов читаются одним запросом и группируются по шаблону, ошибка запроса.
+func TestGetTemplateContentProducts(t *testing.T) {
+	mockClient, repo, ctx := newTestRepo()
+	templateIDs := []int64{1, 2}
+
+	scanArgs := []interface{}{mock.AnythingOfType("*int64"), mock.AnythingOfType("*int"),
+		mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*models.Money"),
+		mock.AnythingOfType("*models.VATRate"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*map[string]string"),
+		mock.AnythingOfType("*string"), mock.AnythingOfType("*[]models.Packaging"),
+		mock.AnythingOfType("**models.Nutrition"), mock.AnythingOfType("*[]models.Allergen"), mock.AnythingOfType("*map[string]models.Translation"),
+		mock.AnythingOfType("*models.ProductStatus"), mock.AnythingOfType("*models.Availability"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*[]string")}
+	expectLine := func(rows *postgresql.MockRows, templateID int64, quantity int, productID int64, name string, price models.Money) {
+		rows.On("Next").Return(true).Once()
+		rows.On("Scan", scanArgs...).
+			Run(func(args mock.Arguments) {
+				*(args[0].(*int64)) = templateID
+				*(args[1].(*int)) = quantity
+				*(args[2].(*int64)) = productID
+				*(args[3].(*string)) = name
+				*(args[5].(*models.Money)) = price
+			}).Return(nil).Once()
+	}
+
+	t.Run("Успешное получение строк с продуктами", func(t *testing.T) {
+		mockRows := new(postgresql.MockRows)
+		mockClient.On("Query", mock.Anything, mock.Anything, templateIDs).Return(mockRows, nil).Once()
+		expectLine(mockRows, 1, 2, 10, "Tea", models.Money(5000))
+		expectLine(mockRows, 1, 1, 11, "Coffee", models.Money(9000))
+		expectLine(mockRows, 2, 3, 10, "Tea", models.Money(5000))
+		mockRows.On("Next").Return(false).Once()
+		mockRows.On("Err").Return(nil).Once()
+
+		contents, err := repo.GetTemplateContentProducts(ctx, templateIDs)
+
+		assert.NoError(t, err)
+		if assert.Len(t, contents[1], 2) && assert.Len(t, contents[2], 1) {
+			assert.Equal(t, int64(11), contents[1][1].ProductID)
+			assert.Equal(t, 1, contents[1][1].Quantity)
+			assert.Equal(t, "Coffee", contents[1][1].Product.Name)
+			assert.Equal(t, models.Money(9000), contents[1][1].Product.Price)
+			assert.Equal(t, 3, contents[2][0].Quantity)
+		}
+		mockRows.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка выполнения запроса", func(t *testing.T) {
+		mockClient.On("Query", mock.Anything, mock.Anything, templateIDs).Return((*postgresql.MockRows)(nil), errors.New("query error")).Once()
+
+		contents, err := repo.GetTemplateContentProducts(ctx, templateIDs)
+
+		assert.EqualError(t, err, "query error")
+		assert.Nil(t, contents)
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
 // Техника тест-дизайна: Классы эквивалентности + анализ граничных значений
 // Автор: safr
 // Описание:
@@ -474,7 +764,7 @@ func TestListTemplates(t *testing.T) {
 		mockClient.On("Query", mock.Anything, mock.Anything).Return(mockRows, nil)
 
 		mockRows.On("Next").Return(true).Once()
-		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Run(func(args mock.Arguments) {
 				*(args[0].(*int64)) = expectedTemplates[0].ID
 				*(args[1].(*string)) = expectedTemplates[0].TemplateName
@@ -482,7 +772,7 @@ func TestListTemplates(t *testing.T) {
 			}).Return(nil).Once()
 
 		mockRows.On("Next").Return(true).Once()
-		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Run(func(args mock.Arguments) {
 				*(args[0].(*int64)) = expectedTemplates[1].ID
 				*(args[1].(*string)) = expectedTemplates[1].TemplateName
@@ -524,7 +814,7 @@ func TestListTemplates(t *testing.T) {
 		mockClient.On("Query", mock.Anything, mock.Anything).Return(mockRows, nil)
 
 		mockRows.On("Next").Return(true).Once()
-		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Return(errors.New("scan error")).Once()
 		mockRows.On("Next").Return(false).Once()
 		mockRows.On("Err").Return(nil)
@@ -583,7 +873,7 @@ func TestCreateTemplate(t *testing.T) {
 		mockRow := new(postgresql.MockRow)
 
 		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
-		mockTx.On("QueryRow", mock.Anything, mock.Anything, template.TemplateName, template.Description).
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, template.TemplateName, template.Description, template.Translations, template.Passengers).
 			Return(mockRow).Once()
 		mockRow.On("Scan", mock.AnythingOfType("*int64")).
 			Return(errors.New("insert error")).Once()
@@ -603,7 +893,7 @@ func TestCreateTemplate(t *testing.T) {
 		mockRow := new(postgresql.MockRow)
 
 		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
-		mockTx.On("QueryRow", mock.Anything, mock.Anything, template.TemplateName, template.Description).
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, template.TemplateName, template.Description, template.Translations, template.Passengers).
 			Return(mockRow).Once()
 		mockRow.On("Scan", mock.AnythingOfType("*int64")).
 			Run(func(args mock.Arguments) {
@@ -630,7 +920,7 @@ func TestCreateTemplate(t *testing.T) {
 		mockRow := new(postgresql.MockRow)
 
 		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
-		mockTx.On("QueryRow", mock.Anything, mock.Anything, template.TemplateName, template.Description).
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, template.TemplateName, template.Description, template.Translations, template.Passengers).
 			Return(mockRow).Once()
 		mockRow.On("Scan", mock.AnythingOfType("*int64")).
 			Run(func(args mock.Arguments) {
@@ -641,6 +931,7 @@ func TestCreateTemplate(t *testing.T) {
 			Return(pgconn.NewCommandTag("INSERT 1"), nil).Once()
 		mockTx.On("Exec", mock.Anything, mock.Anything, int64(1), template.Content[1].ProductID, template.Content[1].Quantity).
 			Return(pgconn.NewCommandTag("INSERT 1"), nil).Once()
+		expectRevision(mockTx, 1, "")
 		mockTx.On("Commit", mock.Anything).Return(errors.New("commit error")).Once()
 
 		err := repo.CreateTemplate(ctx, template)
@@ -653,12 +944,497 @@ func TestCreateTemplate(t *testing.T) {
 	})
 }
 
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода UpdateTemplate.
+//   - Классы эквивалентности: замена состава с сохранением, изменением количества, добавлением и удалением строк,
+//     повтор продукта в составе, продукт не найден, устаревшая версия, занятое название.
+func TestUpdateTemplate(t *testing.T) {
+	mockClient, repo, ctx := newTestRepo()
+
+	newTemplate := func() *models.Template {
+		return &models.Template{
+			ID:           7,
+			TemplateName: "Breakfast",
+			Description:  "Morning set",
+			Version:      2,
+			Content: []models.TemplateContent{
+				{ProductID: 1, Quantity: 5},  // без изменений
+				{ProductID: 2, Quantity: 12}, // новое количество
+				{ProductID: 4, Quantity: 1},  // новая строка
+			},
+		}
+	}
+
+	expectUpdate := func(mockTx *postgresql.MockTx, template *models.Template, version int64, err error) {
+		mockRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, template.TemplateName, template.Description, template.Translations, template.ID, template.Version, template.Passengers).
+			Return(mockRow).Once()
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = version }).
+			Return(err).Once()
+	}
+
+	expectCurrentContents := func(mockTx *postgresql.MockTx, contents ...models.TemplateContent) {
+		mockRows := new(postgresql.MockRows)
+		mockTx.On("Query", mock.Anything, mock.Anything, int64(7)).Return(mockRows, nil).Once()
+		for _, c := range contents {
+			mockRows.On("Next").Return(true).Once()
+			mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*int")).
+				Run(func(args mock.Arguments) {
+					*(args[0].(*int64)) = c.ProductID
+					*(args[1].(*int)) = c.Quantity
+				}).
+				Return(nil).Once()
+		}
+		mockRows.On("Next").Return(false).Once()
+		mockRows.On("Err").Return(nil)
+	}
+
+	t.Run("Успешная замена состава", func(t *testing.T) {
+		template := newTemplate()
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, template, 3, nil)
+		expectCurrentContents(mockTx,
+			models.TemplateContent{ProductID: 1, Quantity: 5},
+			models.TemplateContent{ProductID: 2, Quantity: 10},
+			models.TemplateContent{ProductID: 3, Quantity: 2},
+		)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(2), 12).
+			Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(4), 1).
+			Return(pgconn.NewCommandTag("INSERT 1"), nil).Once()
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), []int64{3}).
+			Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+		expectRevision(mockTx, 7, "ivanov")
+		mockTx.On("Commit", mock.Anything).Return(nil).Once()
+
+		err := repo.UpdateTemplate(models.WithAuthor(ctx, "ivanov"), template)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), template.Version)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка Conflict (продукт повторяется в составе)", func(t *testing.T) {
+		template := newTemplate()
+		template.Content = append(template.Content, models.TemplateContent{ProductID: 1, Quantity: 3})
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, template, 3, nil)
+		expectCurrentContents(mockTx, models.TemplateContent{ProductID: 1, Quantity: 5}, models.TemplateContent{ProductID: 2, Quantity: 12})
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(4), 1).
+			Return(pgconn.NewCommandTag("INSERT 1"), nil).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		err := repo.UpdateTemplate(ctx, template)
+
+		assert.True(t, myerr.IsConflict(err))
+		assert.Equal(t, int64(2), template.Version, "Version must not change on failure")
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка NotFound (продукт не найден)", func(t *testing.T) {
+		template := newTemplate()
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, template, 3, nil)
+		expectCurrentContents(mockTx, models.TemplateContent{ProductID: 1, Quantity: 5}, models.TemplateContent{ProductID: 2, Quantity: 12})
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(4), 1).
+			Return(pgconn.CommandTag{}, &pgconn.PgError{Code: pgerrcode.ForeignKeyViolation}).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		err := repo.UpdateTemplate(ctx, template)
+
+		assert.True(t, myerr.IsNotFound(err))
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка PreconditionFailed (версия устарела)", func(t *testing.T) {
+		template := newTemplate()
+		mockTx := new(postgresql.MockTx)
+		versionRow := new(postgresql.MockRow)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, template, 0, pgx.ErrNoRows)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, template.ID).Return(versionRow).Once()
+		versionRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = 5 }).
+			Return(nil).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		err := repo.UpdateTemplate(ctx, template)
+
+		assert.True(t, myerr.IsPreconditionFailed(err))
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка Conflict (название занято)", func(t *testing.T) {
+		template := newTemplate()
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectUpdate(mockTx, template, 0, &pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		err := repo.UpdateTemplate(ctx, template)
+
+		assert.True(t, myerr.IsConflict(err))
+		mockTx.AssertExpectations(t)
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
 // Техника тест-дизайна: Классы эквивалентности + анализ граничных значений
 // Автор: safr
 // Описание:
 //   - Тест для метода DeleteTemplate.
 //   - Проверяет корректность удаления шаблона и его содержимого.
 //   - Классы эквивалентности: успешное удаление, ошибка начала транзакции, ошибка удаления содержимого шаблона, ошибка удаления самого шаблона, ошибка коммита транзакции.
+func TestCloneTemplate(t *testing.T) {
+	mockClient, repo, ctx := newTestRepo()
+
+	expectClone := func(mockTx *postgresql.MockTx, id int64, err error) {
+		mockRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, int64(7), "Summer").Return(mockRow).Once()
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = id }).
+			Return(err).Once()
+	}
+
+	t.Run("Успешное копирование", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectClone(mockTx, 12, nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(12)).
+			Return(pgconn.NewCommandTag("INSERT 0 3"), nil).Once()
+		expectRevision(mockTx, 12, "")
+		mockTx.On("Commit", mock.Anything).Return(nil).Once()
+
+		id, err := repo.CloneTemplate(ctx, 7, "Summer")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), id)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка Conflict (название занято)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectClone(mockTx, 0, &pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.CloneTemplate(ctx, 7, "Summer")
+
+		assert.True(t, myerr.IsConflict(err))
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка NotFound (шаблон не найден)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectClone(mockTx, 0, pgx.ErrNoRows)
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.CloneTemplate(ctx, 7, "Summer")
+
+		assert.True(t, myerr.IsNotFound(err))
+		mockTx.AssertExpectations(t)
+	})
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода ImportTemplates.
+//   - Классы эквивалентности: шаблон с существующим названием заменяется, с новым — создается;
+//     ошибка в любом шаблоне откатывает всю транзакцию.
+func TestImportTemplates(t *testing.T) {
+	mockClient, repo, ctx := newTestRepo()
+
+	templates := []models.Template{
+		{TemplateName: "Breakfast", Version: 3, Content: []models.TemplateContent{{ProductID: 1, Quantity: 2}}},
+		{TemplateName: "Lunch", Content: []models.TemplateContent{{ProductID: 2, Quantity: 1}}},
+	}
+
+	expectFind := func(mockTx *postgresql.MockTx, name string, id int64, err error) {
+		mockRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, name).Return(mockRow).Once()
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = id }).
+			Return(err).Once()
+	}
+	expectScan := func(mockTx *postgresql.MockTx, value int64, args ...any) {
+		mockRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", append([]any{mock.Anything, mock.Anything}, args...)...).Return(mockRow).Once()
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = value }).
+			Return(nil).Once()
+	}
+
+	t.Run("Успешный импорт", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+
+		// Breakfast уже есть: заменяется без проверки версии
+		expectFind(mockTx, "Breakfast", 5, nil)
+		expectScan(mockTx, 4, "Breakfast", "", templates[0].Translations, int64(5), int64(0), 0)
+		mockRows := new(postgresql.MockRows)
+		mockTx.On("Query", mock.Anything, mock.Anything, int64(5)).Return(mockRows, nil).Once()
+		mockRows.On("Next").Return(false).Once()
+		mockRows.On("Err").Return(nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(5), int64(1), 2).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+		expectRevision(mockTx, 5, "")
+
+		// Lunch нет: создается
+		expectFind(mockTx, "Lunch", 0, pgx.ErrNoRows)
+		expectScan(mockTx, 9, "Lunch", "", templates[1].Translations, 0)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(9), int64(2), 1).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+		expectRevision(mockTx, 9, "")
+		mockTx.On("Commit", mock.Anything).Return(nil).Once()
+
+		results, err := repo.ImportTemplates(ctx, templates)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.TemplateImportResult{
+			{TemplateID: 5, TemplateName: "Breakfast", Version: 4},
+			{TemplateID: 9, TemplateName: "Lunch", Version: 1, Created: true},
+		}, results)
+		assert.Equal(t, int64(0), templates[1].ID, "Input templates must not change")
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка NotFound откатывает весь импорт", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectFind(mockTx, "Breakfast", 0, pgx.ErrNoRows)
+		expectScan(mockTx, 10, "Breakfast", "", templates[0].Translations, 0)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(10), int64(1), 2).
+			Return(pgconn.CommandTag{}, &pgconn.PgError{Code: pgerrcode.ForeignKeyViolation}).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.ImportTemplates(ctx, templates)
+
+		assert.True(t, myerr.IsNotFound(err))
+		mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+		mockTx.AssertExpectations(t)
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestTemplateContentLines(t *testing.T) {
+	mockClient, repo, ctx := newTestRepo()
+
+	// expectTouch ожидает повышение версии шаблона 7 с ожидаемой версией expected.
+	expectTouch := func(mockTx *postgresql.MockTx, expected int64, version int64, err error) {
+		mockRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, int64(7), expected).Return(mockRow).Once()
+		mockRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = version }).
+			Return(err).Once()
+	}
+
+	t.Run("Успешное добавление строки", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, 3, 4, nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(2), 3).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+		expectRevision(mockTx, 7, "")
+		mockTx.On("Commit", mock.Anything).Return(nil).Once()
+
+		version, err := repo.AddTemplateContent(ctx, 7, models.TemplateContent{ProductID: 2, Quantity: 3}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), version)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка PreconditionFailed при добавлении (версия шаблона устарела)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, 2, 0, pgx.ErrNoRows)
+		versionRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, int64(7)).Return(versionRow).Once()
+		versionRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = 3 }).
+			Return(nil).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.AddTemplateContent(ctx, 7, models.TemplateContent{ProductID: 2, Quantity: 3}, 2)
+
+		assert.True(t, myerr.IsPreconditionFailed(err))
+		mockTx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка Conflict (продукт уже в шаблоне)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, 0, 4, nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(2), 3).
+			Return(pgconn.CommandTag{}, &pgconn.PgError{Code: pgerrcode.UniqueViolation}).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.AddTemplateContent(ctx, 7, models.TemplateContent{ProductID: 2, Quantity: 3}, 0)
+
+		assert.True(t, myerr.IsConflict(err))
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка NotFound (продукт не найден)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, 0, 4, nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(99), 1).
+			Return(pgconn.CommandTag{}, &pgconn.PgError{Code: pgerrcode.ForeignKeyViolation}).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.AddTemplateContent(ctx, 7, models.TemplateContent{ProductID: 99, Quantity: 1}, 0)
+
+		assert.True(t, myerr.IsNotFound(err))
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка NotFound (шаблон не найден)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, 0, 0, pgx.ErrNoRows)
+		versionRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, int64(7)).Return(versionRow).Once()
+		versionRow.On("Scan", mock.AnythingOfType("*int64")).Return(pgx.ErrNoRows).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.DeleteTemplateContent(ctx, 7, 2, 0)
+
+		assert.True(t, myerr.IsNotFound(err))
+		mockTx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Успешное изменение количества", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, 4, 5, nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(2), 6).
+			Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+		expectRevision(mockTx, 7, "")
+		mockTx.On("Commit", mock.Anything).Return(nil).Once()
+
+		version, err := repo.UpdateTemplateContent(ctx, 7, models.TemplateContent{ProductID: 2, Quantity: 6}, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), version)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка PreconditionFailed (версия шаблона устарела)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, 3, 0, pgx.ErrNoRows)
+		versionRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, int64(7)).Return(versionRow).Once()
+		versionRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = 4 }).
+			Return(nil).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.UpdateTemplateContent(ctx, 7, models.TemplateContent{ProductID: 2, Quantity: 6}, 3)
+
+		assert.True(t, myerr.IsPreconditionFailed(err))
+		mockTx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка NotFound (строки нет в шаблоне)", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, 4, 5, nil)
+		mockTx.On("Exec", mock.Anything, mock.Anything, int64(7), int64(3)).
+			Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		_, err := repo.DeleteTemplateContent(ctx, 7, 3, 4)
+
+		assert.True(t, myerr.IsNotFound(err))
+		mockTx.AssertExpectations(t)
+	})
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для методов ListTemplateRevisions и GetTemplateRevision.
+//   - Классы эквивалентности: список ревизий, ревизия с составом, ревизия не найдена.
+func TestTemplateRevisions(t *testing.T) {
+	mockClient, repo, ctx := newTestRepo()
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	revisionFields := []any{mock.AnythingOfType("*int64"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"),
+		mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int"), mock.AnythingOfType("*string"), mock.AnythingOfType("*time.Time"),
+		mock.AnythingOfType("*bool")}
+	fillRevision := func(revision int64, name string) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			*(args[0].(*int64)) = 7
+			*(args[1].(*int64)) = revision
+			*(args[2].(*string)) = name
+			*(args[6].(*string)) = "ivanov"
+			*(args[7].(*time.Time)) = created
+		}
+	}
+
+	t.Run("Список ревизий", func(t *testing.T) {
+		mockRows := new(postgresql.MockRows)
+		mockClient.On("Query", mock.Anything, mock.Anything, int64(7)).Return(mockRows, nil).Once()
+		mockRows.On("Next").Return(true).Twice()
+		mockRows.On("Scan", revisionFields...).Run(fillRevision(3, "Breakfast")).Return(nil).Once()
+		mockRows.On("Scan", revisionFields...).Run(fillRevision(2, "Lunch")).Return(nil).Once()
+		mockRows.On("Next").Return(false).Once()
+		mockRows.On("Err").Return(nil)
+
+		revisions, err := repo.ListTemplateRevisions(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 2)
+		assert.Equal(t, int64(3), revisions[0].Revision)
+		assert.Equal(t, "ivanov", revisions[0].Author)
+		assert.Equal(t, models.Template{ID: 7, TemplateName: "Breakfast", Version: 3, UpdatedAt: created}, revisions[0].Template)
+		mockRows.AssertExpectations(t)
+	})
+
+	t.Run("Ревизия с составом", func(t *testing.T) {
+		mockRow := new(postgresql.MockRow)
+		mockClient.On("QueryRow", mock.Anything, mock.Anything, int64(7), int64(2)).Return(mockRow).Once()
+		mockRow.On("Scan", append(revisionFields, mock.AnythingOfType("*[]models.TemplateContent"))...).
+			Run(func(args mock.Arguments) {
+				fillRevision(2, "Lunch")(args)
+				*(args[9].(*[]models.TemplateContent)) = []models.TemplateContent{{ProductID: 1, Quantity: 5}}
+			}).
+			Return(nil).Once()
+
+		rev, err := repo.GetTemplateRevision(ctx, 7, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), rev.TemplateID)
+		assert.Equal(t, int64(2), rev.Template.Version)
+		assert.Equal(t, []models.TemplateContent{{ProductID: 1, Quantity: 5}}, rev.Template.Content)
+		mockRow.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка NotFound (ревизия не найдена)", func(t *testing.T) {
+		mockRow := new(postgresql.MockRow)
+		mockClient.On("QueryRow", mock.Anything, mock.Anything, int64(7), int64(9)).Return(mockRow).Once()
+		mockRow.On("Scan", append(revisionFields, mock.AnythingOfType("*[]models.TemplateContent"))...).Return(pgx.ErrNoRows).Once()
+
+		_, err := repo.GetTemplateRevision(ctx, 7, 9)
+
+		assert.True(t, myerr.IsNotFound(err))
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestDeleteTemplate(t *testing.T) {
 	ctx := context.Background()
 	logger := log.NewNopLogger()
@@ -666,16 +1442,39 @@ func TestDeleteTemplate(t *testing.T) {
 	repo := postgresql.NewGoodsRepository(mockClient, logger)
 
 	packageID := int64(1)
+	version := int64(4)
+
+	// expectTouch ожидает повышение версии шаблона с проверкой ожидаемой версии.
+	expectTouch := func(mockTx *postgresql.MockTx, err error) {
+		touchRow := new(postgresql.MockRow)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, packageID, version).Return(touchRow).Once()
+		touchRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = version + 1 }).
+			Return(err).Once()
+	}
+	// expectDeletionRevision ожидает запись ревизии, отмеченной как удаление шаблона.
+	expectDeletionRevision := func(mockTx *postgresql.MockTx) {
+		mockTx.On("Exec", mock.Anything, mock.MatchedBy(func(sql string) bool { return strings.Contains(sql, "packagerevision") }), packageID, "", true).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	}
+	// expectDelete ожидает запись ревизии удаления, удаление состава и затем самого шаблона.
+	expectDelete := func(mockTx *postgresql.MockTx, templateErr error) {
+		expectTouch(mockTx, nil)
+		expectDeletionRevision(mockTx)
+		mockTx.On("Exec", mock.Anything, mock.MatchedBy(func(sql string) bool { return strings.Contains(sql, "packagecontent") }), packageID).
+			Return(pgconn.NewCommandTag("DELETE 2"), nil).Once()
+		mockTx.On("Exec", mock.Anything, mock.MatchedBy(func(sql string) bool { return strings.HasPrefix(sql, "DELETE FROM package ") }), packageID).
+			Return(pgconn.NewCommandTag("DELETE 1"), templateErr).Once()
+	}
 
-	t.Run("успешное удаление шаблона", func(t *testing.T) {
+	t.Run("успешное удаление шаблона с ревизией удаления", func(t *testing.T) {
 		mockTx := new(postgresql.MockTx)
 
 		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
-		mockTx.On("Exec", mock.Anything, mock.Anything, packageID).
-			Return(pgconn.NewCommandTag("DELETE 1"), nil).Twice()
+		expectDelete(mockTx, nil)
 		mockTx.On("Commit", mock.Anything).Return(nil).Once()
 
-		err := repo.DeleteTemplate(ctx, packageID)
+		err := repo.DeleteTemplate(ctx, packageID, version)
 
 		assert.NoError(t, err)
 		mockClient.AssertExpectations(t)
@@ -685,7 +1484,7 @@ func TestDeleteTemplate(t *testing.T) {
 	t.Run("ошибка: не удалось начать транзакцию", func(t *testing.T) {
 		mockClient.On("Begin", mock.Anything).Return((*postgresql.MockTx)(nil), errors.New("transaction error")).Once()
 
-		err := repo.DeleteTemplate(ctx, packageID)
+		err := repo.DeleteTemplate(ctx, packageID, version)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "transaction error")
@@ -696,11 +1495,13 @@ func TestDeleteTemplate(t *testing.T) {
 		mockTx := new(postgresql.MockTx)
 
 		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
-		mockTx.On("Exec", mock.Anything, mock.Anything, packageID).
+		expectTouch(mockTx, nil)
+		expectDeletionRevision(mockTx)
+		mockTx.On("Exec", mock.Anything, mock.MatchedBy(func(sql string) bool { return strings.Contains(sql, "packagecontent") }), packageID).
 			Return(pgconn.NewCommandTag("DELETE 0"), errors.New("delete content error")).Once()
 		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
 
-		err := repo.DeleteTemplate(ctx, packageID)
+		err := repo.DeleteTemplate(ctx, packageID, version)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "delete content error")
@@ -712,13 +1513,10 @@ func TestDeleteTemplate(t *testing.T) {
 		mockTx := new(postgresql.MockTx)
 
 		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
-		mockTx.On("Exec", mock.Anything, mock.Anything, packageID).
-			Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
-		mockTx.On("Exec", mock.Anything, mock.Anything, packageID).
-			Return(pgconn.NewCommandTag("DELETE 0"), errors.New("delete template error")).Once()
+		expectDelete(mockTx, errors.New("delete template error"))
 		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
 
-		err := repo.DeleteTemplate(ctx, packageID)
+		err := repo.DeleteTemplate(ctx, packageID, version)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "delete template error")
@@ -726,16 +1524,52 @@ func TestDeleteTemplate(t *testing.T) {
 		mockTx.AssertExpectations(t)
 	})
 
+	t.Run("ошибка PreconditionFailed: версия устарела, транзакция откатывается", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		versionRow := new(postgresql.MockRow)
+
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, pgx.ErrNoRows)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, packageID).Return(versionRow).Once()
+		versionRow.On("Scan", mock.AnythingOfType("*int64")).
+			Run(func(args mock.Arguments) { *args.Get(0).(*int64) = version + 1 }).
+			Return(nil).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		err := repo.DeleteTemplate(ctx, packageID, version)
+
+		assert.True(t, myerr.IsPreconditionFailed(err))
+		mockTx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+		mockClient.AssertExpectations(t)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("ошибка NotFound: шаблона нет, транзакция откатывается", func(t *testing.T) {
+		mockTx := new(postgresql.MockTx)
+		versionRow := new(postgresql.MockRow)
+
+		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
+		expectTouch(mockTx, pgx.ErrNoRows)
+		mockTx.On("QueryRow", mock.Anything, mock.Anything, packageID).Return(versionRow).Once()
+		versionRow.On("Scan", mock.AnythingOfType("*int64")).Return(pgx.ErrNoRows).Once()
+		mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		err := repo.DeleteTemplate(ctx, packageID, version)
+
+		assert.True(t, myerr.IsNotFound(err))
+		mockClient.AssertExpectations(t)
+		mockTx.AssertExpectations(t)
+	})
+
 	t.Run("ошибка: не удалось зафиксировать транзакцию", func(t *testing.T) {
 		mockTx := new(postgresql.MockTx)
 
 		mockClient.On("Begin", mock.Anything).Return(mockTx, nil).Once()
-		mockTx.On("Exec", mock.Anything, mock.Anything, packageID).
-			Return(pgconn.NewCommandTag("DELETE 1"), nil).Twice()
+		expectDelete(mockTx, nil)
 		mockTx.On("Commit", mock.Anything).Return(errors.New("commit error")).Once()
 		mockTx.On("Rollback", mock.Anything).Return(nil).Once() // Добавляем Rollback
 
-		err := repo.DeleteTemplate(ctx, packageID)
+		err := repo.DeleteTemplate(ctx, packageID, version)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "commit error")
@@ -764,7 +1598,7 @@ func TestSearchTemplates(t *testing.T) {
 			Return(mockRows, nil).Once()
 
 		mockRows.On("Next").Return(true).Once()
-		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Run(func(args mock.Arguments) {
 				*(args[0].(*int64)) = 1
 				*(args[1].(*string)) = "Test Template"
@@ -807,7 +1641,7 @@ func TestSearchTemplates(t *testing.T) {
 			Return(mockRows, nil).Once()
 
 		mockRows.On("Next").Return(true).Once()
-		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Return(errors.New("scan error")).Once()
 		mockRows.On("Next").Return(false).Once() // Завершаем итерацию
 		mockRows.On("Err").Return(nil).Once()    // Нет ошибки на уровне строк
@@ -863,7 +1697,7 @@ func TestGetAllTemplates(t *testing.T) {
 			Return(mockRows, nil).Once()
 
 		mockRows.On("Next").Return(true).Once()
-		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Run(func(args mock.Arguments) {
 				*(args[0].(*int64)) = 1
 				*(args[1].(*string)) = "Test Template"
@@ -908,7 +1742,7 @@ func TestGetAllTemplates(t *testing.T) {
 			Return(mockRows, nil).Once()
 
 		mockRows.On("Next").Return(true).Once()
-		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).
+		mockRows.On("Scan", mock.AnythingOfType("*int64"), mock.AnythingOfType("*string"), mock.AnythingOfType("*string"), mock.AnythingOfType("*map[string]models.Translation"), mock.AnythingOfType("*int64"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*int")).
 			Return(errors.New("scan error")).Once()
 
 		mockRows.On("Next").Return(false).Once()
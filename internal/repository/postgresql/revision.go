@@ -0,0 +1,93 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// revisionColumns lists the template revision columns, without the content, in the order expected by scanRevision.
+const revisionColumns = `packageid, revision, packagename, description, translations, passengers, author, createdat, deleted`
+
+// scanRevision scans a row selected with revisionColumns, followed by extra destinations, into rev.
+func scanRevision(row pgx.Row, rev *models.TemplateRevision, extra ...any) error {
+	t := &rev.Template
+	dest := []any{&rev.TemplateID, &rev.Revision, &t.TemplateName, &t.Description, &t.Translations, &t.Passengers, &rev.Author, &rev.CreatedAt, &rev.Deleted}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	t.ID = rev.TemplateID
+	t.Version = rev.Revision
+	t.UpdatedAt = rev.CreatedAt
+	return nil
+}
+
+// recordTemplateRevision snapshots the current state of a template into packagerevision.
+// It must run as the last statement of every transaction that changes a template, after the row version
+// has been bumped, so that the revision number equals the version the change produced.
+// The author is taken from the context.
+func recordTemplateRevision(ctx context.Context, tx pgx.Tx, templateID int64) error {
+	return insertTemplateRevision(ctx, tx, templateID, false)
+}
+
+// recordTemplateDeletion snapshots the final state of a template into packagerevision and marks the revision
+// as the deletion, so that the history shows who deleted the template and when.
+// It must run after the row version has been bumped and before the template and its contents are deleted.
+func recordTemplateDeletion(ctx context.Context, tx pgx.Tx, templateID int64) error {
+	return insertTemplateRevision(ctx, tx, templateID, true)
+}
+
+// insertTemplateRevision snapshots the current state of a template into packagerevision.
+func insertTemplateRevision(ctx context.Context, tx pgx.Tx, templateID int64, deleted bool) error {
+	const sql = `INSERT INTO packagerevision (packageid, revision, packagename, description, translations, passengers, content, author, deleted)
+		SELECT p.packageid, p.rowversion, p.packagename, p.description, p.translations, p.passengers,
+			COALESCE((SELECT jsonb_agg(jsonb_build_object('product_id', c.productid, 'quantity', c.quantity) ORDER BY c.packagecontentid)
+				FROM packagecontent c WHERE c.packageid = p.packageid), '[]'::jsonb),
+			$2, $3
+		FROM package p WHERE p.packageid = $1;`
+	_, err := tx.Exec(ctx, sql, templateID, models.AuthorFromContext(ctx), deleted)
+	return err
+}
+
+// ListTemplateRevisions returns the revisions of a template, newest first, without their contents.
+// Revisions outlive the template, so the history of a deleted template can still be read.
+func (r *GoodsPGRepository) ListTemplateRevisions(ctx context.Context, templateID int64) ([]models.TemplateRevision, error) {
+	const sql = "SELECT " + revisionColumns + " FROM packagerevision WHERE packageid = $1 ORDER BY revision DESC;"
+	rows, err := r.client.Query(ctx, sql, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []models.TemplateRevision
+	for rows.Next() {
+		var rev models.TemplateRevision
+		if err := scanRevision(rows, &rev); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// GetTemplateRevision returns a single revision of a template along with the contents it recorded.
+func (r *GoodsPGRepository) GetTemplateRevision(ctx context.Context, templateID int64, revision int64) (models.TemplateRevision, error) {
+	const sql = "SELECT " + revisionColumns + ", content FROM packagerevision WHERE packageid = $1 AND revision = $2;"
+	var rev models.TemplateRevision
+	if err := scanRevision(r.client.QueryRow(ctx, sql, templateID, revision), &rev, &rev.Template.Content); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return rev, myerr.NotFound(fmt.Sprintf("Revision %d of template with ID %d not found", revision, templateID), nil)
+		}
+		return rev, err
+	}
+	return rev, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// GetAvailableProducts возвращает продукты в продаже, которые разрешено продавать на маршруте в момент продажи.
+// Вариант доступен, только если доступен и он сам, и его родительский продукт.
+func (s *GoodsService) GetAvailableProducts(ctx context.Context, sale models.SaleContext, query models.ProductQuery) ([]models.Product, error) {
+	logger := log.With(s.log, "method", "GetAvailableProducts")
+	if sale.Route == "" {
+		return nil, myerr.Validation("Route must be specified", nil)
+	}
+	if sale.At.IsZero() {
+		return nil, myerr.Validation("Sale time must be specified", nil)
+	}
+	products, err := s.publishedProducts(ctx, query)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	return localizeProducts(nestVariants(availableProducts(products, sale)), query.Locales), nil
+}
+
+// availableProducts оставляет продукты в продаже, правила доступности которых и их родителей разрешают продажу.
+func availableProducts(products []models.Product, sale models.SaleContext) []models.Product {
+	unavailable := make(map[int64]bool)
+	for _, p := range products {
+		if !p.IsActive() || !p.Availability.Allows(sale) {
+			unavailable[p.ID] = true
+		}
+	}
+	filtered := make([]models.Product, 0, len(products))
+	for _, p := range products {
+		if !unavailable[p.ID] && !unavailable[p.ParentID] {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// validateAvailability проверяет правила доступности продукта: дни недели, формат времени и дат,
+// непустые коды маршрутов и регионов и то, что один код не одновременно разрешен и запрещен.
+func validateAvailability(a models.Availability) error {
+	for _, w := range a.Windows {
+		for _, day := range w.Days {
+			if !day.Valid() {
+				return myerr.Validation(fmt.Sprintf("Unknown weekday %q, expected one of %v", day, models.Weekdays), nil)
+			}
+		}
+		if !validLayout(models.ClockLayout, w.Start) || !validLayout(models.ClockLayout, w.End) {
+			return myerr.Validation(fmt.Sprintf("Time window %s-%s must use HH:MM format", w.Start, w.End), nil)
+		}
+		if w.Start == w.End {
+			return myerr.Validation(fmt.Sprintf("Time window %s-%s is empty", w.Start, w.End), nil)
+		}
+	}
+	for _, p := range a.Periods {
+		if !validLayout(models.DateLayout, p.From) || !validLayout(models.DateLayout, p.To) {
+			return myerr.Validation(fmt.Sprintf("Period %s..%s must use YYYY-MM-DD format", p.From, p.To), nil)
+		}
+		if p.From > p.To {
+			return myerr.Validation(fmt.Sprintf("Period %s..%s ends before it starts", p.From, p.To), nil)
+		}
+	}
+	if err := validateAccessList("route", a.Routes); err != nil {
+		return err
+	}
+	return validateAccessList("region", a.Regions)
+}
+
+// validateAccessList проверяет, что коды непустые и ни один код не указан и в разрешенных, и в запрещенных.
+func validateAccessList(kind string, l models.AccessList) error {
+	allowed := make(map[string]bool, len(l.Allow))
+	for _, code := range l.Allow {
+		if code == "" {
+			return myerr.Validation(fmt.Sprintf("Allowed %s code must not be empty", kind), nil)
+		}
+		allowed[code] = true
+	}
+	for _, code := range l.Deny {
+		if code == "" {
+			return myerr.Validation(fmt.Sprintf("Denied %s code must not be empty", kind), nil)
+		}
+		if allowed[code] {
+			return myerr.Validation(fmt.Sprintf("The %s %s is both allowed and denied", kind, code), nil)
+		}
+	}
+	return nil
+}
+
+// validLayout сообщает, что значение записано строго в формате layout с ведущими нулями.
+func validLayout(layout, value string) bool {
+	t, err := time.Parse(layout, value)
+	return err == nil && t.Format(layout) == value
+}
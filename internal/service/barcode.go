@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// GetProductByBarcode возвращает продукт по отсканированному штрихкоду с ценой из выбранного прайс-листа.
+func (s *GoodsService) GetProductByBarcode(ctx context.Context, code string, query models.ProductQuery) (models.Product, error) {
+	logger := log.With(s.log, "method", "GetProductByBarcode")
+	gtin, err := models.NormalizeBarcode(code)
+	if err != nil {
+		return models.Product{}, err
+	}
+	id, err := s.repo.GetProductIDByBarcode(ctx, gtin)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.Product{}, err
+	}
+	return s.GetProductByID(ctx, id, query)
+}
+
+// validateBarcodes проверяет контрольные цифры штрихкодов продукта и то, что продукт не содержит один и тот же код дважды.
+// Уникальность среди других продуктов обеспечивает база данных.
+func validateBarcodes(barcodes []string) error {
+	seen := make(map[string]string, len(barcodes))
+	for _, code := range barcodes {
+		gtin, err := models.NormalizeBarcode(code)
+		if err != nil {
+			return err
+		}
+		if prev, ok := seen[gtin]; ok {
+			return myerr.Validation(fmt.Sprintf("Barcodes %s and %s encode the same GTIN", prev, code), nil)
+		}
+		seen[gtin] = code
+	}
+	return nil
+}
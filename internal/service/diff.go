@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// DiffTemplates сравнивает состав двух шаблонов: продукты только в первом, только во втором и с разными количествами.
+// Названия продуктов и шаблонов локализуются на предпочитаемый язык.
+func (s *GoodsService) DiffTemplates(ctx context.Context, a int64, b int64, query models.TemplateQuery) (models.TemplateDiff, error) {
+	logger := log.With(s.log, "method", "DiffTemplates")
+	templateA, err := s.repo.GetTemplateByID(ctx, a)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.TemplateDiff{}, err
+	}
+	templateB, err := s.repo.GetTemplateByID(ctx, b)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.TemplateDiff{}, err
+	}
+
+	diff := diffTemplateContents(templateA.Content, templateB.Content)
+	productIDs := make([]int64, 0, len(templateA.Content)+len(templateB.Content))
+	for _, lines := range [][]models.TemplateContent{diff.OnlyInA, diff.OnlyInB} {
+		for _, line := range lines {
+			productIDs = append(productIDs, line.ProductID)
+		}
+	}
+	for _, line := range diff.Changed {
+		productIDs = append(productIDs, line.ProductID)
+	}
+	products, err := s.templateProducts(ctx, productIDs)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.TemplateDiff{}, err
+	}
+	product := func(id int64) *models.Product {
+		p, ok := products[id]
+		if !ok {
+			return nil
+		}
+		p = p.Localize(query.Locales)
+		return &p
+	}
+	for i := range diff.OnlyInA {
+		diff.OnlyInA[i].Product = product(diff.OnlyInA[i].ProductID)
+	}
+	for i := range diff.OnlyInB {
+		diff.OnlyInB[i].Product = product(diff.OnlyInB[i].ProductID)
+	}
+	for i := range diff.Changed {
+		diff.Changed[i].Product = product(diff.Changed[i].ProductID)
+	}
+
+	templateA.Content, templateB.Content = nil, nil
+	diff.A = templateA.Localize(query.Locales)
+	diff.B = templateB.Localize(query.Locales)
+	return diff, nil
+}
+
+// diffTemplateContents раскладывает строки двух шаблонов на продукты только в первом, только во втором
+// и продукты с разными количествами; одинаковые строки в результат не попадают.
+func diffTemplateContents(a, b []models.TemplateContent) models.TemplateDiff {
+	quantitiesB := make(map[int64]int, len(b))
+	for _, line := range b {
+		quantitiesB[line.ProductID] = line.Quantity
+	}
+	inA := make(map[int64]bool, len(a))
+	var diff models.TemplateDiff
+	for _, line := range a {
+		inA[line.ProductID] = true
+		quantityB, ok := quantitiesB[line.ProductID]
+		switch {
+		case !ok:
+			diff.OnlyInA = append(diff.OnlyInA, models.TemplateContent{ProductID: line.ProductID, Quantity: line.Quantity})
+		case quantityB != line.Quantity:
+			diff.Changed = append(diff.Changed, models.TemplateLineDiff{ProductID: line.ProductID, QuantityA: line.Quantity, QuantityB: quantityB})
+		}
+	}
+	for _, line := range b {
+		if !inA[line.ProductID] {
+			diff.OnlyInB = append(diff.OnlyInB, models.TemplateContent{ProductID: line.ProductID, Quantity: line.Quantity})
+		}
+	}
+	return diff
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strings"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+const (
+	// MaxImageSize — максимальный размер загружаемого изображения в байтах.
+	MaxImageSize = 5 << 20
+	// ThumbnailSize — максимальная длина большей стороны миниатюры в пикселях.
+	ThumbnailSize = 256
+	// maxImageSide ограничивает размеры изображения, чтобы маленький файл не распаковался в гигабайты пикселей.
+	maxImageSide = 8000
+	// jpegQuality — качество кодирования миниатюр в формате JPEG.
+	jpegQuality = 85
+)
+
+// imageFormat описывает поддерживаемый формат изображения.
+type imageFormat struct {
+	ext    string
+	encode func(buf *bytes.Buffer, img image.Image) error
+}
+
+// imageFormats перечисляет допустимые MIME-типы загружаемых изображений.
+var imageFormats = map[string]imageFormat{
+	"image/jpeg": {
+		ext: ".jpg",
+		encode: func(buf *bytes.Buffer, img image.Image) error {
+			return jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality})
+		},
+	},
+	"image/png": {
+		ext: ".png",
+		encode: func(buf *bytes.Buffer, img image.Image) error {
+			return png.Encode(buf, img)
+		},
+	},
+}
+
+// UploadProductImage сохраняет изображение продукта и его миниатюру и проставляет продукту ссылки на них.
+// Тип содержимого определяется по самим данным и должен совпадать с заявленным клиентом, если тот его указал.
+func (s *GoodsService) UploadProductImage(ctx context.Context, productID int64, upload models.Blob) (models.ProductImage, error) {
+	logger := log.With(s.log, "method", "UploadProductImage")
+
+	if len(upload.Data) == 0 {
+		return models.ProductImage{}, myerr.Validation("Image must not be empty", nil)
+	}
+	if len(upload.Data) > MaxImageSize {
+		return models.ProductImage{}, myerr.Validation(fmt.Sprintf("Image size exceeds %d bytes", MaxImageSize), nil)
+	}
+	contentType := http.DetectContentType(upload.Data)
+	format, ok := imageFormats[contentType]
+	if !ok {
+		return models.ProductImage{}, myerr.Validation(fmt.Sprintf("Unsupported image type %s, expected JPEG or PNG", contentType), nil)
+	}
+	if declared := mediaType(upload.ContentType); declared != "" && declared != contentType {
+		return models.ProductImage{}, myerr.Validation(fmt.Sprintf("Declared content type %s does not match image data %s", declared, contentType), nil)
+	}
+
+	thumb, err := makeThumbnail(upload.Data, format)
+	if err != nil {
+		return models.ProductImage{}, err
+	}
+
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.ProductImage{}, err
+	}
+
+	suffix, err := randomSuffix()
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.ProductImage{}, err
+	}
+	imageKey := fmt.Sprintf("product-%d-%s%s", productID, suffix, format.ext)
+	thumbKey := fmt.Sprintf("product-%d-%s-thumb%s", productID, suffix, format.ext)
+
+	if err := s.images.Put(ctx, imageKey, models.Blob{ContentType: contentType, Data: upload.Data}); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.ProductImage{}, err
+	}
+	if err := s.images.Put(ctx, thumbKey, models.Blob{ContentType: contentType, Data: thumb}); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		s.deleteImages(ctx, logger, imageKey)
+		return models.ProductImage{}, err
+	}
+
+	productImage := models.ProductImage{
+		ImageURL:     s.images.URL(imageKey),
+		ThumbnailURL: s.images.URL(thumbKey),
+	}
+	if err := s.repo.SetProductImage(ctx, productID, productImage); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		s.deleteImages(ctx, logger, imageKey, thumbKey)
+		return models.ProductImage{}, err
+	}
+
+	// Предыдущие изображения удаляются, только если они хранились у нас, а не были заданы внешней ссылкой.
+	s.deleteImages(ctx, logger, s.imageKey(product.ImageURL), s.imageKey(product.ThumbnailURL))
+	return productImage, nil
+}
+
+// GetImage возвращает сохраненное изображение по его ключу.
+func (s *GoodsService) GetImage(ctx context.Context, key string) (models.Blob, error) {
+	logger := log.With(s.log, "method", "GetImage")
+	blob, err := s.images.Get(ctx, key)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.Blob{}, err
+	}
+	return blob, nil
+}
+
+// imageKey возвращает ключ хранилища для ссылки на изображение или пустую строку для внешних ссылок.
+func (s *GoodsService) imageKey(url string) string {
+	if url == "" {
+		return ""
+	}
+	key, ok := strings.CutPrefix(url, s.images.URL(""))
+	if !ok {
+		return ""
+	}
+	return key
+}
+
+// deleteImages удаляет изображения из хранилища. Ошибки только логируются: ссылки на них уже не используются.
+func (s *GoodsService) deleteImages(ctx context.Context, logger log.Logger, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.images.Delete(ctx, key); err != nil {
+			_ = level.Warn(logger).Log("msg", "failed to delete image", "key", key, "err", err)
+		}
+	}
+}
+
+// mediaType отбрасывает параметры заголовка Content-Type, например "; charset=...".
+func mediaType(contentType string) string {
+	mt, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
+// randomSuffix генерирует случайную часть ключа, чтобы новое изображение не попадало в кэш по старой ссылке.
+func randomSuffix() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// makeThumbnail декодирует изображение и кодирует его уменьшенную копию в том же формате.
+func makeThumbnail(data []byte, format imageFormat) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, myerr.Validation("Image data is corrupted", err)
+	}
+	if cfg.Width > maxImageSide || cfg.Height > maxImageSide {
+		return nil, myerr.Validation(fmt.Sprintf("Image dimensions exceed %dx%d pixels", maxImageSide, maxImageSide), nil)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, myerr.Validation("Image data is corrupted", err)
+	}
+
+	var buf bytes.Buffer
+	if err := format.encode(&buf, resize(img, ThumbnailSize)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resize уменьшает изображение так, чтобы большая сторона не превышала maxSide, сохраняя пропорции.
+// Каждый пиксель результата — среднее покрываемой им области исходного изображения.
+// Изображения, которые уже помещаются в maxSide, возвращаются без изменений.
+func resize(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSide && height <= maxSide {
+		return src
+	}
+
+	dstWidth, dstHeight := maxSide, maxSide
+	if width >= height {
+		dstHeight = max(1, height*maxSide/width)
+	} else {
+		dstWidth = max(1, width*maxSide/height)
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := bounds.Min.Y+y*height/dstHeight, bounds.Min.Y+(y+1)*height/dstHeight
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := bounds.Min.X+x*width/dstWidth, bounds.Min.X+(x+1)*width/dstWidth
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
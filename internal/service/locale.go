@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// validateTranslations проверяет коды языков переводов. Перевод на язык по умолчанию не допускается:
+// он хранится в основных полях, и два источника одного текста быстро разойдутся.
+func validateTranslations(translations map[string]models.Translation) error {
+	for locale, t := range translations {
+		if problem := translationProblem(locale, t); problem != "" {
+			return myerr.Validation(problem, nil)
+		}
+	}
+	return nil
+}
+
+// translationProblem возвращает описание проблемы перевода или пустую строку, если перевод корректен.
+func translationProblem(locale string, t models.Translation) string {
+	switch {
+	case !models.ValidLocale(locale):
+		return fmt.Sprintf("Invalid language code %q, expected a lowercase ISO 639 code such as \"en\"", locale)
+	case locale == models.DefaultLocale:
+		return fmt.Sprintf("Translation to the default language %q must be set in the main fields", locale)
+	case t.Name == "" && t.Description == "":
+		return fmt.Sprintf("Translation to %q must not be empty", locale)
+	}
+	return ""
+}
+
+// localizeProducts локализует продукты на первый доступный из предпочитаемых языков.
+func localizeProducts(products []models.Product, locales []string) []models.Product {
+	if len(locales) == 0 {
+		return products
+	}
+	for i := range products {
+		products[i] = products[i].Localize(locales)
+	}
+	return products
+}
+
+// localizeTemplates локализует шаблоны на первый доступный из предпочитаемых языков.
+func localizeTemplates(templates []models.Template, locales []string) []models.Template {
+	if len(locales) == 0 {
+		return templates
+	}
+	for i := range templates {
+		templates[i] = templates[i].Localize(locales)
+	}
+	return templates
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// maxNutrientsPer100g — белки, жиры и углеводы вместе не могут весить больше самой порции.
+const maxNutrientsPer100g = 100
+
+// validateNutrition проверяет пищевую ценность и аллергены продукта.
+func validateNutrition(p *models.Product) error {
+	if n := p.Nutrition; n != nil {
+		if n.Kcal < 0 || n.Proteins < 0 || n.Fats < 0 || n.Carbohydrates < 0 {
+			return myerr.Validation("Nutrition values must not be negative", nil)
+		}
+		if n.Proteins+n.Fats+n.Carbohydrates > maxNutrientsPer100g {
+			return myerr.Validation(fmt.Sprintf("Proteins, fats and carbohydrates must not exceed %d g per 100 g", maxNutrientsPer100g), nil)
+		}
+	}
+	return validateAllergens(p.Allergens)
+}
+
+// validateAllergens проверяет, что все аллергены входят в перечень допустимых.
+func validateAllergens(allergens []models.Allergen) error {
+	for _, allergen := range allergens {
+		if !allergen.Valid() {
+			return myerr.Validation(fmt.Sprintf("Unknown allergen %q, expected one of %v", allergen, models.Allergens), nil)
+		}
+	}
+	return nil
+}
+
+// excludeAllergens убирает из списка продукты, содержащие хотя бы один из перечисленных аллергенов.
+func excludeAllergens(products []models.Product, allergens []models.Allergen) []models.Product {
+	if len(allergens) == 0 {
+		return products
+	}
+	filtered := make([]models.Product, 0, len(products))
+	for _, p := range products {
+		if !p.ContainsAnyAllergen(allergens) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
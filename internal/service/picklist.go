@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// MaxPickListMultiplier ограничивает число комплектов одного шаблона в сводном списке комплектации.
+const MaxPickListMultiplier = 10_000
+
+// BuildPickList сводит несколько шаблонов, каждый со своим числом комплектов, в один список продуктов с общими количествами.
+// Список отсортирован по названию (локализованному на предпочитаемый язык) или по артикулу продукта.
+func (s *GoodsService) BuildPickList(ctx context.Context, entries []models.PickListEntry, query models.PickListQuery) ([]models.PickListItem, error) {
+	logger := log.With(s.log, "method", "BuildPickList")
+	if err := validatePickList(entries, query); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	quantities := make(map[int64]int)
+	var productIDs []int64
+	for _, entry := range entries {
+		content, err := s.templateContent(ctx, entry.TemplateID)
+		if err != nil {
+			_ = level.Error(logger).Log("err", err)
+			return nil, err
+		}
+		for _, line := range content {
+			if _, ok := quantities[line.ProductID]; !ok {
+				productIDs = append(productIDs, line.ProductID)
+			}
+			total, ok := addPickQuantity(quantities[line.ProductID], line.Quantity, entry.Multiplier)
+			if !ok {
+				err := myerr.Validation(fmt.Sprintf("Total quantity of product with ID %d is too large", line.ProductID), nil)
+				_ = level.Error(logger).Log("err", err)
+				return nil, err
+			}
+			quantities[line.ProductID] = total
+		}
+	}
+
+	products, err := s.templateProducts(ctx, productIDs)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	items := make([]models.PickListItem, 0, len(productIDs))
+	for _, id := range productIDs {
+		product, ok := products[id]
+		if !ok {
+			product = models.Product{ID: id}
+		}
+		items = append(items, models.PickListItem{Product: product.Localize(query.Locales), Quantity: quantities[id]})
+	}
+	sortPickList(items, query.SortBy)
+	return items, nil
+}
+
+// templateContent возвращает состав шаблона. Пустой состав перепроверяется по самому шаблону,
+// чтобы опечатка в ID давала NotFound, а не молча уменьшала сводный список.
+func (s *GoodsService) templateContent(ctx context.Context, templateID int64) ([]models.TemplateContent, error) {
+	content, err := s.repo.GetProductsByTemplateID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		if _, err := s.repo.GetTemplateByID(ctx, templateID); err != nil {
+			return nil, err
+		}
+	}
+	return content, nil
+}
+
+// addPickQuantity прибавляет к total количество quantity, умноженное на multiplier.
+// Возвращает false, если результат не помещается в int32, как и количества строк шаблона.
+func addPickQuantity(total, quantity, multiplier int) (int, bool) {
+	if quantity > math.MaxInt32/multiplier {
+		return 0, false
+	}
+	added := quantity * multiplier
+	if total > math.MaxInt32-added {
+		return 0, false
+	}
+	return total + added, true
+}
+
+// validatePickList проверяет, что задан хотя бы один шаблон, числа комплектов положительны и не превышают
+// MaxPickListMultiplier, а порядок строк допустим.
+func validatePickList(entries []models.PickListEntry, query models.PickListQuery) error {
+	if len(entries) == 0 {
+		return myerr.Validation("Pick list must include at least one template", nil)
+	}
+	for _, entry := range entries {
+		if entry.Multiplier <= 0 {
+			return myerr.Validation(fmt.Sprintf("Multiplier of template with ID %d must be positive", entry.TemplateID), nil)
+		}
+		if entry.Multiplier > MaxPickListMultiplier {
+			return myerr.Validation(fmt.Sprintf("Multiplier of template with ID %d must not exceed %d", entry.TemplateID, MaxPickListMultiplier), nil)
+		}
+	}
+	if query.SortBy != "" && !slices.Contains(models.PickListSorts, query.SortBy) {
+		return myerr.Validation(fmt.Sprintf("Invalid pick list sort %q, expected one of %v", query.SortBy, models.PickListSorts), nil)
+	}
+	return nil
+}
+
+// sortPickList сортирует строки по названию или артикулу; при совпадении порядок определяет ID продукта.
+func sortPickList(items []models.PickListItem, sortBy string) {
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i].Product, items[j].Product
+		if sortBy == models.PickListSortSKU && a.SKU != b.SKU {
+			return a.SKU < b.SKU
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+}
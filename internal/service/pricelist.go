@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// currencyPattern описывает код валюты ISO 4217 (например, RUB, BYN, KZT).
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ListPriceLists возвращает список всех прайс-листов.
+func (s *GoodsService) ListPriceLists(ctx context.Context) ([]models.PriceList, error) {
+	logger := log.With(s.log, "method", "ListPriceLists")
+	priceLists, err := s.repo.ListPriceLists(ctx)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	return priceLists, nil
+}
+
+// CreatePriceList создает новый прайс-лист.
+func (s *GoodsService) CreatePriceList(ctx context.Context, priceList *models.PriceList) (int64, error) {
+	logger := log.With(s.log, "method", "CreatePriceList")
+
+	priceList.Name = strings.TrimSpace(priceList.Name)
+	if priceList.Name == "" {
+		return 0, myerr.Validation("Price list name must not be empty", nil)
+	}
+	if !currencyPattern.MatchString(priceList.Currency) {
+		return 0, myerr.Validation(fmt.Sprintf("Invalid currency code %q", priceList.Currency), nil)
+	}
+
+	id, err := s.repo.CreatePriceList(ctx, priceList)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+// SetPriceListPrices задает цены продуктов в прайс-листе.
+func (s *GoodsService) SetPriceListPrices(ctx context.Context, priceListID int64, prices []models.ProductPrice) error {
+	logger := log.With(s.log, "method", "SetPriceListPrices")
+
+	for _, price := range prices {
+		if price.Price < 0 {
+			return myerr.Validation(fmt.Sprintf("Price of product with ID %d must not be negative", price.ProductID), nil)
+		}
+	}
+
+	if err := s.repo.SetPriceListPrices(ctx, priceListID, prices); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return err
+	}
+	return nil
+}
+
+// GetTemplateTotal рассчитывает стоимость шаблона по выбранному прайс-листу.
+func (s *GoodsService) GetTemplateTotal(ctx context.Context, templateID int64, priceList string) (models.TemplateTotal, error) {
+	logger := log.With(s.log, "method", "GetTemplateTotal")
+
+	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.TemplateTotal{}, err
+	}
+
+	productIDs := make([]int64, len(template.Content))
+	for i, content := range template.Content {
+		productIDs[i] = content.ProductID
+	}
+	products, err := s.repo.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.TemplateTotal{}, err
+	}
+	products, currency, err := s.applyPriceList(ctx, priceList, products)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.TemplateTotal{}, err
+	}
+
+	return templateTotal(template.ID, template.Content, productsByID(products), priceList, currency)
+}
+
+// expandTemplateProducts подставляет в строки шаблона продукты с ценами из выбранного прайс-листа и считает итоги шаблона.
+// Строки вместе с продуктами читаются одним запросом, поэтому число запросов не зависит от числа строк.
+func (s *GoodsService) expandTemplateProducts(ctx context.Context, template *models.Template, priceList string) error {
+	contents, err := s.repo.GetTemplateContentProducts(ctx, []int64{template.ID})
+	if err != nil {
+		return err
+	}
+	content := contents[template.ID]
+	products := make([]models.Product, len(content))
+	for i, c := range content {
+		products[i] = *c.Product
+	}
+	products, currency, err := s.applyPriceList(ctx, priceList, products)
+	if err != nil {
+		return err
+	}
+
+	byID := productsByID(products)
+	total, err := templateTotal(template.ID, content, byID, priceList, currency)
+	if err != nil {
+		return err
+	}
+	for i := range content {
+		product := byID[content[i].ProductID]
+		content[i].Product = &product
+	}
+	template.Content = content
+	template.Total = &total
+	return nil
+}
+
+// templateTotal считает число единиц товара и стоимость строк шаблона по ценам продуктов.
+// Продукт строки без цены в прайс-листе дает ошибку NotFound.
+func templateTotal(templateID int64, content []models.TemplateContent, byID map[int64]models.Product, priceList string, currency string) (models.TemplateTotal, error) {
+	// НДС выделяется из стоимости каждой строки, а не из общей суммы, как в кассовом чеке.
+	var sum models.PriceBreakdown
+	items := 0
+	for _, c := range content {
+		product, ok := byID[c.ProductID]
+		if !ok {
+			return models.TemplateTotal{}, myerr.NotFound(fmt.Sprintf("Product with ID %d has no price in price list %s", c.ProductID, priceList), nil)
+		}
+		sum = sum.Add(product.VATRate.Breakdown(product.Price.Mul(c.Quantity)))
+		items += c.Quantity
+	}
+	return models.TemplateTotal{
+		TemplateID: templateID,
+		PriceList:  priceList,
+		Currency:   currency,
+		Items:      items,
+		Net:        sum.Net,
+		VAT:        sum.VAT,
+		Total:      sum.Gross,
+	}, nil
+}
+
+// productsByID индексирует продукты по их ID.
+func productsByID(products []models.Product) map[int64]models.Product {
+	byID := make(map[int64]models.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	return byID
+}
+
+// applyPriceList подставляет в продукты цены и валюту выбранного прайс-листа и возвращает эту валюту.
+// Для пустого имени используется базовый прайс-лист. Продукты без цены в прайс-листе отбрасываются.
+func (s *GoodsService) applyPriceList(ctx context.Context, priceList string, products []models.Product) ([]models.Product, string, error) {
+	if priceList == "" {
+		for i := range products {
+			products[i].Currency = models.BaseCurrency
+		}
+		return products, models.BaseCurrency, nil
+	}
+
+	pl, err := s.repo.GetPriceListByName(ctx, priceList)
+	if err != nil {
+		return nil, "", err
+	}
+
+	productIDs := make([]int64, len(products))
+	for i, p := range products {
+		productIDs[i] = p.ID
+	}
+	prices, err := s.repo.GetPriceListPrices(ctx, pl.ID, productIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	priced := make([]models.Product, 0, len(products))
+	for _, p := range products {
+		price, ok := prices[p.ID]
+		if !ok {
+			continue
+		}
+		p.Price = price
+		p.Currency = pl.Currency
+		priced = append(priced, p)
+	}
+	return priced, pl.Currency, nil
+}
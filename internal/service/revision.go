@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// ListTemplateRevisions возвращает ревизии шаблона от новых к старым без их состава.
+// Ревизии удаленного шаблона остаются доступными; если ревизий нет, проверяется, что шаблон существует.
+func (s *GoodsService) ListTemplateRevisions(ctx context.Context, templateID int64) ([]models.TemplateRevision, error) {
+	logger := log.With(s.log, "method", "ListTemplateRevisions")
+	revisions, err := s.repo.ListTemplateRevisions(ctx, templateID)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		// Шаблоны, созданные до появления истории, еще не имеют ревизий
+		if _, err := s.repo.GetTemplateByID(ctx, templateID); err != nil {
+			_ = level.Error(logger).Log("err", err)
+			return nil, err
+		}
+	}
+	return revisions, nil
+}
+
+// GetTemplateRevision возвращает ревизию шаблона вместе с составом, который она зафиксировала.
+func (s *GoodsService) GetTemplateRevision(ctx context.Context, templateID int64, revision int64) (models.TemplateRevision, error) {
+	logger := log.With(s.log, "method", "GetTemplateRevision")
+	rev, err := s.repo.GetTemplateRevision(ctx, templateID, revision)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.TemplateRevision{}, err
+	}
+	return rev, nil
+}
+
+// RestoreTemplateRevision делает ревизию текущим состоянием шаблона и возвращает новую версию шаблона.
+// Восстановление — обычное изменение шаблона, поэтому оно создает новую ревизию, а история не переписывается.
+// Снимок проверяется по правилам шаблона целиком, но продукты ревизии считаются уже входящими в шаблон:
+// снятые с продажи продукты остаются в нем, как и при изменении строк, а продукты, у которых с тех пор
+// появились варианты, повторяющиеся строки и неверные количества отклоняются. Восстановление применяется, только если версия шаблона совпадает с ожидаемой (0 отключает проверку).
+func (s *GoodsService) RestoreTemplateRevision(ctx context.Context, templateID int64, revision int64, version int64) (int64, error) {
+	logger := log.With(s.log, "method", "RestoreTemplateRevision")
+	rev, err := s.repo.GetTemplateRevision(ctx, templateID, revision)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	template := rev.Template
+	template.ID = templateID
+	template.Version = version
+	if err := s.prepareTemplate(ctx, &template, contentProductIDs(template.Content)); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	if err := s.repo.UpdateTemplate(ctx, &template); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	return template.Version, nil
+}
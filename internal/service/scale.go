@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// ScaleTemplate возвращает шаблон, локализованный на предпочитаемый язык, с количествами, пересчитанными
+// на другое число пассажиров (относительно базового числа шаблона) или по множителю.
+// Количества округляются вверх: до целых базовых единиц или до целых упаковок, отмеченных у продукта RoundScaled.
+// Сам шаблон не изменяется.
+func (s *GoodsService) ScaleTemplate(ctx context.Context, id int64, scale models.TemplateScale, query models.TemplateQuery) (models.Template, error) {
+	logger := log.With(s.log, "method", "ScaleTemplate")
+	template, err := s.repo.GetTemplateByID(ctx, id)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.Template{}, err
+	}
+	factor, err := scaleFactor(template, scale)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.Template{}, err
+	}
+	productIDs := make([]int64, len(template.Content))
+	for i, line := range template.Content {
+		productIDs[i] = line.ProductID
+	}
+	products, err := s.templateProducts(ctx, productIDs)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.Template{}, err
+	}
+	for i, line := range template.Content {
+		// Продукт без упаковок (или не найденный) округляется до целых базовых единиц
+		quantity, err := products[line.ProductID].ScaledQuantity(line.Quantity, factor)
+		if err != nil {
+			_ = level.Error(logger).Log("err", err)
+			return models.Template{}, err
+		}
+		template.Content[i].Quantity = quantity
+	}
+	if scale.Passengers > 0 {
+		template.Passengers = scale.Passengers
+	} else {
+		template.Passengers = int(math.Round(float64(template.Passengers) * factor))
+	}
+	return template.Localize(query.Locales), nil
+}
+
+// scaleFactor вычисляет множитель количеств. Задать нужно ровно одно: число пассажиров или множитель;
+// масштабировать по числу пассажиров можно только шаблон с базовым числом пассажиров.
+func scaleFactor(template models.Template, scale models.TemplateScale) (float64, error) {
+	switch {
+	case scale.Passengers < 0:
+		return 0, myerr.Validation("Passenger count must be positive", nil)
+	case scale.Factor < 0 || math.IsNaN(scale.Factor) || math.IsInf(scale.Factor, 0):
+		return 0, myerr.Validation("Scale factor must be a positive number", nil)
+	case scale.Passengers > 0 && scale.Factor > 0:
+		return 0, myerr.Validation("Specify either passengers or factor, not both", nil)
+	case scale.Passengers > 0:
+		if template.Passengers == 0 {
+			return 0, myerr.Validation(fmt.Sprintf("Template with ID %d has no base passenger count, scale it by factor instead", template.ID), nil)
+		}
+		return float64(scale.Passengers) / float64(template.Passengers), nil
+	case scale.Factor > 0:
+		return scale.Factor, nil
+	default:
+		return 0, myerr.Validation("Specify passengers or factor to scale the template", nil)
+	}
+}
@@ -2,95 +2,221 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 )
 
 // Service описывает сервис для работы с продуктами и шаблонами.
 type Service interface {
-	// GetAllProducts возвращает список всех продуктов.
-	GetAllProducts(ctx context.Context) ([]models.Product, error)
-	// GetProductByID возвращает продукт по его ID.
-	GetProductByID(ctx context.Context, id int64) (models.Product, error)
-	// SearchTemplates ищет шаблоны продуктов по их имени или ID с пагинацией.
-	SearchTemplates(ctx context.Context, searchString string, limit int64, offset int64) ([]models.Template, error)
+	// GetAllProducts возвращает список всех продуктов с ценами из выбранного прайс-листа без продуктов с исключенными аллергенами.
+	GetAllProducts(ctx context.Context, query models.ProductQuery) ([]models.Product, error)
+	// GetProductByID возвращает продукт по его ID с ценой из выбранного прайс-листа.
+	GetProductByID(ctx context.Context, id int64, query models.ProductQuery) (models.Product, error)
+	// SearchTemplates ищет шаблоны продуктов по их имени или ID с пагинацией, по запросу вместе с составом.
+	SearchTemplates(ctx context.Context, searchString string, limit int64, offset int64, query models.TemplateQuery) ([]models.Template, error)
 	// AddTemplate добавляет новый шаблон продуктов в базу данных.
 	AddTemplate(ctx context.Context, template *models.Template) (int64, error)
-	// GetTemplateByID возвращает шаблон продуктов по его ID.
-	GetTemplateByID(ctx context.Context, id int64) (models.Template, error)
-	// CreateProduct добавляет новый продукт в базу данных.
+	// ListTemplates возвращает список всех шаблонов без их состава.
+	ListTemplates(ctx context.Context, query models.TemplateQuery) ([]models.Template, error)
+	// DeleteTemplate удаляет шаблон вместе с его составом, если его версия совпадает с ожидаемой (0 отключает проверку),
+	// и возвращает удаленный шаблон; при dryRun ничего не удаляется.
+	DeleteTemplate(ctx context.Context, id int64, version int64, dryRun bool) (models.Template, error)
+	// UpdateTemplate заменяет название, описание и состав шаблона, если его версия совпадает с ожидаемой (0 отключает проверку).
+	UpdateTemplate(ctx context.Context, template *models.Template) error
+	// ScaleTemplate возвращает шаблон с количествами, пересчитанными на другое число пассажиров или по множителю.
+	ScaleTemplate(ctx context.Context, id int64, scale models.TemplateScale, query models.TemplateQuery) (models.Template, error)
+	// DiffTemplates сравнивает состав двух шаблонов.
+	DiffTemplates(ctx context.Context, a int64, b int64, query models.TemplateQuery) (models.TemplateDiff, error)
+	// BuildPickList сводит несколько шаблонов с числом комплектов в один список продуктов с общими количествами.
+	BuildPickList(ctx context.Context, entries []models.PickListEntry, query models.PickListQuery) ([]models.PickListItem, error)
+	// CloneTemplate копирует описание и состав шаблона в новый шаблон с указанным названием.
+	CloneTemplate(ctx context.Context, id int64, name string) (int64, error)
+	// ExportTemplates возвращает выбранные или все шаблоны в переносимом виде с артикулами продуктов вместо ID.
+	ExportTemplates(ctx context.Context, ids []int64) ([]models.PortableTemplate, error)
+	// ImportTemplates атомарно создает или заменяет шаблоны из переносимого вида, сопоставляя их по названию.
+	ImportTemplates(ctx context.Context, templates []models.PortableTemplate) ([]models.TemplateImportResult, error)
+	// AddTemplateLine добавляет в шаблон строку с продуктом, если версия шаблона совпадает с ожидаемой
+	// (0 отключает проверку), и возвращает новую версию шаблона.
+	AddTemplateLine(ctx context.Context, templateID int64, line models.TemplateContent, version int64) (int64, error)
+	// UpdateTemplateLine меняет количество продукта в строке шаблона, если версия шаблона совпадает с ожидаемой
+	// (0 отключает проверку), и возвращает новую версию шаблона.
+	UpdateTemplateLine(ctx context.Context, templateID int64, line models.TemplateContent, version int64) (int64, error)
+	// RemoveTemplateLine удаляет строку с продуктом из шаблона, если версия шаблона совпадает с ожидаемой
+	// (0 отключает проверку), и возвращает новую версию шаблона.
+	RemoveTemplateLine(ctx context.Context, templateID int64, productID int64, version int64) (int64, error)
+	// ListTemplateRevisions возвращает историю изменений шаблона от новых ревизий к старым.
+	ListTemplateRevisions(ctx context.Context, templateID int64) ([]models.TemplateRevision, error)
+	// GetTemplateRevision возвращает одну ревизию шаблона вместе с составом.
+	GetTemplateRevision(ctx context.Context, templateID int64, revision int64) (models.TemplateRevision, error)
+	// RestoreTemplateRevision делает ревизию текущим состоянием шаблона, если его версия совпадает с ожидаемой (0 отключает проверку).
+	RestoreTemplateRevision(ctx context.Context, templateID int64, revision int64, version int64) (int64, error)
+	// GetTemplateByID возвращает шаблон продуктов по его ID, по запросу с раскрытыми продуктами и итогами.
+	GetTemplateByID(ctx context.Context, id int64, query models.TemplateQuery) (models.Template, error)
+	// CreateProduct добавляет новый продукт в базу данных; по умолчанию продукт создается черновиком.
 	CreateProduct(ctx context.Context, p *models.Product) (int64, error)
-	// UpdateProduct обновляет информацию о продукте в базе данных.
+	// UpdateProduct обновляет информацию о продукте в базе данных, если его версия совпадает с ожидаемой (0 отключает проверку).
 	UpdateProduct(ctx context.Context, p *models.Product) error
-	// DeleteProduct удаляет продукт из базы данных.
-	DeleteProduct(ctx context.Context, id int64) error
+	// DeleteProduct удаляет продукт из базы данных, если его версия совпадает с ожидаемой (0 отключает проверку).
+	DeleteProduct(ctx context.Context, id int64, version int64) error
+	// GetTemplateTotal рассчитывает стоимость шаблона по выбранному прайс-листу.
+	GetTemplateTotal(ctx context.Context, templateID int64, priceList string) (models.TemplateTotal, error)
+	// ListPriceLists возвращает список всех прайс-листов.
+	ListPriceLists(ctx context.Context) ([]models.PriceList, error)
+	// CreatePriceList создает новый прайс-лист.
+	CreatePriceList(ctx context.Context, priceList *models.PriceList) (int64, error)
+	// SetPriceListPrices задает цены продуктов в прайс-листе.
+	SetPriceListPrices(ctx context.Context, priceListID int64, prices []models.ProductPrice) error
+	// UploadProductImage сохраняет изображение продукта и его миниатюру и проставляет продукту ссылки на них.
+	UploadProductImage(ctx context.Context, productID int64, upload models.Blob) (models.ProductImage, error)
+	// GetImage возвращает сохраненное изображение по его ключу.
+	GetImage(ctx context.Context, key string) (models.Blob, error)
+	// GetProductByBarcode возвращает продукт по отсканированному штрихкоду с ценой из выбранного прайс-листа.
+	GetProductByBarcode(ctx context.Context, code string, query models.ProductQuery) (models.Product, error)
+	// GetAvailableProducts возвращает продукты, которые можно продавать на маршруте в указанный момент.
+	GetAvailableProducts(ctx context.Context, sale models.SaleContext, query models.ProductQuery) ([]models.Product, error)
 }
 
 // GoodsService реализует интерфейс Service.
 type GoodsService struct {
-	repo models.GoodsRepository
-	log  log.Logger
+	repo   models.GoodsRepository
+	images models.BlobStore
+	log    log.Logger
 }
 
 // NewService создает новый экземпляр Service.
-func NewService(repo models.GoodsRepository, logger log.Logger) Service {
+func NewService(repo models.GoodsRepository, images models.BlobStore, logger log.Logger) Service {
 	return &GoodsService{
-		repo: repo,
-		log:  logger,
+		repo:   repo,
+		images: images,
+		log:    logger,
 	}
 }
 
-// GetAllProducts возвращает список всех продуктов.
-func (s *GoodsService) GetAllProducts(ctx context.Context) ([]models.Product, error) {
+// GetAllProducts возвращает список всех продуктов с ценами из выбранного прайс-листа.
+// Варианты возвращаются вложенными в родительские продукты.
+// Черновики, продукты, у которых нет цены в выбранном прайс-листе, и продукты с исключенными аллергенами в результат не попадают.
+func (s *GoodsService) GetAllProducts(ctx context.Context, query models.ProductQuery) ([]models.Product, error) {
 	logger := log.With(s.log, "method", "GetAllProducts")
-	products, err := s.repo.GetAllProducts(ctx)
+	products, err := s.publishedProducts(ctx, query)
 	if err != nil {
 		_ = level.Error(logger).Log("err", err)
 		return nil, err
 	}
-	return products, nil
+	return localizeProducts(nestVariants(products), query.Locales), nil
+}
+
+// publishedProducts возвращает плоский список опубликованных продуктов с ценами из выбранного прайс-листа
+// без продуктов с исключенными аллергенами.
+func (s *GoodsService) publishedProducts(ctx context.Context, query models.ProductQuery) ([]models.Product, error) {
+	if err := validateAllergens(query.ExcludeAllergens); err != nil {
+		return nil, err
+	}
+	products, err := s.repo.GetAllProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	products, _, err = s.applyPriceList(ctx, query.PriceList, products)
+	if err != nil {
+		return nil, err
+	}
+	return excludeAllergens(excludeDrafts(products), query.ExcludeAllergens), nil
 }
 
-// GetProductByID возвращает продукт по его ID.
-func (s *GoodsService) GetProductByID(ctx context.Context, id int64) (models.Product, error) {
+// GetProductByID возвращает продукт по его ID с ценой из выбранного прайс-листа.
+// Для родительского продукта возвращаются и его варианты.
+func (s *GoodsService) GetProductByID(ctx context.Context, id int64, query models.ProductQuery) (models.Product, error) {
 	logger := log.With(s.log, "method", "GetProductByID")
 	product, err := s.repo.GetProductByID(ctx, id)
 	if err != nil {
 		_ = level.Error(logger).Log("err", err)
 		return models.Product{}, err
 	}
-	return product, nil
+	products := []models.Product{product}
+	if !product.IsVariant() {
+		variants, err := s.repo.GetProductVariants(ctx, []int64{id})
+		if err != nil {
+			_ = level.Error(logger).Log("err", err)
+			return models.Product{}, err
+		}
+		products = append(products, variants...)
+	}
+
+	priced, _, err := s.applyPriceList(ctx, query.PriceList, products)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.Product{}, err
+	}
+	for _, p := range nestVariants(priced) {
+		if p.ID == id {
+			return p.Localize(query.Locales), nil
+		}
+	}
+	return models.Product{}, myerr.NotFound(fmt.Sprintf("Product with ID %d has no price in price list %s", id, query.PriceList), nil)
 }
 
 // SearchTemplates ищет шаблоны продуктов по их имени или ID с пагинацией.
-func (s *GoodsService) SearchTemplates(ctx context.Context, searchString string, limit int64, offset int64) ([]models.Template, error) {
+// Поиск идет и по переводам, а результаты локализуются на предпочитаемый язык.
+// Состав шаблонов загружается только по запросу, одним запросом на всю страницу.
+func (s *GoodsService) SearchTemplates(ctx context.Context, searchString string, limit int64, offset int64, query models.TemplateQuery) ([]models.Template, error) {
 	logger := log.With(s.log, "method", "SearchTemplates")
 
+	var (
+		templates []models.Template
+		err       error
+	)
 	if searchString == "" {
 		// Пустая строка поиска, возвращаем все шаблоны с пагинацией
-		templates, err := s.repo.GetAllTemplates(ctx, limit, offset)
-		if err != nil {
+		templates, err = s.repo.GetAllTemplates(ctx, limit, offset)
+	} else {
+		// Поиск шаблонов по строке
+		templates, err = s.repo.SearchTemplates(ctx, searchString, limit, offset)
+	}
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	if query.WithContent {
+		if err := s.attachTemplateContents(ctx, templates); err != nil {
 			_ = level.Error(logger).Log("err", err)
 			return nil, err
 		}
-		return templates, nil
 	}
+	return localizeTemplates(templates, query.Locales), nil
+}
 
-	// Поиск шаблонов по строке
-	templates, err := s.repo.SearchTemplates(ctx, searchString, limit, offset)
+// attachTemplateContents загружает состав всех шаблонов страницы одним запросом и подставляет его в шаблоны.
+func (s *GoodsService) attachTemplateContents(ctx context.Context, templates []models.Template) error {
+	if len(templates) == 0 {
+		return nil
+	}
+	templateIDs := make([]int64, len(templates))
+	for i, t := range templates {
+		templateIDs[i] = t.ID
+	}
+	contents, err := s.repo.GetTemplateContents(ctx, templateIDs)
 	if err != nil {
-		_ = level.Error(logger).Log("err", err)
-		return nil, err
+		return err
 	}
-
-	return templates, nil
+	for i := range templates {
+		templates[i].Content = contents[templates[i].ID]
+	}
+	return nil
 }
 
 // AddTemplate добавляет новый шаблон продуктов в базу данных.
+// В шаблон можно добавить только продукты в продаже; количества, заданные в упаковках, сохраняются в базовых единицах продуктов.
 func (s *GoodsService) AddTemplate(ctx context.Context, template *models.Template) (int64, error) {
 	logger := log.With(s.log, "method", "AddTemplate")
+	if err := s.prepareTemplate(ctx, template, nil); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
 	err := s.repo.CreateTemplate(ctx, template)
 	if err != nil {
 		_ = level.Error(logger).Log("err", err)
@@ -99,20 +225,159 @@ func (s *GoodsService) AddTemplate(ctx context.Context, template *models.Templat
 	return template.ID, nil
 }
 
-// GetTemplateByID возвращает шаблон продуктов по его ID.
-func (s *GoodsService) GetTemplateByID(ctx context.Context, id int64) (models.Template, error) {
+// UpdateTemplate заменяет название, описание, переводы и состав шаблона по тем же правилам, что и AddTemplate,
+// но продукты, снятые с продажи после добавления в шаблон, можно оставить в нем, как и при изменении строк.
+// Если шаблон успели изменить после чтения клиентом, возвращается ошибка PreconditionFailed, а template.Version получает новую версию при успехе.
+// Запись всегда проверяет версию сохраненного шаблона, по составу которого решается, какие продукты можно оставить,
+// даже при If-Match: *: иначе строка, добавленная параллельно, проверялась бы по устаревшему составу.
+func (s *GoodsService) UpdateTemplate(ctx context.Context, template *models.Template) error {
+	logger := log.With(s.log, "method", "UpdateTemplate")
+	stored, err := s.repo.GetTemplateByID(ctx, template.ID)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return err
+	}
+	if template.Version != 0 && template.Version != stored.Version {
+		err := myerr.PreconditionFailed(fmt.Sprintf("Template with ID %d was modified concurrently: current version is %d, expected %d",
+			template.ID, stored.Version, template.Version), nil)
+		_ = level.Error(logger).Log("err", err)
+		return err
+	}
+	template.Version = stored.Version
+	if err := s.prepareTemplate(ctx, template, contentProductIDs(stored.Content)); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return err
+	}
+	if err := s.repo.UpdateTemplate(ctx, template); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return err
+	}
+	return nil
+}
+
+// CloneTemplate копирует описание и состав шаблона в новый шаблон с указанным названием и возвращает его ID.
+// Переводы не копируются, так как содержат название исходного шаблона.
+// Если шаблон с таким названием уже существует, возвращается ошибка Conflict.
+func (s *GoodsService) CloneTemplate(ctx context.Context, id int64, name string) (int64, error) {
+	logger := log.With(s.log, "method", "CloneTemplate")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		err := myerr.Validation("Template name must not be empty", nil)
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	cloneID, err := s.repo.CloneTemplate(ctx, id, name)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	return cloneID, nil
+}
+
+// prepareTemplate проверяет переводы, название и состав шаблона и приводит количества к базовым единицам продуктов.
+// stored содержит ID продуктов сохраненного шаблона, которые можно оставить, даже если они сняты с продажи.
+// Все проблемы возвращаются одной ошибкой Validation, см. validateTemplate.
+func (s *GoodsService) prepareTemplate(ctx context.Context, template *models.Template, stored map[int64]bool) error {
+	productIDs := make([]int64, len(template.Content))
+	for i, content := range template.Content {
+		productIDs[i] = content.ProductID
+	}
+	parents, err := s.variantParents(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	products, err := s.templateProducts(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	if err := validateTemplate(template, products, parents, stored); err != nil {
+		return err
+	}
+	return normalizeTemplateQuantities(template.Content, products)
+}
+
+// GetTemplateByID возвращает шаблон продуктов по его ID, локализованный на предпочитаемый язык.
+// При раскрытии продуктов строки содержат продукты с ценами из выбранного прайс-листа, а шаблон — итоги.
+func (s *GoodsService) GetTemplateByID(ctx context.Context, id int64, query models.TemplateQuery) (models.Template, error) {
 	logger := log.With(s.log, "method", "GetTemplateByID")
 	template, err := s.repo.GetTemplateByID(ctx, id)
 	if err != nil {
 		_ = level.Error(logger).Log("err", err)
 		return models.Template{}, err
 	}
+	if query.ExpandProducts {
+		if err := s.expandTemplateProducts(ctx, &template, query.PriceList); err != nil {
+			_ = level.Error(logger).Log("err", err)
+			return models.Template{}, err
+		}
+	}
+	return template.Localize(query.Locales), nil
+}
+
+// ListTemplates возвращает список всех шаблонов без их состава, локализованный на предпочитаемый язык.
+func (s *GoodsService) ListTemplates(ctx context.Context, query models.TemplateQuery) ([]models.Template, error) {
+	logger := log.With(s.log, "method", "ListTemplates")
+	templates, err := s.repo.ListTemplates(ctx)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	return localizeTemplates(templates, query.Locales), nil
+}
+
+// DeleteTemplate удаляет шаблон вместе с его составом и возвращает удаленный шаблон.
+// Шаблон удаляется, только если его версия совпадает с ожидаемой (0 отключает проверку), иначе возвращается ошибка PreconditionFailed.
+// При dryRun шаблон только загружается, чтобы клиент увидел, что будет удалено; версия проверяется так же.
+func (s *GoodsService) DeleteTemplate(ctx context.Context, id int64, version int64, dryRun bool) (models.Template, error) {
+	logger := log.With(s.log, "method", "DeleteTemplate")
+	template, err := s.repo.GetTemplateByID(ctx, id)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.Template{}, err
+	}
+	if dryRun {
+		if version != 0 && template.Version != version {
+			err := myerr.PreconditionFailed(fmt.Sprintf("Template with ID %d was modified concurrently: current version is %d, expected %d", id, template.Version, version), nil)
+			_ = level.Error(logger).Log("err", err)
+			return models.Template{}, err
+		}
+		return template, nil
+	}
+	if err := s.repo.DeleteTemplate(ctx, id, version); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return models.Template{}, err
+	}
 	return template, nil
 }
 
 // CreateProduct добавляет новый продукт в базу данных.
 func (s *GoodsService) CreateProduct(ctx context.Context, p *models.Product) (int64, error) {
 	logger := log.With(s.log, "method", "CreateProduct")
+	if err := validateVATRate(p.VATRate); err != nil {
+		return 0, err
+	}
+	if err := validateBarcodes(p.Barcodes); err != nil {
+		return 0, err
+	}
+	if err := validateUnits(p); err != nil {
+		return 0, err
+	}
+	if err := validateNutrition(p); err != nil {
+		return 0, err
+	}
+	if err := validateTranslations(p.Translations); err != nil {
+		return 0, err
+	}
+	if err := validateStatus(p); err != nil {
+		return 0, err
+	}
+	if err := validateAvailability(p.Availability); err != nil {
+		return 0, err
+	}
+	if err := s.validateVariant(ctx, p); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
 	productID, err := s.repo.CreateProduct(ctx, p)
 	if err != nil {
 		_ = level.Error(logger).Log("err", err)
@@ -122,8 +387,35 @@ func (s *GoodsService) CreateProduct(ctx context.Context, p *models.Product) (in
 }
 
 // UpdateProduct обновляет информацию о продукте в базе данных.
+// Если продукт успели изменить после чтения клиентом, возвращается ошибка PreconditionFailed, а p.Version получает новую версию при успехе.
 func (s *GoodsService) UpdateProduct(ctx context.Context, p *models.Product) error {
 	logger := log.With(s.log, "method", "UpdateProduct")
+	if err := validateVATRate(p.VATRate); err != nil {
+		return err
+	}
+	if err := validateBarcodes(p.Barcodes); err != nil {
+		return err
+	}
+	if err := validateUnits(p); err != nil {
+		return err
+	}
+	if err := validateNutrition(p); err != nil {
+		return err
+	}
+	if err := validateTranslations(p.Translations); err != nil {
+		return err
+	}
+	if err := validateAvailability(p.Availability); err != nil {
+		return err
+	}
+	if err := s.validateStatusTransition(ctx, p); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return err
+	}
+	if err := s.validateVariant(ctx, p); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return err
+	}
 	err := s.repo.UpdateProduct(ctx, p)
 	if err != nil {
 		_ = level.Error(logger).Log("err", err)
@@ -133,12 +425,22 @@ func (s *GoodsService) UpdateProduct(ctx context.Context, p *models.Product) err
 }
 
 // DeleteProduct удаляет продукт из базы данных.
-func (s *GoodsService) DeleteProduct(ctx context.Context, id int64) error {
+// Если продукт успели изменить после чтения клиентом, возвращается ошибка PreconditionFailed.
+func (s *GoodsService) DeleteProduct(ctx context.Context, id int64, version int64) error {
 	logger := log.With(s.log, "method", "DeleteProduct")
-	err := s.repo.DeleteProduct(ctx, id)
+	err := s.repo.DeleteProduct(ctx, id, version)
 	if err != nil {
 		_ = level.Error(logger).Log("err", err)
 		return err
 	}
 	return nil
 }
+
+// validateVATRate проверяет, что ставка НДС задана и входит в перечень допустимых.
+// Фискальные регистраторы не принимают продажи товаров без ставки НДС.
+func validateVATRate(rate models.VATRate) error {
+	if !rate.Valid() {
+		return myerr.Validation(fmt.Sprintf("Invalid VAT rate %q, expected one of %v", rate, models.VATRates), nil)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// validateStatus проверяет статус нового продукта. Пустой статус заменяется на черновик,
+// чтобы продукт не попадал в публичные списки, пока его явно не опубликуют.
+func validateStatus(p *models.Product) error {
+	if p.Status == "" {
+		p.Status = models.ProductStatusDraft
+	}
+	if !p.Status.Valid() {
+		return myerr.Validation(fmt.Sprintf("Invalid product status %q, expected one of %v", p.Status, models.ProductStatuses), nil)
+	}
+	return nil
+}
+
+// validateStatusTransition проверяет, что изменение статуса продукта разрешено. Пустой статус сохраняет текущий.
+func (s *GoodsService) validateStatusTransition(ctx context.Context, p *models.Product) error {
+	if p.Status != "" && !p.Status.Valid() {
+		return myerr.Validation(fmt.Sprintf("Invalid product status %q, expected one of %v", p.Status, models.ProductStatuses), nil)
+	}
+	current, err := s.repo.GetProductByID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if p.Status == "" {
+		p.Status = current.Status
+		return nil
+	}
+	if !current.Status.CanTransitionTo(p.Status) {
+		return myerr.Conflict(fmt.Sprintf("Product with ID %d cannot change status from %s to %s", p.ID, current.Status, p.Status), nil)
+	}
+	return nil
+}
+
+// excludeDrafts убирает из публичного списка черновики и варианты родительских продуктов-черновиков.
+func excludeDrafts(products []models.Product) []models.Product {
+	drafts := make(map[int64]bool)
+	for _, p := range products {
+		if p.IsDraft() {
+			drafts[p.ID] = true
+		}
+	}
+	if len(drafts) == 0 {
+		return products
+	}
+	filtered := make([]models.Product, 0, len(products)-len(drafts))
+	for _, p := range products {
+		if !drafts[p.ID] && !drafts[p.ParentID] {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// AddTemplateLine добавляет в шаблон строку с продуктом по тем же правилам, что и AddTemplate, и возвращает новую версию шаблона.
+// Если продукт уже есть в шаблоне, возвращается ошибка Conflict.
+// Строка добавляется, только если версия шаблона совпадает с ожидаемой (0 отключает проверку), иначе возвращается ошибка PreconditionFailed.
+func (s *GoodsService) AddTemplateLine(ctx context.Context, templateID int64, line models.TemplateContent, version int64) (int64, error) {
+	logger := log.With(s.log, "method", "AddTemplateLine")
+	content := []models.TemplateContent{line}
+	if err := s.prepareTemplateLines(ctx, content, true); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	updated, err := s.repo.AddTemplateContent(ctx, templateID, content[0], version)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	return updated, nil
+}
+
+// UpdateTemplateLine меняет количество продукта в строке шаблона и возвращает новую версию шаблона.
+// Статус продукта не проверяется: уже добавленный продукт может оставаться в шаблоне после снятия с продажи.
+// Строка меняется, только если версия шаблона совпадает с ожидаемой (0 отключает проверку), иначе возвращается ошибка PreconditionFailed.
+func (s *GoodsService) UpdateTemplateLine(ctx context.Context, templateID int64, line models.TemplateContent, version int64) (int64, error) {
+	logger := log.With(s.log, "method", "UpdateTemplateLine")
+	content := []models.TemplateContent{line}
+	if err := s.prepareTemplateLines(ctx, content, false); err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	updated, err := s.repo.UpdateTemplateContent(ctx, templateID, content[0], version)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	return updated, nil
+}
+
+// RemoveTemplateLine удаляет строку с продуктом из шаблона и возвращает новую версию шаблона.
+// Строка удаляется, только если версия шаблона совпадает с ожидаемой (0 отключает проверку), иначе возвращается ошибка PreconditionFailed.
+func (s *GoodsService) RemoveTemplateLine(ctx context.Context, templateID int64, productID int64, version int64) (int64, error) {
+	logger := log.With(s.log, "method", "RemoveTemplateLine")
+	updated, err := s.repo.DeleteTemplateContent(ctx, templateID, productID, version)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return 0, err
+	}
+	return updated, nil
+}
+
+// prepareTemplateLines проверяет строки по правилам шаблона целиком и приводит их количества к базовым единицам продуктов.
+// Для новых строк дополнительно проверяется, что продукты конкретные и находятся в продаже; измененные строки
+// уже входят в шаблон, поэтому их продукты могут быть сняты с продажи. Все проблемы возвращаются одной ошибкой Validation.
+func (s *GoodsService) prepareTemplateLines(ctx context.Context, content []models.TemplateContent, added bool) error {
+	productIDs := make([]int64, len(content))
+	for i, line := range content {
+		productIDs[i] = line.ProductID
+	}
+	var parents, stored map[int64]bool
+	if added {
+		var err error
+		if parents, err = s.variantParents(ctx, productIDs); err != nil {
+			return err
+		}
+	} else {
+		stored = contentProductIDs(content)
+	}
+	products, err := s.templateProducts(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	if fields := validateTemplateLines(content, products, parents, stored, requestLineError); len(fields) > 0 {
+		return myerr.ValidationFields(fmt.Sprintf("Template line has %d problem(s)", len(fields)), fields)
+	}
+	return normalizeTemplateQuantities(content, products)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// validateTemplate проверяет шаблон целиком до записи: непустое название, неотрицательное число пассажиров, переводы,
+// положительные количества, известные упаковки, отсутствие повторяющихся продуктов и продуктов с вариантами
+// и наличие в продаже всех продуктов, кроме уже входящих в сохраненный шаблон: снятый с продажи продукт можно оставить,
+// но нельзя добавить. parents содержит ID продуктов, у которых есть варианты, stored — ID продуктов сохраненного шаблона.
+// Все найденные проблемы возвращаются одной ошибкой Validation; поля строк имеют вид content[i].quantity.
+func validateTemplate(template *models.Template, products map[int64]models.Product, parents, stored map[int64]bool) error {
+	var fields []myerr.FieldError
+	if strings.TrimSpace(template.TemplateName) == "" {
+		fields = append(fields, myerr.FieldError{Field: "templateName", Message: "Template name must not be empty"})
+	}
+	if template.Passengers < 0 {
+		fields = append(fields, myerr.FieldError{Field: "passengers", Message: "Base passenger count must not be negative"})
+	}
+	locales := make([]string, 0, len(template.Translations))
+	for locale := range template.Translations {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	for _, locale := range locales {
+		if problem := translationProblem(locale, template.Translations[locale]); problem != "" {
+			fields = append(fields, myerr.FieldError{Field: "translations." + locale, Message: problem})
+		}
+	}
+	fields = append(fields, validateTemplateLines(template.Content, products, parents, stored, contentLineError)...)
+	if len(fields) > 0 {
+		return myerr.ValidationFields(fmt.Sprintf("Template has %d problem(s)", len(fields)), fields)
+	}
+	return nil
+}
+
+// validateTemplateLines проверяет строки шаблона по правилам validateTemplate и возвращает найденные проблемы.
+// problem строит ошибку поля строки, чтобы шаблон целиком и отдельная строка сообщали о проблемах в своих путях.
+func validateTemplateLines(content []models.TemplateContent, products map[int64]models.Product, parents, stored map[int64]bool,
+	problem func(line int, field, message string) myerr.FieldError) []myerr.FieldError {
+	var fields []myerr.FieldError
+	lines := make(map[int64]int, len(content))
+	for i, line := range content {
+		lineError := func(field, message string) {
+			fields = append(fields, problem(i, field, message))
+		}
+		if line.Quantity <= 0 {
+			lineError("quantity", fmt.Sprintf("Quantity of product with ID %d must be positive", line.ProductID))
+		}
+		if first, ok := lines[line.ProductID]; ok {
+			lineError("productID", fmt.Sprintf("Product with ID %d is already in line %d", line.ProductID, first))
+			continue
+		}
+		lines[line.ProductID] = i
+		product, ok := products[line.ProductID]
+		switch {
+		case !ok:
+			lineError("productID", fmt.Sprintf("Product with ID %d not found", line.ProductID))
+			continue
+		case parents[line.ProductID]:
+			lineError("productID", fmt.Sprintf("Product with ID %d has variants, reference a concrete variant instead", line.ProductID))
+		case !product.IsActive() && !stored[line.ProductID]:
+			lineError("productID", fmt.Sprintf("Product with ID %d is %s and cannot be added to a template", product.ID, product.Status))
+		}
+		if line.Quantity > 0 {
+			if _, err := product.BaseQuantity(line.Quantity, line.Unit); err != nil {
+				lineError("unit", unitProblem(err))
+			}
+		}
+	}
+	return fields
+}
+
+// contentLineError возвращает ошибку поля строки шаблона с путем вида content[2].quantity.
+func contentLineError(line int, field, message string) myerr.FieldError {
+	return myerr.FieldError{Field: lineField(line, field), Index: &line, Message: message}
+}
+
+// requestLineError возвращает ошибку поля отдельной строки шаблона, путь которой совпадает с полем запроса.
+func requestLineError(_ int, field, message string) myerr.FieldError {
+	return myerr.FieldError{Field: field, Message: message}
+}
+
+// contentProductIDs возвращает множество ID продуктов в строках шаблона.
+func contentProductIDs(content []models.TemplateContent) map[int64]bool {
+	ids := make(map[int64]bool, len(content))
+	for _, line := range content {
+		ids[line.ProductID] = true
+	}
+	return ids
+}
+
+// lineField возвращает путь к полю строки шаблона, например content[2].quantity.
+func lineField(line int, field string) string {
+	return fmt.Sprintf("content[%d].%s", line, field)
+}
+
+// unitProblem возвращает текст ошибки перевода количества в базовые единицы.
+func unitProblem(err error) string {
+	if appErr, ok := myerr.IsAppError(err); ok {
+		return appErr.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// ExportTemplates возвращает шаблоны с указанными ID, а без ID — все шаблоны, в переносимом виде:
+// продукты указаны артикулами, количества — в базовых единицах, переводы не локализуются.
+func (s *GoodsService) ExportTemplates(ctx context.Context, ids []int64) ([]models.PortableTemplate, error) {
+	logger := log.With(s.log, "method", "ExportTemplates")
+	templates, err := s.exportedTemplates(ctx, ids)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	var productIDs []int64
+	for _, t := range templates {
+		for _, line := range t.Content {
+			productIDs = append(productIDs, line.ProductID)
+		}
+	}
+	products, err := s.templateProducts(ctx, productIDs)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	portable := make([]models.PortableTemplate, len(templates))
+	for i, t := range templates {
+		lines := make([]models.PortableTemplateLine, len(t.Content))
+		for j, line := range t.Content {
+			product, ok := products[line.ProductID]
+			if !ok {
+				err := myerr.Internal(fmt.Sprintf("Product with ID %d from template with ID %d not found", line.ProductID, t.ID), nil)
+				_ = level.Error(logger).Log("err", err)
+				return nil, err
+			}
+			lines[j] = models.PortableTemplateLine{SKU: product.SKU, Quantity: line.Quantity}
+		}
+		portable[i] = models.PortableTemplate{
+			TemplateName: t.TemplateName,
+			Description:  t.Description,
+			Translations: t.Translations,
+			Passengers:   t.Passengers,
+			Content:      lines,
+		}
+	}
+	return portable, nil
+}
+
+// exportedTemplates загружает выбранные шаблоны по одному, а все шаблоны — вместе с составом двумя запросами.
+func (s *GoodsService) exportedTemplates(ctx context.Context, ids []int64) ([]models.Template, error) {
+	if len(ids) > 0 {
+		templates := make([]models.Template, len(ids))
+		for i, id := range ids {
+			t, err := s.repo.GetTemplateByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			templates[i] = t
+		}
+		return templates, nil
+	}
+
+	templates, err := s.repo.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachTemplateContents(ctx, templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// ImportTemplates создает или заменяет шаблоны из переносимого вида: шаблон с уже существующим названием заменяется,
+// иначе создается новый. Артикулы продуктов разрешаются в ID целевой базы данных.
+// Шаблоны проверяются по тем же правилам, что и в UpdateTemplate: снятые с продажи продукты, уже входящие в заменяемый
+// шаблон, остаются в нем, поэтому экспорт можно импортировать обратно. Все ненайденные артикулы и ошибки проверки
+// возвращаются одной ошибкой Validation, и тогда не записывается ни один шаблон.
+func (s *GoodsService) ImportTemplates(ctx context.Context, portable []models.PortableTemplate) ([]models.TemplateImportResult, error) {
+	logger := log.With(s.log, "method", "ImportTemplates")
+	templates, err := s.resolveTemplates(ctx, portable)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	stored, err := s.storedTemplateContents(ctx, templates)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	var fields []myerr.FieldError
+	for i := range templates {
+		err := s.prepareTemplate(ctx, &templates[i], contentProductIDs(stored[templates[i].TemplateName]))
+		if err == nil {
+			continue
+		}
+		templateFields := myerr.Fields(err)
+		if len(templateFields) == 0 {
+			appErr, ok := myerr.IsAppError(err)
+			if !ok || appErr.Type != myerr.ErrorTypeValidation {
+				_ = level.Error(logger).Log("err", err)
+				return nil, err
+			}
+			templateFields = []myerr.FieldError{{Message: appErr.Message}}
+		}
+		for _, f := range templateFields {
+			f.Field = importField(i, f.Field)
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) > 0 {
+		err := myerr.ValidationFields(fmt.Sprintf("Import has %d problem(s)", len(fields)), fields)
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	results, err := s.repo.ImportTemplates(ctx, templates)
+	if err != nil {
+		_ = level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// storedTemplateContents возвращает состав уже существующих шаблонов с названиями импортируемых шаблонов по названию.
+func (s *GoodsService) storedTemplateContents(ctx context.Context, templates []models.Template) (map[string][]models.TemplateContent, error) {
+	existing, err := s.repo.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	imported := make(map[string]bool, len(templates))
+	for _, t := range templates {
+		imported[t.TemplateName] = true
+	}
+	names := make(map[int64]string)
+	var ids []int64
+	for _, t := range existing {
+		if imported[t.TemplateName] {
+			names[t.ID] = t.TemplateName
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	contents, err := s.repo.GetTemplateContents(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string][]models.TemplateContent, len(contents))
+	for id, content := range contents {
+		stored[names[id]] = content
+	}
+	return stored, nil
+}
+
+// resolveTemplates переводит переносимые шаблоны во внутренние, заменяя артикулы на ID продуктов.
+// Повторяющиеся названия шаблонов и ненайденные артикулы возвращаются одной ошибкой Validation.
+func (s *GoodsService) resolveTemplates(ctx context.Context, portable []models.PortableTemplate) ([]models.Template, error) {
+	if len(portable) == 0 {
+		return nil, myerr.Validation("Import document contains no templates", nil)
+	}
+
+	var skus []string
+	for _, t := range portable {
+		for _, line := range t.Content {
+			skus = append(skus, strings.TrimSpace(line.SKU))
+		}
+	}
+	bySKU := make(map[string]int64, len(skus))
+	if len(skus) > 0 {
+		products, err := s.repo.GetProductsBySKUs(ctx, skus)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range products {
+			bySKU[p.SKU] = p.ID
+		}
+	}
+
+	var fields []myerr.FieldError
+	names := make(map[string]int, len(portable))
+	templates := make([]models.Template, len(portable))
+	for i, t := range portable {
+		name := strings.TrimSpace(t.TemplateName)
+		if first, ok := names[name]; ok && name != "" {
+			fields = append(fields, myerr.FieldError{Field: importField(i, "templateName"), Message: fmt.Sprintf("Template %s is already in template %d", name, first)})
+		}
+		names[name] = i
+
+		content := make([]models.TemplateContent, len(t.Content))
+		for j, line := range t.Content {
+			sku := strings.TrimSpace(line.SKU)
+			productID, ok := bySKU[sku]
+			if !ok {
+				fields = append(fields, myerr.FieldError{Field: importField(i, fmt.Sprintf("content[%d].sku", j)), Index: &j, Message: fmt.Sprintf("Product with SKU %q not found", sku)})
+			}
+			content[j] = models.TemplateContent{ProductID: productID, Quantity: line.Quantity, Unit: line.Unit}
+		}
+		templates[i] = models.Template{
+			TemplateName: name,
+			Description:  t.Description,
+			Translations: t.Translations,
+			Passengers:   t.Passengers,
+			Content:      content,
+		}
+	}
+	if len(fields) > 0 {
+		return nil, myerr.ValidationFields(fmt.Sprintf("Import has %d problem(s)", len(fields)), fields)
+	}
+	return templates, nil
+}
+
+// importField возвращает путь к полю шаблона в импортируемом документе, например templates[2].content[0].sku.
+func importField(template int, field string) string {
+	if field == "" {
+		return fmt.Sprintf("templates[%d]", template)
+	}
+	return fmt.Sprintf("templates[%d].%s", template, field)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// validateUnits проверяет базовую единицу и уровни упаковки продукта. Пустая базовая единица заменяется на штуки.
+func validateUnits(p *models.Product) error {
+	if p.Unit == "" {
+		p.Unit = models.UnitPiece
+	}
+	seen := make(map[string]bool, len(p.Packaging))
+	rounded := 0
+	for _, packaging := range p.Packaging {
+		if packaging.Name == "" {
+			return myerr.Validation("Packaging name must not be empty", nil)
+		}
+		if packaging.Name == p.Unit {
+			return myerr.Validation(fmt.Sprintf("Packaging %q duplicates the base unit", packaging.Name), nil)
+		}
+		if seen[packaging.Name] {
+			return myerr.Validation(fmt.Sprintf("Packaging %q is defined more than once", packaging.Name), nil)
+		}
+		if packaging.Quantity <= 0 {
+			return myerr.Validation(fmt.Sprintf("Packaging %q must contain a positive number of %s", packaging.Name, p.Unit), nil)
+		}
+		if packaging.RoundScaled {
+			rounded++
+		}
+		seen[packaging.Name] = true
+	}
+	if rounded > 1 {
+		return myerr.Validation("Only one packaging can be used to round scaled templates", nil)
+	}
+	return nil
+}
+
+// templateProducts загружает продукты, на которые ссылаются строки шаблона, одним запросом.
+func (s *GoodsService) templateProducts(ctx context.Context, productIDs []int64) (map[int64]models.Product, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+	products, err := s.repo.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]models.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	return byID, nil
+}
+
+// normalizeTemplateQuantities переводит количества строк шаблона, заданные в упаковках, в базовые единицы продуктов.
+func normalizeTemplateQuantities(content []models.TemplateContent, products map[int64]models.Product) error {
+	for i, line := range content {
+		if line.Unit == "" {
+			continue
+		}
+		product, ok := products[line.ProductID]
+		if !ok {
+			return myerr.NotFound(fmt.Sprintf("Product with ID %d not found", line.ProductID), nil)
+		}
+		quantity, err := product.BaseQuantity(line.Quantity, line.Unit)
+		if err != nil {
+			return err
+		}
+		content[i].Quantity = quantity
+		content[i].Unit = ""
+	}
+	return nil
+}
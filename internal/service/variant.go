@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+)
+
+// validateVariant проверяет связь варианта с родителем: родитель существует и сам не является вариантом,
+// у варианта задана хотя бы одна опция, а продукт, у которого уже есть варианты, не становится вариантом.
+func (s *GoodsService) validateVariant(ctx context.Context, p *models.Product) error {
+	if !p.IsVariant() {
+		return nil
+	}
+	if p.ParentID == p.ID {
+		return myerr.Validation("Product cannot be a variant of itself", nil)
+	}
+	if len(p.Options) == 0 {
+		return myerr.Validation("Variant must have at least one option value", nil)
+	}
+
+	parent, err := s.repo.GetProductByID(ctx, p.ParentID)
+	if err != nil {
+		if myerr.IsNotFound(err) {
+			return myerr.NotFound(fmt.Sprintf("Parent product with ID %d not found", p.ParentID), err)
+		}
+		return err
+	}
+	if parent.IsVariant() {
+		return myerr.Validation(fmt.Sprintf("Product with ID %d is itself a variant and cannot have variants", parent.ID), nil)
+	}
+
+	if p.ID != 0 {
+		variants, err := s.repo.GetProductVariants(ctx, []int64{p.ID})
+		if err != nil {
+			return err
+		}
+		if len(variants) > 0 {
+			return myerr.Validation(fmt.Sprintf("Product with ID %d has variants and cannot become a variant", p.ID), nil)
+		}
+	}
+	return nil
+}
+
+// variantParents возвращает множество ID продуктов из списка, у которых есть варианты.
+func (s *GoodsService) variantParents(ctx context.Context, productIDs []int64) (map[int64]bool, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+	variants, err := s.repo.GetProductVariants(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[int64]bool, len(variants))
+	for _, v := range variants {
+		parents[v.ParentID] = true
+	}
+	return parents, nil
+}
+
+// nestVariants вкладывает варианты в их родительские продукты, сохраняя порядок родителей.
+// Вариант, родителя которого нет в списке (например, у родителя нет цены в прайс-листе), остается на верхнем уровне.
+func nestVariants(products []models.Product) []models.Product {
+	parents := make(map[int64]bool, len(products))
+	for _, p := range products {
+		if !p.IsVariant() {
+			parents[p.ID] = true
+		}
+	}
+
+	variants := make(map[int64][]models.Product)
+	nested := make([]models.Product, 0, len(products))
+	for _, p := range products {
+		if p.IsVariant() && parents[p.ParentID] {
+			variants[p.ParentID] = append(variants[p.ParentID], p)
+			continue
+		}
+		nested = append(nested, p)
+	}
+	for i := range nested {
+		if vs, ok := variants[nested[i].ID]; ok && !nested[i].IsVariant() {
+			nested[i].Variants = vs
+		}
+	}
+	return nested
+}
@@ -141,6 +141,70 @@ $$;
 
 ALTER FUNCTION public.set_default_version_id() OWNER TO postgres;
 
+--
+-- Name: set_updatedat(); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.set_updatedat() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    -- Время изменения отдается клиентам в Last-Modified
+    NEW.updatedat := CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$;
+
+
+ALTER FUNCTION public.set_updatedat() OWNER TO postgres;
+
+--
+-- Name: touch_parent_product(); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.touch_parent_product() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    -- Родительский продукт отдается вместе с вариантами, поэтому изменение варианта меняет и версию родителя
+    IF TG_OP <> 'INSERT' AND OLD.parentid IS NOT NULL THEN
+        UPDATE public.product SET rowversion = rowversion + 1 WHERE id = OLD.parentid;
+    END IF;
+    IF TG_OP <> 'DELETE' AND NEW.parentid IS NOT NULL AND NEW.parentid IS DISTINCT FROM OLD.parentid THEN
+        UPDATE public.product SET rowversion = rowversion + 1 WHERE id = NEW.parentid;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+
+ALTER FUNCTION public.touch_parent_product() OWNER TO postgres;
+
+--
+-- Name: touch_priced_product(); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.touch_priced_product() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    -- Цена из прайс-листа входит в представление продукта и его родителя, который отдается вместе с вариантами,
+    -- поэтому ее изменение меняет версию обоих
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE public.product SET rowversion = rowversion + 1
+        WHERE id = OLD.productid OR id = (SELECT parentid FROM public.product WHERE id = OLD.productid);
+    END IF;
+    IF TG_OP <> 'DELETE' AND NEW.productid IS DISTINCT FROM OLD.productid THEN
+        UPDATE public.product SET rowversion = rowversion + 1
+        WHERE id = NEW.productid OR id = (SELECT parentid FROM public.product WHERE id = NEW.productid);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+
+ALTER FUNCTION public.touch_priced_product() OWNER TO postgres;
+
 SET default_tablespace = '';
 
 SET default_table_access_method = heap;
@@ -190,7 +254,12 @@ ALTER SEQUENCE public.changes_change_id_seq OWNED BY public.changes.change_id;
 CREATE TABLE public.package (
     packageid integer NOT NULL,
     packagename character varying(255) NOT NULL UNIQUE,
-    description text
+    description text,
+    translations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    rowversion integer DEFAULT 1 NOT NULL,
+    updatedat timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    passengers integer DEFAULT 0 NOT NULL,
+    CONSTRAINT package_passengers_check CHECK ((passengers >= 0))
 );
 
 
@@ -254,6 +323,49 @@ ALTER SEQUENCE public.packagecontent_packagecontentid_seq OWNER TO postgres;
 ALTER SEQUENCE public.packagecontent_packagecontentid_seq OWNED BY public.packagecontent.packagecontentid;
 
 
+--
+-- Name: packagerevision; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.packagerevision (
+    packagerevisionid integer NOT NULL,
+    packageid integer NOT NULL,
+    revision integer NOT NULL,
+    packagename character varying(255) NOT NULL,
+    description text,
+    translations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    passengers integer DEFAULT 0 NOT NULL,
+    content jsonb DEFAULT '[]'::jsonb NOT NULL,
+    author character varying(255) DEFAULT ''::character varying NOT NULL,
+    createdat timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted boolean DEFAULT false NOT NULL
+);
+
+
+ALTER TABLE public.packagerevision OWNER TO postgres;
+
+--
+-- Name: packagerevision_packagerevisionid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.packagerevision_packagerevisionid_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER SEQUENCE public.packagerevision_packagerevisionid_seq OWNER TO postgres;
+
+--
+-- Name: packagerevision_packagerevisionid_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: postgres
+--
+
+ALTER SEQUENCE public.packagerevision_packagerevisionid_seq OWNED BY public.packagerevision.packagerevisionid;
+
+
 --
 -- Name: product; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -263,8 +375,23 @@ CREATE TABLE public.product (
     name character varying(255) NOT NULL,
     description text,
     price numeric(10,2) NOT NULL,
+    vatrate character varying(6) NOT NULL,
     imageurl character varying(255),
-    sku character varying(100) NOT NULL UNIQUE
+    thumbnailurl character varying(255) DEFAULT ''::character varying NOT NULL,
+    sku character varying(100) NOT NULL UNIQUE,
+    parentid integer,
+    options jsonb DEFAULT '{}'::jsonb NOT NULL,
+    unit character varying(20) DEFAULT 'pcs'::character varying NOT NULL,
+    packaging jsonb DEFAULT '[]'::jsonb NOT NULL,
+    nutrition jsonb,
+    allergens jsonb DEFAULT '[]'::jsonb NOT NULL,
+    translations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    status character varying(20) DEFAULT 'active'::character varying NOT NULL,
+    availability jsonb DEFAULT '{}'::jsonb NOT NULL,
+    rowversion integer DEFAULT 1 NOT NULL,
+    updatedat timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT product_vatrate_check CHECK (((vatrate)::text = ANY ((ARRAY['20'::character varying, '10'::character varying, '0'::character varying, 'exempt'::character varying])::text[]))),
+    CONSTRAINT product_status_check CHECK (((status)::text = ANY ((ARRAY['draft'::character varying, 'active'::character varying, 'discontinued'::character varying])::text[])))
 );
 
 
@@ -292,6 +419,67 @@ ALTER SEQUENCE public.product_id_seq OWNER TO postgres;
 ALTER SEQUENCE public.product_id_seq OWNED BY public.product.id;
 
 
+--
+-- Name: pricelist; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.pricelist (
+    pricelistid integer NOT NULL,
+    name character varying(100) NOT NULL UNIQUE,
+    currency character(3) NOT NULL
+);
+
+
+ALTER TABLE public.pricelist OWNER TO postgres;
+
+--
+-- Name: pricelist_pricelistid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.pricelist_pricelistid_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER SEQUENCE public.pricelist_pricelistid_seq OWNER TO postgres;
+
+--
+-- Name: pricelist_pricelistid_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: postgres
+--
+
+ALTER SEQUENCE public.pricelist_pricelistid_seq OWNED BY public.pricelist.pricelistid;
+
+
+--
+-- Name: pricelistitem; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.pricelistitem (
+    pricelistid integer NOT NULL,
+    productid integer NOT NULL,
+    price numeric(10,2) NOT NULL
+);
+
+
+ALTER TABLE public.pricelistitem OWNER TO postgres;
+
+--
+-- Name: productbarcode; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.productbarcode (
+    gtin character(14) NOT NULL,
+    barcode character varying(14) NOT NULL,
+    productid integer NOT NULL
+);
+
+
+ALTER TABLE public.productbarcode OWNER TO postgres;
+
 --
 -- Name: version; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -349,6 +537,13 @@ ALTER TABLE ONLY public.package ALTER COLUMN packageid SET DEFAULT nextval('publ
 ALTER TABLE ONLY public.packagecontent ALTER COLUMN packagecontentid SET DEFAULT nextval('public.packagecontent_packagecontentid_seq'::regclass);
 
 
+--
+-- Name: packagerevision packagerevisionid; Type: DEFAULT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.packagerevision ALTER COLUMN packagerevisionid SET DEFAULT nextval('public.packagerevision_packagerevisionid_seq'::regclass);
+
+
 --
 -- Name: product id; Type: DEFAULT; Schema: public; Owner: postgres
 --
@@ -356,6 +551,13 @@ ALTER TABLE ONLY public.packagecontent ALTER COLUMN packagecontentid SET DEFAULT
 ALTER TABLE ONLY public.product ALTER COLUMN id SET DEFAULT nextval('public.product_id_seq'::regclass);
 
 
+--
+-- Name: pricelist pricelistid; Type: DEFAULT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.pricelist ALTER COLUMN pricelistid SET DEFAULT nextval('public.pricelist_pricelistid_seq'::regclass);
+
+
 --
 -- Name: version version_id; Type: DEFAULT; Schema: public; Owner: postgres
 --
@@ -387,6 +589,30 @@ ALTER TABLE ONLY public.packagecontent
     ADD CONSTRAINT packagecontent_pkey PRIMARY KEY (packagecontentid);
 
 
+--
+-- Name: packagecontent packagecontent_packageid_productid_key; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.packagecontent
+    ADD CONSTRAINT packagecontent_packageid_productid_key UNIQUE (packageid, productid);
+
+
+--
+-- Name: packagerevision packagerevision_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.packagerevision
+    ADD CONSTRAINT packagerevision_pkey PRIMARY KEY (packagerevisionid);
+
+
+--
+-- Name: packagerevision packagerevision_packageid_revision_key; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.packagerevision
+    ADD CONSTRAINT packagerevision_packageid_revision_key UNIQUE (packageid, revision);
+
+
 --
 -- Name: product product_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -395,6 +621,22 @@ ALTER TABLE ONLY public.product
     ADD CONSTRAINT product_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: pricelist pricelist_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.pricelist
+    ADD CONSTRAINT pricelist_pkey PRIMARY KEY (pricelistid);
+
+
+--
+-- Name: pricelistitem pricelistitem_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.pricelistitem
+    ADD CONSTRAINT pricelistitem_pkey PRIMARY KEY (pricelistid, productid);
+
+
 --
 -- Name: version versions_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -417,6 +659,20 @@ CREATE INDEX idx_package_id ON public.packagecontent USING btree (packageid);
 CREATE INDEX idx_product_id ON public.packagecontent USING btree (productid);
 
 
+--
+-- Name: idx_product_parentid; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_product_parentid ON public.product USING btree (parentid);
+
+
+--
+-- Name: idx_productbarcode_productid; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_productbarcode_productid ON public.productbarcode USING btree (productid);
+
+
 --
 -- Name: version trigger_prevent_multiple_dev_versions; Type: TRIGGER; Schema: public; Owner: postgres
 --
@@ -431,6 +687,34 @@ CREATE TRIGGER trigger_prevent_multiple_dev_versions BEFORE INSERT ON public.ver
 CREATE TRIGGER trigger_set_default_version_id BEFORE INSERT ON public.changes FOR EACH ROW EXECUTE FUNCTION public.set_default_version_id();
 
 
+--
+-- Name: product trigger_product_set_updatedat; Type: TRIGGER; Schema: public; Owner: postgres
+--
+
+CREATE TRIGGER trigger_product_set_updatedat BEFORE UPDATE ON public.product FOR EACH ROW EXECUTE FUNCTION public.set_updatedat();
+
+
+--
+-- Name: package trigger_package_set_updatedat; Type: TRIGGER; Schema: public; Owner: postgres
+--
+
+CREATE TRIGGER trigger_package_set_updatedat BEFORE UPDATE ON public.package FOR EACH ROW EXECUTE FUNCTION public.set_updatedat();
+
+
+--
+-- Name: product trigger_touch_parent_product; Type: TRIGGER; Schema: public; Owner: postgres
+--
+
+CREATE TRIGGER trigger_touch_parent_product AFTER INSERT OR DELETE OR UPDATE OF parentid, name, description, price, vatrate, imageurl, thumbnailurl, sku, options, unit, packaging, nutrition, allergens, translations, status, availability ON public.product FOR EACH ROW EXECUTE FUNCTION public.touch_parent_product();
+
+
+--
+-- Name: pricelistitem trigger_touch_priced_product; Type: TRIGGER; Schema: public; Owner: postgres
+--
+
+CREATE TRIGGER trigger_touch_priced_product AFTER INSERT OR DELETE OR UPDATE ON public.pricelistitem FOR EACH ROW EXECUTE FUNCTION public.touch_priced_product();
+
+
 --
 -- Name: changes changes_version_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -455,6 +739,46 @@ ALTER TABLE ONLY public.packagecontent
     ADD CONSTRAINT packagecontent_productid_fkey FOREIGN KEY (productid) REFERENCES public.product(id);
 
 
+--
+-- Name: productbarcode productbarcode_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.productbarcode
+    ADD CONSTRAINT productbarcode_pkey PRIMARY KEY (gtin);
+
+
+--
+-- Name: pricelistitem pricelistitem_pricelistid_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.pricelistitem
+    ADD CONSTRAINT pricelistitem_pricelistid_fkey FOREIGN KEY (pricelistid) REFERENCES public.pricelist(pricelistid) ON DELETE CASCADE;
+
+
+--
+-- Name: productbarcode productbarcode_productid_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.productbarcode
+    ADD CONSTRAINT productbarcode_productid_fkey FOREIGN KEY (productid) REFERENCES public.product(id) ON DELETE CASCADE;
+
+
+--
+-- Name: product product_parentid_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.product
+    ADD CONSTRAINT product_parentid_fkey FOREIGN KEY (parentid) REFERENCES public.product(id);
+
+
+--
+-- Name: pricelistitem pricelistitem_productid_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.pricelistitem
+    ADD CONSTRAINT pricelistitem_productid_fkey FOREIGN KEY (productid) REFERENCES public.product(id) ON DELETE CASCADE;
+
+
 --
 -- Name: TABLE changes; Type: ACL; Schema: public; Owner: postgres
 --
@@ -497,6 +821,20 @@ GRANT ALL ON TABLE public.packagecontent TO application_user;
 GRANT USAGE ON SEQUENCE public.packagecontent_packagecontentid_seq TO application_user;
 
 
+--
+-- Name: TABLE packagerevision; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT SELECT,INSERT ON TABLE public.packagerevision TO application_user;
+
+
+--
+-- Name: SEQUENCE packagerevision_packagerevisionid_seq; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT USAGE ON SEQUENCE public.packagerevision_packagerevisionid_seq TO application_user;
+
+
 --
 -- Name: TABLE product; Type: ACL; Schema: public; Owner: postgres
 --
@@ -511,6 +849,34 @@ GRANT SELECT,INSERT,DELETE,UPDATE ON TABLE public.product TO application_user;
 GRANT USAGE ON SEQUENCE public.product_id_seq TO application_user;
 
 
+--
+-- Name: TABLE pricelist; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT SELECT,INSERT,DELETE,UPDATE ON TABLE public.pricelist TO application_user;
+
+
+--
+-- Name: SEQUENCE pricelist_pricelistid_seq; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT USAGE ON SEQUENCE public.pricelist_pricelistid_seq TO application_user;
+
+
+--
+-- Name: TABLE pricelistitem; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT SELECT,INSERT,DELETE,UPDATE ON TABLE public.pricelistitem TO application_user;
+
+
+--
+-- Name: TABLE productbarcode; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT SELECT,INSERT,DELETE,UPDATE ON TABLE public.productbarcode TO application_user;
+
+
 --
 -- Name: TABLE version; Type: ACL; Schema: public; Owner: postgres
 --
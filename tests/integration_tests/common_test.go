@@ -13,6 +13,7 @@ import (
 	"github.com/go-kit/log/level"
 
 	"github.com/Chaika-Team/ChaikaGoods/internal/config"
+	"github.com/Chaika-Team/ChaikaGoods/internal/repository/filesystem"
 	"github.com/Chaika-Team/ChaikaGoods/internal/repository/postgresql"
 	repo "github.com/Chaika-Team/ChaikaGoods/internal/repository/postgresql"
 	"github.com/Chaika-Team/ChaikaGoods/internal/service"
@@ -50,7 +51,12 @@ func setupService(t *testing.T, keyspace string) service.Service {
 		cleanupDB(ctx, t, cfg, keyspace, pool, logger)
 	})
 
-	return service.NewService(repo.NewGoodsRepository(pool, logger), logger)
+	images, err := filesystem.NewBlobStore(t.TempDir(), cfg.Images.BaseURL)
+	if err != nil {
+		t.Fatalf("Cannot create image storage, got err: %v", err)
+	}
+
+	return service.NewService(repo.NewGoodsRepository(pool, logger), images, logger)
 }
 
 func createDB(ctx context.Context, t *testing.T, cfg config.Config, keyspace string, logger log.Logger) {
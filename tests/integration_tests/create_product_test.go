@@ -19,7 +19,8 @@ func TestCreateProductSuccess(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Coffee",
-		Price:       399.99,
+		Price:       models.Money(39999),
+		VATRate:     models.VATRate20,
 		Description: "100 percent arabic",
 	}
 
@@ -38,7 +39,8 @@ func TestCreateProductWithSameSKU(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Coffee",
-		Price:       399.99,
+		Price:       models.Money(39999),
+		VATRate:     models.VATRate20,
 		Description: "100 percent Arabic",
 		SKU:         "AE41MV9ZCHKGDA",
 	}
@@ -49,7 +51,8 @@ func TestCreateProductWithSameSKU(t *testing.T) {
 
 	productReplica := models.Product{
 		Name:        "Tea",
-		Price:       99.99,
+		Price:       models.Money(9999),
+		VATRate:     models.VATRate20,
 		Description: "Black Knight",
 		SKU:         "AE41MV9ZCHKGDA",
 	}
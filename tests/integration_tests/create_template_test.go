@@ -58,7 +58,8 @@ func TestCreateTemplateWithProducts(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Milk",
-		Price:       99.99,
+		Price:       models.Money(9999),
+		VATRate:     models.VATRate20,
 		Description: "Basic 2.5% milk",
 	}
 
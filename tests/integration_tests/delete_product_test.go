@@ -18,7 +18,8 @@ func TestDeleteProduct(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Milk",
-		Price:       99.99,
+		Price:       models.Money(9999),
+		VATRate:     models.VATRate20,
 		Description: "Basic 2.5% milk",
 	}
 
@@ -27,7 +28,7 @@ func TestDeleteProduct(t *testing.T) {
 		t.Fatalf("Cannot create product, got err: %v", err)
 	}
 
-	err = svc.DeleteProduct(ctx, id)
+	err = svc.DeleteProduct(ctx, id, 0)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -41,7 +42,8 @@ func TestDeleteProductNotFound(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Banana",
-		Price:       99.99,
+		Price:       models.Money(9999),
+		VATRate:     models.VATRate20,
 		Description: "From Australia",
 	}
 
@@ -50,7 +52,7 @@ func TestDeleteProductNotFound(t *testing.T) {
 		t.Fatalf("Cannot create product, got err: %v", err)
 	}
 	otherId := id + 1
-	err = svc.DeleteProduct(ctx, otherId)
+	err = svc.DeleteProduct(ctx, otherId, 0)
 	if err == nil {
 		t.Fatalf("Expected error, got nil: %v", err)
 	}
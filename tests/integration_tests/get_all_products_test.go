@@ -19,16 +19,20 @@ func TestGetAllProductSuccess(t *testing.T) {
 
 	productOne := models.Product{
 		Name:        "Milk",
-		Price:       99.99,
+		Price:       models.Money(9999),
+		VATRate:     models.VATRate20,
 		Description: "Basic 2.5% milk",
 		SKU:         "SF93N30A",
+		Status:      models.ProductStatusActive,
 	}
 
 	productTwo := models.Product{
 		Name:        "Chocolate",
-		Price:       199.99,
+		Price:       models.Money(19999),
+		VATRate:     models.VATRate20,
 		Description: "80 percent cacao",
 		SKU:         "SF93N30B",
+		Status:      models.ProductStatusActive,
 	}
 
 	id, err := svc.CreateProduct(ctx, &productOne)
@@ -43,7 +47,7 @@ func TestGetAllProductSuccess(t *testing.T) {
 	}
 	assert.Equal(t, id, int64(2))
 
-	productsInfo, err := svc.GetAllProducts(ctx)
+	productsInfo, err := svc.GetAllProducts(ctx, models.ProductQuery{})
 	if err != nil {
 		t.Fatalf("Cannot get all products, got err: %v", err)
 	}
@@ -56,7 +60,7 @@ func TestGetAllProductEmpty(t *testing.T) {
 	svc := setupService(t, keyspace)
 	ctx := context.Background()
 
-	productsInfo, err := svc.GetAllProducts(ctx)
+	productsInfo, err := svc.GetAllProducts(ctx, models.ProductQuery{})
 	if err != nil {
 		t.Fatalf("Cannot get all products, got err: %v", err)
 	}
@@ -19,7 +19,8 @@ func TestGetProductID(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Milk",
-		Price:       99.99,
+		Price:       models.Money(9999),
+		VATRate:     models.VATRate20,
 		Description: "Basic 2.5% milk",
 	}
 
@@ -28,7 +29,7 @@ func TestGetProductID(t *testing.T) {
 		t.Fatalf("Cannot create product, got err: %v", err)
 	}
 
-	productInfo, err := svc.GetProductByID(ctx, id)
+	productInfo, err := svc.GetProductByID(ctx, id, models.ProductQuery{})
 	if err != nil {
 		t.Fatalf("Expected product, got err: %v", err)
 	}
@@ -36,7 +37,7 @@ func TestGetProductID(t *testing.T) {
 	assert.Equal(t, productInfo.Price, product.Price)
 	assert.Equal(t, productInfo.Name, product.Name)
 
-	productInfo, err = svc.GetProductByID(ctx, 3)
+	productInfo, err = svc.GetProductByID(ctx, 3, models.ProductQuery{})
 	if err == nil {
 		t.Fatalf("Expected error, got product info: %v", productInfo)
 	}
@@ -0,0 +1,78 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/handler"
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+)
+
+func TestGetParentProductETagAfterVariantPriceChange(t *testing.T) {
+	keyspace := "parent_product_etag_test"
+
+	svc := setupService(t, keyspace)
+	ctx := context.Background()
+	server := handler.NewHTTPServer(log.NewNopLogger(), handler.MakeEndpoints(log.NewNopLogger(), svc), "no-cache")
+
+	parent := models.Product{Name: "Juice", Price: models.Money(9999), VATRate: models.VATRate20, SKU: "JUICE"}
+	parentID, err := svc.CreateProduct(ctx, &parent)
+	if err != nil {
+		t.Fatalf("Cannot create parent product, got err: %v", err)
+	}
+	variant := models.Product{
+		Name:     "Juice 0.5 L",
+		Price:    models.Money(9999),
+		VATRate:  models.VATRate20,
+		SKU:      "JUICE-05",
+		ParentID: parentID,
+		Options:  map[string]string{"volume": "0.5 L"},
+	}
+	variantID, err := svc.CreateProduct(ctx, &variant)
+	if err != nil {
+		t.Fatalf("Cannot create variant, got err: %v", err)
+	}
+	priceListID, err := svc.CreatePriceList(ctx, &models.PriceList{Name: "wholesale", Currency: models.BaseCurrency})
+	if err != nil {
+		t.Fatalf("Cannot create price list, got err: %v", err)
+	}
+	prices := []models.ProductPrice{{ProductID: parentID, Price: models.Money(8999)}, {ProductID: variantID, Price: models.Money(8999)}}
+	if err := svc.SetPriceListPrices(ctx, priceListID, prices); err != nil {
+		t.Fatalf("Cannot set prices, got err: %v", err)
+	}
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/product/%d?price_list=wholesale", parentID), nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := get("")
+	assert.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, http.StatusNotModified, get(etag).Code)
+
+	prices = []models.ProductPrice{{ProductID: variantID, Price: models.Money(7999)}}
+	if err := svc.SetPriceListPrices(ctx, priceListID, prices); err != nil {
+		t.Fatalf("Cannot change variant price, got err: %v", err)
+	}
+
+	changed := get(etag)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+	assert.Contains(t, changed.Body.String(), `"79.99"`)
+}
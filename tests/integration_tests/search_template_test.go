@@ -17,7 +17,7 @@ func TestSearchTemplateNonExisting(t *testing.T) {
 	svc := setupService(t, keyspace)
 	ctx := context.Background()
 
-	template, err := svc.SearchTemplates(ctx, "Default", 1, 1)
+	template, err := svc.SearchTemplates(ctx, "Default", 1, 1, models.TemplateQuery{})
 	if err != nil {
 		t.Fatalf("Expected empty template, got err: %v", err)
 	}
@@ -43,7 +43,7 @@ func TestSearchTemplateExistingVariousString(t *testing.T) {
 	assert.Equal(t, id, int64(1))
 
 	// Empty string case
-	templateResponse, err := svc.SearchTemplates(ctx, "", 1, 0)
+	templateResponse, err := svc.SearchTemplates(ctx, "", 1, 0, models.TemplateQuery{})
 	if err != nil {
 		t.Fatalf("Expected successful operation, got err: %v", err)
 	}
@@ -55,7 +55,7 @@ func TestSearchTemplateExistingVariousString(t *testing.T) {
 	assert.Nil(t, templateResponse[0].Content)
 
 	// Existing string case
-	templateResponse, err = svc.SearchTemplates(ctx, "Template", 1, 0)
+	templateResponse, err = svc.SearchTemplates(ctx, "Template", 1, 0, models.TemplateQuery{})
 	if err != nil {
 		t.Fatalf("Expected successful operation, got err: %v", err)
 	}
@@ -67,7 +67,7 @@ func TestSearchTemplateExistingVariousString(t *testing.T) {
 	assert.Nil(t, templateResponse[0].Content)
 
 	// Non existing string case
-	templateResponse, err = svc.SearchTemplates(ctx, "NonExists", 1, 0)
+	templateResponse, err = svc.SearchTemplates(ctx, "NonExists", 1, 0, models.TemplateQuery{})
 	if err != nil {
 		t.Fatalf("Expected successful operation, got err: %v", err)
 	}
@@ -94,7 +94,7 @@ func TestSearchTemplateNotFull(t *testing.T) {
 	}
 	assert.Equal(t, id, int64(1))
 
-	templateResponse, err := svc.SearchTemplates(ctx, "New Template", 1, 0)
+	templateResponse, err := svc.SearchTemplates(ctx, "New Template", 1, 0, models.TemplateQuery{})
 	if err != nil {
 		t.Fatalf("Expected successful operation, got err: %v", err)
 	}
@@ -112,14 +112,14 @@ func TestSearchTemplateInvalidRequests(t *testing.T) {
 	svc := setupService(t, keyspace)
 	ctx := context.Background()
 
-	template, err := svc.SearchTemplates(ctx, "Context", -10, 10)
+	template, err := svc.SearchTemplates(ctx, "Context", -10, 10, models.TemplateQuery{})
 	if err == nil {
 		t.Fatalf("Expected error, got template: %v", template)
 	}
 	assert.Nil(t, template)
 	assert.Error(t, err)
 
-	template, err = svc.SearchTemplates(ctx, "Context", 10, -10)
+	template, err = svc.SearchTemplates(ctx, "Context", 10, -10, models.TemplateQuery{})
 	if err == nil {
 		t.Fatalf("Expected error, got template: %v", template)
 	}
@@ -19,7 +19,8 @@ func TestUpdateProductSuccess(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Coffee",
-		Price:       399.99,
+		Price:       models.Money(39999),
+		VATRate:     models.VATRate20,
 		Description: "100 percent arabic",
 	}
 	id, err := svc.CreateProduct(ctx, &product)
@@ -27,13 +28,13 @@ func TestUpdateProductSuccess(t *testing.T) {
 		t.Fatalf("Expected id, got err: %v", err)
 	}
 
-	product.Price = 499.99
+	product.Price = models.Money(49999)
 	err = svc.UpdateProduct(ctx, &product)
 	if err != nil {
 		t.Fatalf("Unexpected err: %v", err)
 	}
 
-	productResponse, err := svc.GetProductByID(ctx, id)
+	productResponse, err := svc.GetProductByID(ctx, id, models.ProductQuery{})
 	if err != nil {
 		t.Fatalf("Expected product, got err: %v", err)
 	}
@@ -49,7 +50,8 @@ func TestUpdateProductSKU(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Coffee",
-		Price:       399.99,
+		Price:       models.Money(39999),
+		VATRate:     models.VATRate20,
 		Description: "100 percent Arabic",
 		SKU:         "AE41MV9ZCHKGDA",
 	}
@@ -60,7 +62,8 @@ func TestUpdateProductSKU(t *testing.T) {
 
 	productNew := models.Product{
 		Name:        "Tea",
-		Price:       99.99,
+		Price:       models.Money(9999),
+		VATRate:     models.VATRate20,
 		Description: "Black Knight",
 		SKU:         "AE41MV9ZCHKGDB",
 	}
@@ -75,12 +78,12 @@ func TestUpdateProductSKU(t *testing.T) {
 		t.Fatalf("Expect error, got nil: %v", err)
 	}
 
-	productResponse1, err := svc.GetProductByID(ctx, id)
+	productResponse1, err := svc.GetProductByID(ctx, id, models.ProductQuery{})
 	if err != nil {
 		t.Fatalf("Expected product, got err: %v", err)
 	}
 
-	productResponse2, err := svc.GetProductByID(ctx, idNew)
+	productResponse2, err := svc.GetProductByID(ctx, idNew, models.ProductQuery{})
 	if err != nil {
 		t.Fatalf("Expected product, got err: %v", err)
 	}
@@ -95,7 +98,8 @@ func TestUpdateProductNotFound(t *testing.T) {
 
 	product := models.Product{
 		Name:        "Coffee",
-		Price:       399.99,
+		Price:       models.Money(39999),
+		VATRate:     models.VATRate20,
 		Description: "100 percent Arabic",
 		SKU:         "AE41MV9ZCHKGDA",
 	}
@@ -105,7 +109,7 @@ func TestUpdateProductNotFound(t *testing.T) {
 	}
 
 	product.ID = 10
-	product.Price = 299.99
+	product.Price = models.Money(29999)
 	err = svc.UpdateProduct(ctx, &product)
 	if err == nil {
 		t.Fatalf("Expect error, got nil: %v", err)
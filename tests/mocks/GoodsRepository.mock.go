@@ -22,6 +22,180 @@ func (_m *MockGoodsRepository) EXPECT() *MockGoodsRepository_Expecter {
 	return &MockGoodsRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddTemplateContent provides a mock function with given fields: ctx, templateID, content, version
+func (_m *MockGoodsRepository) AddTemplateContent(ctx context.Context, templateID int64, content models.TemplateContent, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, content, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTemplateContent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) (int64, error)); ok {
+		return rf(ctx, templateID, content, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) int64); ok {
+		r0 = rf(ctx, templateID, content, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TemplateContent, int64) error); ok {
+		r1 = rf(ctx, templateID, content, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_AddTemplateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTemplateContent'
+type MockGoodsRepository_AddTemplateContent_Call struct {
+	*mock.Call
+}
+
+// AddTemplateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - content models.TemplateContent
+//   - version int64
+func (_e *MockGoodsRepository_Expecter) AddTemplateContent(ctx interface{}, templateID interface{}, content interface{}, version interface{}) *MockGoodsRepository_AddTemplateContent_Call {
+	return &MockGoodsRepository_AddTemplateContent_Call{Call: _e.mock.On("AddTemplateContent", ctx, templateID, content, version)}
+}
+
+func (_c *MockGoodsRepository_AddTemplateContent_Call) Run(run func(ctx context.Context, templateID int64, content models.TemplateContent, version int64)) *MockGoodsRepository_AddTemplateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TemplateContent), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_AddTemplateContent_Call) Return(_a0 int64, _a1 error) *MockGoodsRepository_AddTemplateContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_AddTemplateContent_Call) RunAndReturn(run func(context.Context, int64, models.TemplateContent, int64) (int64, error)) *MockGoodsRepository_AddTemplateContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloneTemplate provides a mock function with given fields: ctx, templateID, name
+func (_m *MockGoodsRepository) CloneTemplate(ctx context.Context, templateID int64, name string) (int64, error) {
+	ret := _m.Called(ctx, templateID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneTemplate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return rf(ctx, templateID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = rf(ctx, templateID, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, templateID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_CloneTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloneTemplate'
+type MockGoodsRepository_CloneTemplate_Call struct {
+	*mock.Call
+}
+
+// CloneTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - name string
+func (_e *MockGoodsRepository_Expecter) CloneTemplate(ctx interface{}, templateID interface{}, name interface{}) *MockGoodsRepository_CloneTemplate_Call {
+	return &MockGoodsRepository_CloneTemplate_Call{Call: _e.mock.On("CloneTemplate", ctx, templateID, name)}
+}
+
+func (_c *MockGoodsRepository_CloneTemplate_Call) Run(run func(ctx context.Context, templateID int64, name string)) *MockGoodsRepository_CloneTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_CloneTemplate_Call) Return(_a0 int64, _a1 error) *MockGoodsRepository_CloneTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_CloneTemplate_Call) RunAndReturn(run func(context.Context, int64, string) (int64, error)) *MockGoodsRepository_CloneTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePriceList provides a mock function with given fields: ctx, priceList
+func (_m *MockGoodsRepository) CreatePriceList(ctx context.Context, priceList *models.PriceList) (int64, error) {
+	ret := _m.Called(ctx, priceList)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePriceList")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PriceList) (int64, error)); ok {
+		return rf(ctx, priceList)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PriceList) int64); ok {
+		r0 = rf(ctx, priceList)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PriceList) error); ok {
+		r1 = rf(ctx, priceList)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_CreatePriceList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePriceList'
+type MockGoodsRepository_CreatePriceList_Call struct {
+	*mock.Call
+}
+
+// CreatePriceList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - priceList *models.PriceList
+func (_e *MockGoodsRepository_Expecter) CreatePriceList(ctx interface{}, priceList interface{}) *MockGoodsRepository_CreatePriceList_Call {
+	return &MockGoodsRepository_CreatePriceList_Call{Call: _e.mock.On("CreatePriceList", ctx, priceList)}
+}
+
+func (_c *MockGoodsRepository_CreatePriceList_Call) Run(run func(ctx context.Context, priceList *models.PriceList)) *MockGoodsRepository_CreatePriceList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PriceList))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_CreatePriceList_Call) Return(_a0 int64, _a1 error) *MockGoodsRepository_CreatePriceList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_CreatePriceList_Call) RunAndReturn(run func(context.Context, *models.PriceList) (int64, error)) *MockGoodsRepository_CreatePriceList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateProduct provides a mock function with given fields: ctx, p
 func (_m *MockGoodsRepository) CreateProduct(ctx context.Context, p *models.Product) (int64, error) {
 	ret := _m.Called(ctx, p)
@@ -126,17 +300,17 @@ func (_c *MockGoodsRepository_CreateTemplate_Call) RunAndReturn(run func(context
 	return _c
 }
 
-// DeleteProduct provides a mock function with given fields: ctx, id
-func (_m *MockGoodsRepository) DeleteProduct(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// DeleteProduct provides a mock function with given fields: ctx, id, version
+func (_m *MockGoodsRepository) DeleteProduct(ctx context.Context, id int64, version int64) error {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteProduct")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -152,13 +326,14 @@ type MockGoodsRepository_DeleteProduct_Call struct {
 // DeleteProduct is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int64
-func (_e *MockGoodsRepository_Expecter) DeleteProduct(ctx interface{}, id interface{}) *MockGoodsRepository_DeleteProduct_Call {
-	return &MockGoodsRepository_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id)}
+//   - version int64
+func (_e *MockGoodsRepository_Expecter) DeleteProduct(ctx interface{}, id interface{}, version interface{}) *MockGoodsRepository_DeleteProduct_Call {
+	return &MockGoodsRepository_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id, version)}
 }
 
-func (_c *MockGoodsRepository_DeleteProduct_Call) Run(run func(ctx context.Context, id int64)) *MockGoodsRepository_DeleteProduct_Call {
+func (_c *MockGoodsRepository_DeleteProduct_Call) Run(run func(ctx context.Context, id int64, version int64)) *MockGoodsRepository_DeleteProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
 	})
 	return _c
 }
@@ -168,22 +343,22 @@ func (_c *MockGoodsRepository_DeleteProduct_Call) Return(_a0 error) *MockGoodsRe
 	return _c
 }
 
-func (_c *MockGoodsRepository_DeleteProduct_Call) RunAndReturn(run func(context.Context, int64) error) *MockGoodsRepository_DeleteProduct_Call {
+func (_c *MockGoodsRepository_DeleteProduct_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockGoodsRepository_DeleteProduct_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTemplate provides a mock function with given fields: ctx, templateID
-func (_m *MockGoodsRepository) DeleteTemplate(ctx context.Context, templateID int64) error {
-	ret := _m.Called(ctx, templateID)
+// DeleteTemplate provides a mock function with given fields: ctx, templateID, version
+func (_m *MockGoodsRepository) DeleteTemplate(ctx context.Context, templateID int64, version int64) error {
+	ret := _m.Called(ctx, templateID, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTemplate")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, templateID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, templateID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -199,13 +374,14 @@ type MockGoodsRepository_DeleteTemplate_Call struct {
 // DeleteTemplate is a helper method to define mock.On call
 //   - ctx context.Context
 //   - templateID int64
-func (_e *MockGoodsRepository_Expecter) DeleteTemplate(ctx interface{}, templateID interface{}) *MockGoodsRepository_DeleteTemplate_Call {
-	return &MockGoodsRepository_DeleteTemplate_Call{Call: _e.mock.On("DeleteTemplate", ctx, templateID)}
+//   - version int64
+func (_e *MockGoodsRepository_Expecter) DeleteTemplate(ctx interface{}, templateID interface{}, version interface{}) *MockGoodsRepository_DeleteTemplate_Call {
+	return &MockGoodsRepository_DeleteTemplate_Call{Call: _e.mock.On("DeleteTemplate", ctx, templateID, version)}
 }
 
-func (_c *MockGoodsRepository_DeleteTemplate_Call) Run(run func(ctx context.Context, templateID int64)) *MockGoodsRepository_DeleteTemplate_Call {
+func (_c *MockGoodsRepository_DeleteTemplate_Call) Run(run func(ctx context.Context, templateID int64, version int64)) *MockGoodsRepository_DeleteTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
 	})
 	return _c
 }
@@ -215,7 +391,66 @@ func (_c *MockGoodsRepository_DeleteTemplate_Call) Return(_a0 error) *MockGoodsR
 	return _c
 }
 
-func (_c *MockGoodsRepository_DeleteTemplate_Call) RunAndReturn(run func(context.Context, int64) error) *MockGoodsRepository_DeleteTemplate_Call {
+func (_c *MockGoodsRepository_DeleteTemplate_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockGoodsRepository_DeleteTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTemplateContent provides a mock function with given fields: ctx, templateID, productID, version
+func (_m *MockGoodsRepository) DeleteTemplateContent(ctx context.Context, templateID int64, productID int64, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, productID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTemplateContent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (int64, error)); ok {
+		return rf(ctx, templateID, productID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) int64); ok {
+		r0 = rf(ctx, templateID, productID, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, templateID, productID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_DeleteTemplateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTemplateContent'
+type MockGoodsRepository_DeleteTemplateContent_Call struct {
+	*mock.Call
+}
+
+// DeleteTemplateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - productID int64
+//   - version int64
+func (_e *MockGoodsRepository_Expecter) DeleteTemplateContent(ctx interface{}, templateID interface{}, productID interface{}, version interface{}) *MockGoodsRepository_DeleteTemplateContent_Call {
+	return &MockGoodsRepository_DeleteTemplateContent_Call{Call: _e.mock.On("DeleteTemplateContent", ctx, templateID, productID, version)}
+}
+
+func (_c *MockGoodsRepository_DeleteTemplateContent_Call) Run(run func(ctx context.Context, templateID int64, productID int64, version int64)) *MockGoodsRepository_DeleteTemplateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_DeleteTemplateContent_Call) Return(_a0 int64, _a1 error) *MockGoodsRepository_DeleteTemplateContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_DeleteTemplateContent_Call) RunAndReturn(run func(context.Context, int64, int64, int64) (int64, error)) *MockGoodsRepository_DeleteTemplateContent_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -338,27 +573,27 @@ func (_c *MockGoodsRepository_GetAllTemplates_Call) RunAndReturn(run func(contex
 	return _c
 }
 
-// GetProductByID provides a mock function with given fields: ctx, id
-func (_m *MockGoodsRepository) GetProductByID(ctx context.Context, id int64) (models.Product, error) {
-	ret := _m.Called(ctx, id)
+// GetPriceListByName provides a mock function with given fields: ctx, name
+func (_m *MockGoodsRepository) GetPriceListByName(ctx context.Context, name string) (models.PriceList, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProductByID")
+		panic("no return value specified for GetPriceListByName")
 	}
 
-	var r0 models.Product
+	var r0 models.PriceList
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.Product, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.PriceList, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) models.Product); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.PriceList); ok {
+		r0 = rf(ctx, name)
 	} else {
-		r0 = ret.Get(0).(models.Product)
+		r0 = ret.Get(0).(models.PriceList)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -366,58 +601,58 @@ func (_m *MockGoodsRepository) GetProductByID(ctx context.Context, id int64) (mo
 	return r0, r1
 }
 
-// MockGoodsRepository_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
-type MockGoodsRepository_GetProductByID_Call struct {
+// MockGoodsRepository_GetPriceListByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceListByName'
+type MockGoodsRepository_GetPriceListByName_Call struct {
 	*mock.Call
 }
 
-// GetProductByID is a helper method to define mock.On call
+// GetPriceListByName is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-func (_e *MockGoodsRepository_Expecter) GetProductByID(ctx interface{}, id interface{}) *MockGoodsRepository_GetProductByID_Call {
-	return &MockGoodsRepository_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id)}
+//   - name string
+func (_e *MockGoodsRepository_Expecter) GetPriceListByName(ctx interface{}, name interface{}) *MockGoodsRepository_GetPriceListByName_Call {
+	return &MockGoodsRepository_GetPriceListByName_Call{Call: _e.mock.On("GetPriceListByName", ctx, name)}
 }
 
-func (_c *MockGoodsRepository_GetProductByID_Call) Run(run func(ctx context.Context, id int64)) *MockGoodsRepository_GetProductByID_Call {
+func (_c *MockGoodsRepository_GetPriceListByName_Call) Run(run func(ctx context.Context, name string)) *MockGoodsRepository_GetPriceListByName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockGoodsRepository_GetProductByID_Call) Return(_a0 models.Product, _a1 error) *MockGoodsRepository_GetProductByID_Call {
+func (_c *MockGoodsRepository_GetPriceListByName_Call) Return(_a0 models.PriceList, _a1 error) *MockGoodsRepository_GetPriceListByName_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockGoodsRepository_GetProductByID_Call) RunAndReturn(run func(context.Context, int64) (models.Product, error)) *MockGoodsRepository_GetProductByID_Call {
+func (_c *MockGoodsRepository_GetPriceListByName_Call) RunAndReturn(run func(context.Context, string) (models.PriceList, error)) *MockGoodsRepository_GetPriceListByName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProductsByTemplateID provides a mock function with given fields: ctx, templateID
-func (_m *MockGoodsRepository) GetProductsByTemplateID(ctx context.Context, templateID int64) ([]models.TemplateContent, error) {
-	ret := _m.Called(ctx, templateID)
+// GetPriceListPrices provides a mock function with given fields: ctx, priceListID, productIDs
+func (_m *MockGoodsRepository) GetPriceListPrices(ctx context.Context, priceListID int64, productIDs []int64) (map[int64]models.Money, error) {
+	ret := _m.Called(ctx, priceListID, productIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProductsByTemplateID")
+		panic("no return value specified for GetPriceListPrices")
 	}
 
-	var r0 []models.TemplateContent
+	var r0 map[int64]models.Money
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.TemplateContent, error)); ok {
-		return rf(ctx, templateID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64) (map[int64]models.Money, error)); ok {
+		return rf(ctx, priceListID, productIDs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.TemplateContent); ok {
-		r0 = rf(ctx, templateID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64) map[int64]models.Money); ok {
+		r0 = rf(ctx, priceListID, productIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.TemplateContent)
+			r0 = ret.Get(0).(map[int64]models.Money)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, templateID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []int64) error); ok {
+		r1 = rf(ctx, priceListID, productIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -425,52 +660,53 @@ func (_m *MockGoodsRepository) GetProductsByTemplateID(ctx context.Context, temp
 	return r0, r1
 }
 
-// MockGoodsRepository_GetProductsByTemplateID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsByTemplateID'
-type MockGoodsRepository_GetProductsByTemplateID_Call struct {
+// MockGoodsRepository_GetPriceListPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceListPrices'
+type MockGoodsRepository_GetPriceListPrices_Call struct {
 	*mock.Call
 }
 
-// GetProductsByTemplateID is a helper method to define mock.On call
+// GetPriceListPrices is a helper method to define mock.On call
 //   - ctx context.Context
-//   - templateID int64
-func (_e *MockGoodsRepository_Expecter) GetProductsByTemplateID(ctx interface{}, templateID interface{}) *MockGoodsRepository_GetProductsByTemplateID_Call {
-	return &MockGoodsRepository_GetProductsByTemplateID_Call{Call: _e.mock.On("GetProductsByTemplateID", ctx, templateID)}
+//   - priceListID int64
+//   - productIDs []int64
+func (_e *MockGoodsRepository_Expecter) GetPriceListPrices(ctx interface{}, priceListID interface{}, productIDs interface{}) *MockGoodsRepository_GetPriceListPrices_Call {
+	return &MockGoodsRepository_GetPriceListPrices_Call{Call: _e.mock.On("GetPriceListPrices", ctx, priceListID, productIDs)}
 }
 
-func (_c *MockGoodsRepository_GetProductsByTemplateID_Call) Run(run func(ctx context.Context, templateID int64)) *MockGoodsRepository_GetProductsByTemplateID_Call {
+func (_c *MockGoodsRepository_GetPriceListPrices_Call) Run(run func(ctx context.Context, priceListID int64, productIDs []int64)) *MockGoodsRepository_GetPriceListPrices_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].([]int64))
 	})
 	return _c
 }
 
-func (_c *MockGoodsRepository_GetProductsByTemplateID_Call) Return(_a0 []models.TemplateContent, _a1 error) *MockGoodsRepository_GetProductsByTemplateID_Call {
+func (_c *MockGoodsRepository_GetPriceListPrices_Call) Return(_a0 map[int64]models.Money, _a1 error) *MockGoodsRepository_GetPriceListPrices_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockGoodsRepository_GetProductsByTemplateID_Call) RunAndReturn(run func(context.Context, int64) ([]models.TemplateContent, error)) *MockGoodsRepository_GetProductsByTemplateID_Call {
+func (_c *MockGoodsRepository_GetPriceListPrices_Call) RunAndReturn(run func(context.Context, int64, []int64) (map[int64]models.Money, error)) *MockGoodsRepository_GetPriceListPrices_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTemplateByID provides a mock function with given fields: ctx, id
-func (_m *MockGoodsRepository) GetTemplateByID(ctx context.Context, id int64) (models.Template, error) {
+// GetProductByID provides a mock function with given fields: ctx, id
+func (_m *MockGoodsRepository) GetProductByID(ctx context.Context, id int64) (models.Product, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTemplateByID")
+		panic("no return value specified for GetProductByID")
 	}
 
-	var r0 models.Template
+	var r0 models.Product
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.Template, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.Product, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) models.Template); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.Product); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(models.Template)
+		r0 = ret.Get(0).(models.Product)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
@@ -482,58 +718,56 @@ func (_m *MockGoodsRepository) GetTemplateByID(ctx context.Context, id int64) (m
 	return r0, r1
 }
 
-// MockGoodsRepository_GetTemplateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateByID'
-type MockGoodsRepository_GetTemplateByID_Call struct {
+// MockGoodsRepository_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
+type MockGoodsRepository_GetProductByID_Call struct {
 	*mock.Call
 }
 
-// GetTemplateByID is a helper method to define mock.On call
+// GetProductByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int64
-func (_e *MockGoodsRepository_Expecter) GetTemplateByID(ctx interface{}, id interface{}) *MockGoodsRepository_GetTemplateByID_Call {
-	return &MockGoodsRepository_GetTemplateByID_Call{Call: _e.mock.On("GetTemplateByID", ctx, id)}
+func (_e *MockGoodsRepository_Expecter) GetProductByID(ctx interface{}, id interface{}) *MockGoodsRepository_GetProductByID_Call {
+	return &MockGoodsRepository_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id)}
 }
 
-func (_c *MockGoodsRepository_GetTemplateByID_Call) Run(run func(ctx context.Context, id int64)) *MockGoodsRepository_GetTemplateByID_Call {
+func (_c *MockGoodsRepository_GetProductByID_Call) Run(run func(ctx context.Context, id int64)) *MockGoodsRepository_GetProductByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockGoodsRepository_GetTemplateByID_Call) Return(_a0 models.Template, _a1 error) *MockGoodsRepository_GetTemplateByID_Call {
+func (_c *MockGoodsRepository_GetProductByID_Call) Return(_a0 models.Product, _a1 error) *MockGoodsRepository_GetProductByID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockGoodsRepository_GetTemplateByID_Call) RunAndReturn(run func(context.Context, int64) (models.Template, error)) *MockGoodsRepository_GetTemplateByID_Call {
+func (_c *MockGoodsRepository_GetProductByID_Call) RunAndReturn(run func(context.Context, int64) (models.Product, error)) *MockGoodsRepository_GetProductByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListTemplates provides a mock function with given fields: ctx
-func (_m *MockGoodsRepository) ListTemplates(ctx context.Context) ([]models.Template, error) {
-	ret := _m.Called(ctx)
+// GetProductIDByBarcode provides a mock function with given fields: ctx, gtin
+func (_m *MockGoodsRepository) GetProductIDByBarcode(ctx context.Context, gtin string) (int64, error) {
+	ret := _m.Called(ctx, gtin)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListTemplates")
+		panic("no return value specified for GetProductIDByBarcode")
 	}
 
-	var r0 []models.Template
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Template, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, gtin)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []models.Template); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, gtin)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Template)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, gtin)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -541,20 +775,724 @@ func (_m *MockGoodsRepository) ListTemplates(ctx context.Context) ([]models.Temp
 	return r0, r1
 }
 
-// MockGoodsRepository_ListTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplates'
-type MockGoodsRepository_ListTemplates_Call struct {
+// MockGoodsRepository_GetProductIDByBarcode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductIDByBarcode'
+type MockGoodsRepository_GetProductIDByBarcode_Call struct {
 	*mock.Call
 }
 
-// ListTemplates is a helper method to define mock.On call
+// GetProductIDByBarcode is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockGoodsRepository_Expecter) ListTemplates(ctx interface{}) *MockGoodsRepository_ListTemplates_Call {
-	return &MockGoodsRepository_ListTemplates_Call{Call: _e.mock.On("ListTemplates", ctx)}
+//   - gtin string
+func (_e *MockGoodsRepository_Expecter) GetProductIDByBarcode(ctx interface{}, gtin interface{}) *MockGoodsRepository_GetProductIDByBarcode_Call {
+	return &MockGoodsRepository_GetProductIDByBarcode_Call{Call: _e.mock.On("GetProductIDByBarcode", ctx, gtin)}
 }
 
-func (_c *MockGoodsRepository_ListTemplates_Call) Run(run func(ctx context.Context)) *MockGoodsRepository_ListTemplates_Call {
+func (_c *MockGoodsRepository_GetProductIDByBarcode_Call) Run(run func(ctx context.Context, gtin string)) *MockGoodsRepository_GetProductIDByBarcode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductIDByBarcode_Call) Return(_a0 int64, _a1 error) *MockGoodsRepository_GetProductIDByBarcode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductIDByBarcode_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockGoodsRepository_GetProductIDByBarcode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductVariants provides a mock function with given fields: ctx, parentIDs
+func (_m *MockGoodsRepository) GetProductVariants(ctx context.Context, parentIDs []int64) ([]models.Product, error) {
+	ret := _m.Called(ctx, parentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductVariants")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]models.Product, error)); ok {
+		return rf(ctx, parentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []models.Product); ok {
+		r0 = rf(ctx, parentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, parentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_GetProductVariants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductVariants'
+type MockGoodsRepository_GetProductVariants_Call struct {
+	*mock.Call
+}
+
+// GetProductVariants is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentIDs []int64
+func (_e *MockGoodsRepository_Expecter) GetProductVariants(ctx interface{}, parentIDs interface{}) *MockGoodsRepository_GetProductVariants_Call {
+	return &MockGoodsRepository_GetProductVariants_Call{Call: _e.mock.On("GetProductVariants", ctx, parentIDs)}
+}
+
+func (_c *MockGoodsRepository_GetProductVariants_Call) Run(run func(ctx context.Context, parentIDs []int64)) *MockGoodsRepository_GetProductVariants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductVariants_Call) Return(_a0 []models.Product, _a1 error) *MockGoodsRepository_GetProductVariants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductVariants_Call) RunAndReturn(run func(context.Context, []int64) ([]models.Product, error)) *MockGoodsRepository_GetProductVariants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductsByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockGoodsRepository) GetProductsByIDs(ctx context.Context, ids []int64) ([]models.Product, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsByIDs")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]models.Product, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []models.Product); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_GetProductsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsByIDs'
+type MockGoodsRepository_GetProductsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetProductsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *MockGoodsRepository_Expecter) GetProductsByIDs(ctx interface{}, ids interface{}) *MockGoodsRepository_GetProductsByIDs_Call {
+	return &MockGoodsRepository_GetProductsByIDs_Call{Call: _e.mock.On("GetProductsByIDs", ctx, ids)}
+}
+
+func (_c *MockGoodsRepository_GetProductsByIDs_Call) Run(run func(ctx context.Context, ids []int64)) *MockGoodsRepository_GetProductsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductsByIDs_Call) Return(_a0 []models.Product, _a1 error) *MockGoodsRepository_GetProductsByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductsByIDs_Call) RunAndReturn(run func(context.Context, []int64) ([]models.Product, error)) *MockGoodsRepository_GetProductsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductsBySKUs provides a mock function with given fields: ctx, skus
+func (_m *MockGoodsRepository) GetProductsBySKUs(ctx context.Context, skus []string) ([]models.Product, error) {
+	ret := _m.Called(ctx, skus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsBySKUs")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]models.Product, error)); ok {
+		return rf(ctx, skus)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []models.Product); ok {
+		r0 = rf(ctx, skus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, skus)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_GetProductsBySKUs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsBySKUs'
+type MockGoodsRepository_GetProductsBySKUs_Call struct {
+	*mock.Call
+}
+
+// GetProductsBySKUs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - skus []string
+func (_e *MockGoodsRepository_Expecter) GetProductsBySKUs(ctx interface{}, skus interface{}) *MockGoodsRepository_GetProductsBySKUs_Call {
+	return &MockGoodsRepository_GetProductsBySKUs_Call{Call: _e.mock.On("GetProductsBySKUs", ctx, skus)}
+}
+
+func (_c *MockGoodsRepository_GetProductsBySKUs_Call) Run(run func(ctx context.Context, skus []string)) *MockGoodsRepository_GetProductsBySKUs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductsBySKUs_Call) Return(_a0 []models.Product, _a1 error) *MockGoodsRepository_GetProductsBySKUs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductsBySKUs_Call) RunAndReturn(run func(context.Context, []string) ([]models.Product, error)) *MockGoodsRepository_GetProductsBySKUs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductsByTemplateID provides a mock function with given fields: ctx, templateID
+func (_m *MockGoodsRepository) GetProductsByTemplateID(ctx context.Context, templateID int64) ([]models.TemplateContent, error) {
+	ret := _m.Called(ctx, templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsByTemplateID")
+	}
+
+	var r0 []models.TemplateContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.TemplateContent, error)); ok {
+		return rf(ctx, templateID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.TemplateContent); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TemplateContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_GetProductsByTemplateID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsByTemplateID'
+type MockGoodsRepository_GetProductsByTemplateID_Call struct {
+	*mock.Call
+}
+
+// GetProductsByTemplateID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+func (_e *MockGoodsRepository_Expecter) GetProductsByTemplateID(ctx interface{}, templateID interface{}) *MockGoodsRepository_GetProductsByTemplateID_Call {
+	return &MockGoodsRepository_GetProductsByTemplateID_Call{Call: _e.mock.On("GetProductsByTemplateID", ctx, templateID)}
+}
+
+func (_c *MockGoodsRepository_GetProductsByTemplateID_Call) Run(run func(ctx context.Context, templateID int64)) *MockGoodsRepository_GetProductsByTemplateID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductsByTemplateID_Call) Return(_a0 []models.TemplateContent, _a1 error) *MockGoodsRepository_GetProductsByTemplateID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetProductsByTemplateID_Call) RunAndReturn(run func(context.Context, int64) ([]models.TemplateContent, error)) *MockGoodsRepository_GetProductsByTemplateID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateByID provides a mock function with given fields: ctx, id
+func (_m *MockGoodsRepository) GetTemplateByID(ctx context.Context, id int64) (models.Template, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateByID")
+	}
+
+	var r0 models.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.Template, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.Template); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_GetTemplateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateByID'
+type MockGoodsRepository_GetTemplateByID_Call struct {
+	*mock.Call
+}
+
+// GetTemplateByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockGoodsRepository_Expecter) GetTemplateByID(ctx interface{}, id interface{}) *MockGoodsRepository_GetTemplateByID_Call {
+	return &MockGoodsRepository_GetTemplateByID_Call{Call: _e.mock.On("GetTemplateByID", ctx, id)}
+}
+
+func (_c *MockGoodsRepository_GetTemplateByID_Call) Run(run func(ctx context.Context, id int64)) *MockGoodsRepository_GetTemplateByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetTemplateByID_Call) Return(_a0 models.Template, _a1 error) *MockGoodsRepository_GetTemplateByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetTemplateByID_Call) RunAndReturn(run func(context.Context, int64) (models.Template, error)) *MockGoodsRepository_GetTemplateByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateContentProducts provides a mock function with given fields: ctx, templateIDs
+func (_m *MockGoodsRepository) GetTemplateContentProducts(ctx context.Context, templateIDs []int64) (map[int64][]models.TemplateContent, error) {
+	ret := _m.Called(ctx, templateIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateContentProducts")
+	}
+
+	var r0 map[int64][]models.TemplateContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64][]models.TemplateContent, error)); ok {
+		return rf(ctx, templateIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64][]models.TemplateContent); ok {
+		r0 = rf(ctx, templateIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]models.TemplateContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, templateIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_GetTemplateContentProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateContentProducts'
+type MockGoodsRepository_GetTemplateContentProducts_Call struct {
+	*mock.Call
+}
+
+// GetTemplateContentProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateIDs []int64
+func (_e *MockGoodsRepository_Expecter) GetTemplateContentProducts(ctx interface{}, templateIDs interface{}) *MockGoodsRepository_GetTemplateContentProducts_Call {
+	return &MockGoodsRepository_GetTemplateContentProducts_Call{Call: _e.mock.On("GetTemplateContentProducts", ctx, templateIDs)}
+}
+
+func (_c *MockGoodsRepository_GetTemplateContentProducts_Call) Run(run func(ctx context.Context, templateIDs []int64)) *MockGoodsRepository_GetTemplateContentProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetTemplateContentProducts_Call) Return(_a0 map[int64][]models.TemplateContent, _a1 error) *MockGoodsRepository_GetTemplateContentProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetTemplateContentProducts_Call) RunAndReturn(run func(context.Context, []int64) (map[int64][]models.TemplateContent, error)) *MockGoodsRepository_GetTemplateContentProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateContents provides a mock function with given fields: ctx, templateIDs
+func (_m *MockGoodsRepository) GetTemplateContents(ctx context.Context, templateIDs []int64) (map[int64][]models.TemplateContent, error) {
+	ret := _m.Called(ctx, templateIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateContents")
+	}
+
+	var r0 map[int64][]models.TemplateContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64][]models.TemplateContent, error)); ok {
+		return rf(ctx, templateIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64][]models.TemplateContent); ok {
+		r0 = rf(ctx, templateIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]models.TemplateContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, templateIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_GetTemplateContents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateContents'
+type MockGoodsRepository_GetTemplateContents_Call struct {
+	*mock.Call
+}
+
+// GetTemplateContents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateIDs []int64
+func (_e *MockGoodsRepository_Expecter) GetTemplateContents(ctx interface{}, templateIDs interface{}) *MockGoodsRepository_GetTemplateContents_Call {
+	return &MockGoodsRepository_GetTemplateContents_Call{Call: _e.mock.On("GetTemplateContents", ctx, templateIDs)}
+}
+
+func (_c *MockGoodsRepository_GetTemplateContents_Call) Run(run func(ctx context.Context, templateIDs []int64)) *MockGoodsRepository_GetTemplateContents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetTemplateContents_Call) Return(_a0 map[int64][]models.TemplateContent, _a1 error) *MockGoodsRepository_GetTemplateContents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetTemplateContents_Call) RunAndReturn(run func(context.Context, []int64) (map[int64][]models.TemplateContent, error)) *MockGoodsRepository_GetTemplateContents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateRevision provides a mock function with given fields: ctx, templateID, revision
+func (_m *MockGoodsRepository) GetTemplateRevision(ctx context.Context, templateID int64, revision int64) (models.TemplateRevision, error) {
+	ret := _m.Called(ctx, templateID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateRevision")
+	}
+
+	var r0 models.TemplateRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (models.TemplateRevision, error)); ok {
+		return rf(ctx, templateID, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) models.TemplateRevision); ok {
+		r0 = rf(ctx, templateID, revision)
+	} else {
+		r0 = ret.Get(0).(models.TemplateRevision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, templateID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_GetTemplateRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateRevision'
+type MockGoodsRepository_GetTemplateRevision_Call struct {
+	*mock.Call
+}
+
+// GetTemplateRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - revision int64
+func (_e *MockGoodsRepository_Expecter) GetTemplateRevision(ctx interface{}, templateID interface{}, revision interface{}) *MockGoodsRepository_GetTemplateRevision_Call {
+	return &MockGoodsRepository_GetTemplateRevision_Call{Call: _e.mock.On("GetTemplateRevision", ctx, templateID, revision)}
+}
+
+func (_c *MockGoodsRepository_GetTemplateRevision_Call) Run(run func(ctx context.Context, templateID int64, revision int64)) *MockGoodsRepository_GetTemplateRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetTemplateRevision_Call) Return(_a0 models.TemplateRevision, _a1 error) *MockGoodsRepository_GetTemplateRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_GetTemplateRevision_Call) RunAndReturn(run func(context.Context, int64, int64) (models.TemplateRevision, error)) *MockGoodsRepository_GetTemplateRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportTemplates provides a mock function with given fields: ctx, templates
+func (_m *MockGoodsRepository) ImportTemplates(ctx context.Context, templates []models.Template) ([]models.TemplateImportResult, error) {
+	ret := _m.Called(ctx, templates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTemplates")
+	}
+
+	var r0 []models.TemplateImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Template) ([]models.TemplateImportResult, error)); ok {
+		return rf(ctx, templates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Template) []models.TemplateImportResult); ok {
+		r0 = rf(ctx, templates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TemplateImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.Template) error); ok {
+		r1 = rf(ctx, templates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_ImportTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTemplates'
+type MockGoodsRepository_ImportTemplates_Call struct {
+	*mock.Call
+}
+
+// ImportTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templates []models.Template
+func (_e *MockGoodsRepository_Expecter) ImportTemplates(ctx interface{}, templates interface{}) *MockGoodsRepository_ImportTemplates_Call {
+	return &MockGoodsRepository_ImportTemplates_Call{Call: _e.mock.On("ImportTemplates", ctx, templates)}
+}
+
+func (_c *MockGoodsRepository_ImportTemplates_Call) Run(run func(ctx context.Context, templates []models.Template)) *MockGoodsRepository_ImportTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.Template))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_ImportTemplates_Call) Return(_a0 []models.TemplateImportResult, _a1 error) *MockGoodsRepository_ImportTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_ImportTemplates_Call) RunAndReturn(run func(context.Context, []models.Template) ([]models.TemplateImportResult, error)) *MockGoodsRepository_ImportTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPriceLists provides a mock function with given fields: ctx
+func (_m *MockGoodsRepository) ListPriceLists(ctx context.Context) ([]models.PriceList, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPriceLists")
+	}
+
+	var r0 []models.PriceList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.PriceList, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.PriceList); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PriceList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_ListPriceLists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPriceLists'
+type MockGoodsRepository_ListPriceLists_Call struct {
+	*mock.Call
+}
+
+// ListPriceLists is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockGoodsRepository_Expecter) ListPriceLists(ctx interface{}) *MockGoodsRepository_ListPriceLists_Call {
+	return &MockGoodsRepository_ListPriceLists_Call{Call: _e.mock.On("ListPriceLists", ctx)}
+}
+
+func (_c *MockGoodsRepository_ListPriceLists_Call) Run(run func(ctx context.Context)) *MockGoodsRepository_ListPriceLists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_ListPriceLists_Call) Return(_a0 []models.PriceList, _a1 error) *MockGoodsRepository_ListPriceLists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_ListPriceLists_Call) RunAndReturn(run func(context.Context) ([]models.PriceList, error)) *MockGoodsRepository_ListPriceLists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTemplateRevisions provides a mock function with given fields: ctx, templateID
+func (_m *MockGoodsRepository) ListTemplateRevisions(ctx context.Context, templateID int64) ([]models.TemplateRevision, error) {
+	ret := _m.Called(ctx, templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplateRevisions")
+	}
+
+	var r0 []models.TemplateRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.TemplateRevision, error)); ok {
+		return rf(ctx, templateID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.TemplateRevision); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TemplateRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_ListTemplateRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplateRevisions'
+type MockGoodsRepository_ListTemplateRevisions_Call struct {
+	*mock.Call
+}
+
+// ListTemplateRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+func (_e *MockGoodsRepository_Expecter) ListTemplateRevisions(ctx interface{}, templateID interface{}) *MockGoodsRepository_ListTemplateRevisions_Call {
+	return &MockGoodsRepository_ListTemplateRevisions_Call{Call: _e.mock.On("ListTemplateRevisions", ctx, templateID)}
+}
+
+func (_c *MockGoodsRepository_ListTemplateRevisions_Call) Run(run func(ctx context.Context, templateID int64)) *MockGoodsRepository_ListTemplateRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_ListTemplateRevisions_Call) Return(_a0 []models.TemplateRevision, _a1 error) *MockGoodsRepository_ListTemplateRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_ListTemplateRevisions_Call) RunAndReturn(run func(context.Context, int64) ([]models.TemplateRevision, error)) *MockGoodsRepository_ListTemplateRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTemplates provides a mock function with given fields: ctx
+func (_m *MockGoodsRepository) ListTemplates(ctx context.Context) ([]models.Template, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplates")
+	}
+
+	var r0 []models.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Template, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Template); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_ListTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplates'
+type MockGoodsRepository_ListTemplates_Call struct {
+	*mock.Call
+}
+
+// ListTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockGoodsRepository_Expecter) ListTemplates(ctx interface{}) *MockGoodsRepository_ListTemplates_Call {
+	return &MockGoodsRepository_ListTemplates_Call{Call: _e.mock.On("ListTemplates", ctx)}
+}
+
+func (_c *MockGoodsRepository_ListTemplates_Call) Run(run func(ctx context.Context)) *MockGoodsRepository_ListTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -630,6 +1568,102 @@ func (_c *MockGoodsRepository_SearchTemplates_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// SetPriceListPrices provides a mock function with given fields: ctx, priceListID, prices
+func (_m *MockGoodsRepository) SetPriceListPrices(ctx context.Context, priceListID int64, prices []models.ProductPrice) error {
+	ret := _m.Called(ctx, priceListID, prices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPriceListPrices")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []models.ProductPrice) error); ok {
+		r0 = rf(ctx, priceListID, prices)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGoodsRepository_SetPriceListPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPriceListPrices'
+type MockGoodsRepository_SetPriceListPrices_Call struct {
+	*mock.Call
+}
+
+// SetPriceListPrices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - priceListID int64
+//   - prices []models.ProductPrice
+func (_e *MockGoodsRepository_Expecter) SetPriceListPrices(ctx interface{}, priceListID interface{}, prices interface{}) *MockGoodsRepository_SetPriceListPrices_Call {
+	return &MockGoodsRepository_SetPriceListPrices_Call{Call: _e.mock.On("SetPriceListPrices", ctx, priceListID, prices)}
+}
+
+func (_c *MockGoodsRepository_SetPriceListPrices_Call) Run(run func(ctx context.Context, priceListID int64, prices []models.ProductPrice)) *MockGoodsRepository_SetPriceListPrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]models.ProductPrice))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_SetPriceListPrices_Call) Return(_a0 error) *MockGoodsRepository_SetPriceListPrices_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGoodsRepository_SetPriceListPrices_Call) RunAndReturn(run func(context.Context, int64, []models.ProductPrice) error) *MockGoodsRepository_SetPriceListPrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetProductImage provides a mock function with given fields: ctx, id, image
+func (_m *MockGoodsRepository) SetProductImage(ctx context.Context, id int64, image models.ProductImage) error {
+	ret := _m.Called(ctx, id, image)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProductImage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.ProductImage) error); ok {
+		r0 = rf(ctx, id, image)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGoodsRepository_SetProductImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetProductImage'
+type MockGoodsRepository_SetProductImage_Call struct {
+	*mock.Call
+}
+
+// SetProductImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - image models.ProductImage
+func (_e *MockGoodsRepository_Expecter) SetProductImage(ctx interface{}, id interface{}, image interface{}) *MockGoodsRepository_SetProductImage_Call {
+	return &MockGoodsRepository_SetProductImage_Call{Call: _e.mock.On("SetProductImage", ctx, id, image)}
+}
+
+func (_c *MockGoodsRepository_SetProductImage_Call) Run(run func(ctx context.Context, id int64, image models.ProductImage)) *MockGoodsRepository_SetProductImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.ProductImage))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_SetProductImage_Call) Return(_a0 error) *MockGoodsRepository_SetProductImage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGoodsRepository_SetProductImage_Call) RunAndReturn(run func(context.Context, int64, models.ProductImage) error) *MockGoodsRepository_SetProductImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateProduct provides a mock function with given fields: ctx, p
 func (_m *MockGoodsRepository) UpdateProduct(ctx context.Context, p *models.Product) error {
 	ret := _m.Called(ctx, p)
@@ -677,6 +1711,112 @@ func (_c *MockGoodsRepository_UpdateProduct_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// UpdateTemplate provides a mock function with given fields: ctx, template
+func (_m *MockGoodsRepository) UpdateTemplate(ctx context.Context, template *models.Template) error {
+	ret := _m.Called(ctx, template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Template) error); ok {
+		r0 = rf(ctx, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGoodsRepository_UpdateTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplate'
+type MockGoodsRepository_UpdateTemplate_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - template *models.Template
+func (_e *MockGoodsRepository_Expecter) UpdateTemplate(ctx interface{}, template interface{}) *MockGoodsRepository_UpdateTemplate_Call {
+	return &MockGoodsRepository_UpdateTemplate_Call{Call: _e.mock.On("UpdateTemplate", ctx, template)}
+}
+
+func (_c *MockGoodsRepository_UpdateTemplate_Call) Run(run func(ctx context.Context, template *models.Template)) *MockGoodsRepository_UpdateTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Template))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_UpdateTemplate_Call) Return(_a0 error) *MockGoodsRepository_UpdateTemplate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGoodsRepository_UpdateTemplate_Call) RunAndReturn(run func(context.Context, *models.Template) error) *MockGoodsRepository_UpdateTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTemplateContent provides a mock function with given fields: ctx, templateID, content, version
+func (_m *MockGoodsRepository) UpdateTemplateContent(ctx context.Context, templateID int64, content models.TemplateContent, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, content, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplateContent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) (int64, error)); ok {
+		return rf(ctx, templateID, content, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) int64); ok {
+		r0 = rf(ctx, templateID, content, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TemplateContent, int64) error); ok {
+		r1 = rf(ctx, templateID, content, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoodsRepository_UpdateTemplateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplateContent'
+type MockGoodsRepository_UpdateTemplateContent_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - content models.TemplateContent
+//   - version int64
+func (_e *MockGoodsRepository_Expecter) UpdateTemplateContent(ctx interface{}, templateID interface{}, content interface{}, version interface{}) *MockGoodsRepository_UpdateTemplateContent_Call {
+	return &MockGoodsRepository_UpdateTemplateContent_Call{Call: _e.mock.On("UpdateTemplateContent", ctx, templateID, content, version)}
+}
+
+func (_c *MockGoodsRepository_UpdateTemplateContent_Call) Run(run func(ctx context.Context, templateID int64, content models.TemplateContent, version int64)) *MockGoodsRepository_UpdateTemplateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TemplateContent), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockGoodsRepository_UpdateTemplateContent_Call) Return(_a0 int64, _a1 error) *MockGoodsRepository_UpdateTemplateContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoodsRepository_UpdateTemplateContent_Call) RunAndReturn(run func(context.Context, int64, models.TemplateContent, int64) (int64, error)) *MockGoodsRepository_UpdateTemplateContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockGoodsRepository creates a new instance of MockGoodsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockGoodsRepository(t interface {
@@ -79,17 +79,17 @@ func (_c *MockProductRepository_CreateProduct_Call) RunAndReturn(run func(contex
 	return _c
 }
 
-// DeleteProduct provides a mock function with given fields: ctx, id
-func (_m *MockProductRepository) DeleteProduct(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// DeleteProduct provides a mock function with given fields: ctx, id, version
+func (_m *MockProductRepository) DeleteProduct(ctx context.Context, id int64, version int64) error {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteProduct")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -105,13 +105,14 @@ type MockProductRepository_DeleteProduct_Call struct {
 // DeleteProduct is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int64
-func (_e *MockProductRepository_Expecter) DeleteProduct(ctx interface{}, id interface{}) *MockProductRepository_DeleteProduct_Call {
-	return &MockProductRepository_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id)}
+//   - version int64
+func (_e *MockProductRepository_Expecter) DeleteProduct(ctx interface{}, id interface{}, version interface{}) *MockProductRepository_DeleteProduct_Call {
+	return &MockProductRepository_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id, version)}
 }
 
-func (_c *MockProductRepository_DeleteProduct_Call) Run(run func(ctx context.Context, id int64)) *MockProductRepository_DeleteProduct_Call {
+func (_c *MockProductRepository_DeleteProduct_Call) Run(run func(ctx context.Context, id int64, version int64)) *MockProductRepository_DeleteProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
 	})
 	return _c
 }
@@ -121,7 +122,7 @@ func (_c *MockProductRepository_DeleteProduct_Call) Return(_a0 error) *MockProdu
 	return _c
 }
 
-func (_c *MockProductRepository_DeleteProduct_Call) RunAndReturn(run func(context.Context, int64) error) *MockProductRepository_DeleteProduct_Call {
+func (_c *MockProductRepository_DeleteProduct_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockProductRepository_DeleteProduct_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -241,6 +242,288 @@ func (_c *MockProductRepository_GetProductByID_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetProductIDByBarcode provides a mock function with given fields: ctx, gtin
+func (_m *MockProductRepository) GetProductIDByBarcode(ctx context.Context, gtin string) (int64, error) {
+	ret := _m.Called(ctx, gtin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductIDByBarcode")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, gtin)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, gtin)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, gtin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductRepository_GetProductIDByBarcode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductIDByBarcode'
+type MockProductRepository_GetProductIDByBarcode_Call struct {
+	*mock.Call
+}
+
+// GetProductIDByBarcode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - gtin string
+func (_e *MockProductRepository_Expecter) GetProductIDByBarcode(ctx interface{}, gtin interface{}) *MockProductRepository_GetProductIDByBarcode_Call {
+	return &MockProductRepository_GetProductIDByBarcode_Call{Call: _e.mock.On("GetProductIDByBarcode", ctx, gtin)}
+}
+
+func (_c *MockProductRepository_GetProductIDByBarcode_Call) Run(run func(ctx context.Context, gtin string)) *MockProductRepository_GetProductIDByBarcode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_GetProductIDByBarcode_Call) Return(_a0 int64, _a1 error) *MockProductRepository_GetProductIDByBarcode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductRepository_GetProductIDByBarcode_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockProductRepository_GetProductIDByBarcode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductVariants provides a mock function with given fields: ctx, parentIDs
+func (_m *MockProductRepository) GetProductVariants(ctx context.Context, parentIDs []int64) ([]models.Product, error) {
+	ret := _m.Called(ctx, parentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductVariants")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]models.Product, error)); ok {
+		return rf(ctx, parentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []models.Product); ok {
+		r0 = rf(ctx, parentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, parentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductRepository_GetProductVariants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductVariants'
+type MockProductRepository_GetProductVariants_Call struct {
+	*mock.Call
+}
+
+// GetProductVariants is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentIDs []int64
+func (_e *MockProductRepository_Expecter) GetProductVariants(ctx interface{}, parentIDs interface{}) *MockProductRepository_GetProductVariants_Call {
+	return &MockProductRepository_GetProductVariants_Call{Call: _e.mock.On("GetProductVariants", ctx, parentIDs)}
+}
+
+func (_c *MockProductRepository_GetProductVariants_Call) Run(run func(ctx context.Context, parentIDs []int64)) *MockProductRepository_GetProductVariants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_GetProductVariants_Call) Return(_a0 []models.Product, _a1 error) *MockProductRepository_GetProductVariants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductRepository_GetProductVariants_Call) RunAndReturn(run func(context.Context, []int64) ([]models.Product, error)) *MockProductRepository_GetProductVariants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductsByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockProductRepository) GetProductsByIDs(ctx context.Context, ids []int64) ([]models.Product, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsByIDs")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]models.Product, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []models.Product); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductRepository_GetProductsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsByIDs'
+type MockProductRepository_GetProductsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetProductsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *MockProductRepository_Expecter) GetProductsByIDs(ctx interface{}, ids interface{}) *MockProductRepository_GetProductsByIDs_Call {
+	return &MockProductRepository_GetProductsByIDs_Call{Call: _e.mock.On("GetProductsByIDs", ctx, ids)}
+}
+
+func (_c *MockProductRepository_GetProductsByIDs_Call) Run(run func(ctx context.Context, ids []int64)) *MockProductRepository_GetProductsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_GetProductsByIDs_Call) Return(_a0 []models.Product, _a1 error) *MockProductRepository_GetProductsByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductRepository_GetProductsByIDs_Call) RunAndReturn(run func(context.Context, []int64) ([]models.Product, error)) *MockProductRepository_GetProductsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductsBySKUs provides a mock function with given fields: ctx, skus
+func (_m *MockProductRepository) GetProductsBySKUs(ctx context.Context, skus []string) ([]models.Product, error) {
+	ret := _m.Called(ctx, skus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsBySKUs")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]models.Product, error)); ok {
+		return rf(ctx, skus)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []models.Product); ok {
+		r0 = rf(ctx, skus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, skus)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductRepository_GetProductsBySKUs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsBySKUs'
+type MockProductRepository_GetProductsBySKUs_Call struct {
+	*mock.Call
+}
+
+// GetProductsBySKUs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - skus []string
+func (_e *MockProductRepository_Expecter) GetProductsBySKUs(ctx interface{}, skus interface{}) *MockProductRepository_GetProductsBySKUs_Call {
+	return &MockProductRepository_GetProductsBySKUs_Call{Call: _e.mock.On("GetProductsBySKUs", ctx, skus)}
+}
+
+func (_c *MockProductRepository_GetProductsBySKUs_Call) Run(run func(ctx context.Context, skus []string)) *MockProductRepository_GetProductsBySKUs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_GetProductsBySKUs_Call) Return(_a0 []models.Product, _a1 error) *MockProductRepository_GetProductsBySKUs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductRepository_GetProductsBySKUs_Call) RunAndReturn(run func(context.Context, []string) ([]models.Product, error)) *MockProductRepository_GetProductsBySKUs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetProductImage provides a mock function with given fields: ctx, id, image
+func (_m *MockProductRepository) SetProductImage(ctx context.Context, id int64, image models.ProductImage) error {
+	ret := _m.Called(ctx, id, image)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProductImage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.ProductImage) error); ok {
+		r0 = rf(ctx, id, image)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProductRepository_SetProductImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetProductImage'
+type MockProductRepository_SetProductImage_Call struct {
+	*mock.Call
+}
+
+// SetProductImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - image models.ProductImage
+func (_e *MockProductRepository_Expecter) SetProductImage(ctx interface{}, id interface{}, image interface{}) *MockProductRepository_SetProductImage_Call {
+	return &MockProductRepository_SetProductImage_Call{Call: _e.mock.On("SetProductImage", ctx, id, image)}
+}
+
+func (_c *MockProductRepository_SetProductImage_Call) Run(run func(ctx context.Context, id int64, image models.ProductImage)) *MockProductRepository_SetProductImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.ProductImage))
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_SetProductImage_Call) Return(_a0 error) *MockProductRepository_SetProductImage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProductRepository_SetProductImage_Call) RunAndReturn(run func(context.Context, int64, models.ProductImage) error) *MockProductRepository_SetProductImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateProduct provides a mock function with given fields: ctx, p
 func (_m *MockProductRepository) UpdateProduct(ctx context.Context, p *models.Product) error {
 	ret := _m.Called(ctx, p)
@@ -79,27 +79,1070 @@ func (_c *MockService_AddTemplate_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
+// AddTemplateLine provides a mock function with given fields: ctx, templateID, line, version
+func (_m *MockService) AddTemplateLine(ctx context.Context, templateID int64, line models.TemplateContent, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, line, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTemplateLine")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) (int64, error)); ok {
+		return rf(ctx, templateID, line, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) int64); ok {
+		r0 = rf(ctx, templateID, line, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TemplateContent, int64) error); ok {
+		r1 = rf(ctx, templateID, line, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_AddTemplateLine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTemplateLine'
+type MockService_AddTemplateLine_Call struct {
+	*mock.Call
+}
+
+// AddTemplateLine is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - line models.TemplateContent
+//   - version int64
+func (_e *MockService_Expecter) AddTemplateLine(ctx interface{}, templateID interface{}, line interface{}, version interface{}) *MockService_AddTemplateLine_Call {
+	return &MockService_AddTemplateLine_Call{Call: _e.mock.On("AddTemplateLine", ctx, templateID, line, version)}
+}
+
+func (_c *MockService_AddTemplateLine_Call) Run(run func(ctx context.Context, templateID int64, line models.TemplateContent, version int64)) *MockService_AddTemplateLine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TemplateContent), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_AddTemplateLine_Call) Return(_a0 int64, _a1 error) *MockService_AddTemplateLine_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_AddTemplateLine_Call) RunAndReturn(run func(context.Context, int64, models.TemplateContent, int64) (int64, error)) *MockService_AddTemplateLine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BuildPickList provides a mock function with given fields: ctx, entries, query
+func (_m *MockService) BuildPickList(ctx context.Context, entries []models.PickListEntry, query models.PickListQuery) ([]models.PickListItem, error) {
+	ret := _m.Called(ctx, entries, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildPickList")
+	}
+
+	var r0 []models.PickListItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.PickListEntry, models.PickListQuery) ([]models.PickListItem, error)); ok {
+		return rf(ctx, entries, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.PickListEntry, models.PickListQuery) []models.PickListItem); ok {
+		r0 = rf(ctx, entries, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PickListItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.PickListEntry, models.PickListQuery) error); ok {
+		r1 = rf(ctx, entries, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_BuildPickList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildPickList'
+type MockService_BuildPickList_Call struct {
+	*mock.Call
+}
+
+// BuildPickList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entries []models.PickListEntry
+//   - query models.PickListQuery
+func (_e *MockService_Expecter) BuildPickList(ctx interface{}, entries interface{}, query interface{}) *MockService_BuildPickList_Call {
+	return &MockService_BuildPickList_Call{Call: _e.mock.On("BuildPickList", ctx, entries, query)}
+}
+
+func (_c *MockService_BuildPickList_Call) Run(run func(ctx context.Context, entries []models.PickListEntry, query models.PickListQuery)) *MockService_BuildPickList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.PickListEntry), args[2].(models.PickListQuery))
+	})
+	return _c
+}
+
+func (_c *MockService_BuildPickList_Call) Return(_a0 []models.PickListItem, _a1 error) *MockService_BuildPickList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_BuildPickList_Call) RunAndReturn(run func(context.Context, []models.PickListEntry, models.PickListQuery) ([]models.PickListItem, error)) *MockService_BuildPickList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloneTemplate provides a mock function with given fields: ctx, id, name
+func (_m *MockService) CloneTemplate(ctx context.Context, id int64, name string) (int64, error) {
+	ret := _m.Called(ctx, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneTemplate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return rf(ctx, id, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = rf(ctx, id, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_CloneTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloneTemplate'
+type MockService_CloneTemplate_Call struct {
+	*mock.Call
+}
+
+// CloneTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - name string
+func (_e *MockService_Expecter) CloneTemplate(ctx interface{}, id interface{}, name interface{}) *MockService_CloneTemplate_Call {
+	return &MockService_CloneTemplate_Call{Call: _e.mock.On("CloneTemplate", ctx, id, name)}
+}
+
+func (_c *MockService_CloneTemplate_Call) Run(run func(ctx context.Context, id int64, name string)) *MockService_CloneTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_CloneTemplate_Call) Return(_a0 int64, _a1 error) *MockService_CloneTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_CloneTemplate_Call) RunAndReturn(run func(context.Context, int64, string) (int64, error)) *MockService_CloneTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePriceList provides a mock function with given fields: ctx, priceList
+func (_m *MockService) CreatePriceList(ctx context.Context, priceList *models.PriceList) (int64, error) {
+	ret := _m.Called(ctx, priceList)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePriceList")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PriceList) (int64, error)); ok {
+		return rf(ctx, priceList)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PriceList) int64); ok {
+		r0 = rf(ctx, priceList)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PriceList) error); ok {
+		r1 = rf(ctx, priceList)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_CreatePriceList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePriceList'
+type MockService_CreatePriceList_Call struct {
+	*mock.Call
+}
+
+// CreatePriceList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - priceList *models.PriceList
+func (_e *MockService_Expecter) CreatePriceList(ctx interface{}, priceList interface{}) *MockService_CreatePriceList_Call {
+	return &MockService_CreatePriceList_Call{Call: _e.mock.On("CreatePriceList", ctx, priceList)}
+}
+
+func (_c *MockService_CreatePriceList_Call) Run(run func(ctx context.Context, priceList *models.PriceList)) *MockService_CreatePriceList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PriceList))
+	})
+	return _c
+}
+
+func (_c *MockService_CreatePriceList_Call) Return(_a0 int64, _a1 error) *MockService_CreatePriceList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_CreatePriceList_Call) RunAndReturn(run func(context.Context, *models.PriceList) (int64, error)) *MockService_CreatePriceList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateProduct provides a mock function with given fields: ctx, p
 func (_m *MockService) CreateProduct(ctx context.Context, p *models.Product) (int64, error) {
 	ret := _m.Called(ctx, p)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateProduct")
+		panic("no return value specified for CreateProduct")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Product) (int64, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Product) int64); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Product) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_CreateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProduct'
+type MockService_CreateProduct_Call struct {
+	*mock.Call
+}
+
+// CreateProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *models.Product
+func (_e *MockService_Expecter) CreateProduct(ctx interface{}, p interface{}) *MockService_CreateProduct_Call {
+	return &MockService_CreateProduct_Call{Call: _e.mock.On("CreateProduct", ctx, p)}
+}
+
+func (_c *MockService_CreateProduct_Call) Run(run func(ctx context.Context, p *models.Product)) *MockService_CreateProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Product))
+	})
+	return _c
+}
+
+func (_c *MockService_CreateProduct_Call) Return(_a0 int64, _a1 error) *MockService_CreateProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_CreateProduct_Call) RunAndReturn(run func(context.Context, *models.Product) (int64, error)) *MockService_CreateProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProduct provides a mock function with given fields: ctx, id, version
+func (_m *MockService) DeleteProduct(ctx context.Context, id int64, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
+type MockService_DeleteProduct_Call struct {
+	*mock.Call
+}
+
+// DeleteProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int64
+func (_e *MockService_Expecter) DeleteProduct(ctx interface{}, id interface{}, version interface{}) *MockService_DeleteProduct_Call {
+	return &MockService_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id, version)}
+}
+
+func (_c *MockService_DeleteProduct_Call) Run(run func(ctx context.Context, id int64, version int64)) *MockService_DeleteProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteProduct_Call) Return(_a0 error) *MockService_DeleteProduct_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_DeleteProduct_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_DeleteProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTemplate provides a mock function with given fields: ctx, id, version, dryRun
+func (_m *MockService) DeleteTemplate(ctx context.Context, id int64, version int64, dryRun bool) (models.Template, error) {
+	ret := _m.Called(ctx, id, version, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTemplate")
+	}
+
+	var r0 models.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bool) (models.Template, error)); ok {
+		return rf(ctx, id, version, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bool) models.Template); ok {
+		r0 = rf(ctx, id, version, dryRun)
+	} else {
+		r0 = ret.Get(0).(models.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, bool) error); ok {
+		r1 = rf(ctx, id, version, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_DeleteTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTemplate'
+type MockService_DeleteTemplate_Call struct {
+	*mock.Call
+}
+
+// DeleteTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int64
+//   - dryRun bool
+func (_e *MockService_Expecter) DeleteTemplate(ctx interface{}, id interface{}, version interface{}, dryRun interface{}) *MockService_DeleteTemplate_Call {
+	return &MockService_DeleteTemplate_Call{Call: _e.mock.On("DeleteTemplate", ctx, id, version, dryRun)}
+}
+
+func (_c *MockService_DeleteTemplate_Call) Run(run func(ctx context.Context, id int64, version int64, dryRun bool)) *MockService_DeleteTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteTemplate_Call) Return(_a0 models.Template, _a1 error) *MockService_DeleteTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_DeleteTemplate_Call) RunAndReturn(run func(context.Context, int64, int64, bool) (models.Template, error)) *MockService_DeleteTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiffTemplates provides a mock function with given fields: ctx, a, b, query
+func (_m *MockService) DiffTemplates(ctx context.Context, a int64, b int64, query models.TemplateQuery) (models.TemplateDiff, error) {
+	ret := _m.Called(ctx, a, b, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffTemplates")
+	}
+
+	var r0 models.TemplateDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, models.TemplateQuery) (models.TemplateDiff, error)); ok {
+		return rf(ctx, a, b, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, models.TemplateQuery) models.TemplateDiff); ok {
+		r0 = rf(ctx, a, b, query)
+	} else {
+		r0 = ret.Get(0).(models.TemplateDiff)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, models.TemplateQuery) error); ok {
+		r1 = rf(ctx, a, b, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_DiffTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffTemplates'
+type MockService_DiffTemplates_Call struct {
+	*mock.Call
+}
+
+// DiffTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - a int64
+//   - b int64
+//   - query models.TemplateQuery
+func (_e *MockService_Expecter) DiffTemplates(ctx interface{}, a interface{}, b interface{}, query interface{}) *MockService_DiffTemplates_Call {
+	return &MockService_DiffTemplates_Call{Call: _e.mock.On("DiffTemplates", ctx, a, b, query)}
+}
+
+func (_c *MockService_DiffTemplates_Call) Run(run func(ctx context.Context, a int64, b int64, query models.TemplateQuery)) *MockService_DiffTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(models.TemplateQuery))
+	})
+	return _c
+}
+
+func (_c *MockService_DiffTemplates_Call) Return(_a0 models.TemplateDiff, _a1 error) *MockService_DiffTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_DiffTemplates_Call) RunAndReturn(run func(context.Context, int64, int64, models.TemplateQuery) (models.TemplateDiff, error)) *MockService_DiffTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportTemplates provides a mock function with given fields: ctx, ids
+func (_m *MockService) ExportTemplates(ctx context.Context, ids []int64) ([]models.PortableTemplate, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTemplates")
+	}
+
+	var r0 []models.PortableTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]models.PortableTemplate, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []models.PortableTemplate); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PortableTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ExportTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTemplates'
+type MockService_ExportTemplates_Call struct {
+	*mock.Call
+}
+
+// ExportTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *MockService_Expecter) ExportTemplates(ctx interface{}, ids interface{}) *MockService_ExportTemplates_Call {
+	return &MockService_ExportTemplates_Call{Call: _e.mock.On("ExportTemplates", ctx, ids)}
+}
+
+func (_c *MockService_ExportTemplates_Call) Run(run func(ctx context.Context, ids []int64)) *MockService_ExportTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockService_ExportTemplates_Call) Return(_a0 []models.PortableTemplate, _a1 error) *MockService_ExportTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ExportTemplates_Call) RunAndReturn(run func(context.Context, []int64) ([]models.PortableTemplate, error)) *MockService_ExportTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllProducts provides a mock function with given fields: ctx, query
+func (_m *MockService) GetAllProducts(ctx context.Context, query models.ProductQuery) ([]models.Product, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllProducts")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ProductQuery) ([]models.Product, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ProductQuery) []models.Product); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ProductQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetAllProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllProducts'
+type MockService_GetAllProducts_Call struct {
+	*mock.Call
+}
+
+// GetAllProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query models.ProductQuery
+func (_e *MockService_Expecter) GetAllProducts(ctx interface{}, query interface{}) *MockService_GetAllProducts_Call {
+	return &MockService_GetAllProducts_Call{Call: _e.mock.On("GetAllProducts", ctx, query)}
+}
+
+func (_c *MockService_GetAllProducts_Call) Run(run func(ctx context.Context, query models.ProductQuery)) *MockService_GetAllProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ProductQuery))
+	})
+	return _c
+}
+
+func (_c *MockService_GetAllProducts_Call) Return(_a0 []models.Product, _a1 error) *MockService_GetAllProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetAllProducts_Call) RunAndReturn(run func(context.Context, models.ProductQuery) ([]models.Product, error)) *MockService_GetAllProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAvailableProducts provides a mock function with given fields: ctx, sale, query
+func (_m *MockService) GetAvailableProducts(ctx context.Context, sale models.SaleContext, query models.ProductQuery) ([]models.Product, error) {
+	ret := _m.Called(ctx, sale, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailableProducts")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SaleContext, models.ProductQuery) ([]models.Product, error)); ok {
+		return rf(ctx, sale, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SaleContext, models.ProductQuery) []models.Product); ok {
+		r0 = rf(ctx, sale, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SaleContext, models.ProductQuery) error); ok {
+		r1 = rf(ctx, sale, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetAvailableProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAvailableProducts'
+type MockService_GetAvailableProducts_Call struct {
+	*mock.Call
+}
+
+// GetAvailableProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sale models.SaleContext
+//   - query models.ProductQuery
+func (_e *MockService_Expecter) GetAvailableProducts(ctx interface{}, sale interface{}, query interface{}) *MockService_GetAvailableProducts_Call {
+	return &MockService_GetAvailableProducts_Call{Call: _e.mock.On("GetAvailableProducts", ctx, sale, query)}
+}
+
+func (_c *MockService_GetAvailableProducts_Call) Run(run func(ctx context.Context, sale models.SaleContext, query models.ProductQuery)) *MockService_GetAvailableProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.SaleContext), args[2].(models.ProductQuery))
+	})
+	return _c
+}
+
+func (_c *MockService_GetAvailableProducts_Call) Return(_a0 []models.Product, _a1 error) *MockService_GetAvailableProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetAvailableProducts_Call) RunAndReturn(run func(context.Context, models.SaleContext, models.ProductQuery) ([]models.Product, error)) *MockService_GetAvailableProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImage provides a mock function with given fields: ctx, key
+func (_m *MockService) GetImage(ctx context.Context, key string) (models.Blob, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImage")
+	}
+
+	var r0 models.Blob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Blob, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Blob); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(models.Blob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImage'
+type MockService_GetImage_Call struct {
+	*mock.Call
+}
+
+// GetImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockService_Expecter) GetImage(ctx interface{}, key interface{}) *MockService_GetImage_Call {
+	return &MockService_GetImage_Call{Call: _e.mock.On("GetImage", ctx, key)}
+}
+
+func (_c *MockService_GetImage_Call) Run(run func(ctx context.Context, key string)) *MockService_GetImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_GetImage_Call) Return(_a0 models.Blob, _a1 error) *MockService_GetImage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetImage_Call) RunAndReturn(run func(context.Context, string) (models.Blob, error)) *MockService_GetImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductByBarcode provides a mock function with given fields: ctx, code, query
+func (_m *MockService) GetProductByBarcode(ctx context.Context, code string, query models.ProductQuery) (models.Product, error) {
+	ret := _m.Called(ctx, code, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByBarcode")
+	}
+
+	var r0 models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ProductQuery) (models.Product, error)); ok {
+		return rf(ctx, code, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ProductQuery) models.Product); ok {
+		r0 = rf(ctx, code, query)
+	} else {
+		r0 = ret.Get(0).(models.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ProductQuery) error); ok {
+		r1 = rf(ctx, code, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetProductByBarcode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByBarcode'
+type MockService_GetProductByBarcode_Call struct {
+	*mock.Call
+}
+
+// GetProductByBarcode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - query models.ProductQuery
+func (_e *MockService_Expecter) GetProductByBarcode(ctx interface{}, code interface{}, query interface{}) *MockService_GetProductByBarcode_Call {
+	return &MockService_GetProductByBarcode_Call{Call: _e.mock.On("GetProductByBarcode", ctx, code, query)}
+}
+
+func (_c *MockService_GetProductByBarcode_Call) Run(run func(ctx context.Context, code string, query models.ProductQuery)) *MockService_GetProductByBarcode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ProductQuery))
+	})
+	return _c
+}
+
+func (_c *MockService_GetProductByBarcode_Call) Return(_a0 models.Product, _a1 error) *MockService_GetProductByBarcode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetProductByBarcode_Call) RunAndReturn(run func(context.Context, string, models.ProductQuery) (models.Product, error)) *MockService_GetProductByBarcode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductByID provides a mock function with given fields: ctx, id, query
+func (_m *MockService) GetProductByID(ctx context.Context, id int64, query models.ProductQuery) (models.Product, error) {
+	ret := _m.Called(ctx, id, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByID")
+	}
+
+	var r0 models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.ProductQuery) (models.Product, error)); ok {
+		return rf(ctx, id, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.ProductQuery) models.Product); ok {
+		r0 = rf(ctx, id, query)
+	} else {
+		r0 = ret.Get(0).(models.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.ProductQuery) error); ok {
+		r1 = rf(ctx, id, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
+type MockService_GetProductByID_Call struct {
+	*mock.Call
+}
+
+// GetProductByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - query models.ProductQuery
+func (_e *MockService_Expecter) GetProductByID(ctx interface{}, id interface{}, query interface{}) *MockService_GetProductByID_Call {
+	return &MockService_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id, query)}
+}
+
+func (_c *MockService_GetProductByID_Call) Run(run func(ctx context.Context, id int64, query models.ProductQuery)) *MockService_GetProductByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.ProductQuery))
+	})
+	return _c
+}
+
+func (_c *MockService_GetProductByID_Call) Return(_a0 models.Product, _a1 error) *MockService_GetProductByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetProductByID_Call) RunAndReturn(run func(context.Context, int64, models.ProductQuery) (models.Product, error)) *MockService_GetProductByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateByID provides a mock function with given fields: ctx, id, query
+func (_m *MockService) GetTemplateByID(ctx context.Context, id int64, query models.TemplateQuery) (models.Template, error) {
+	ret := _m.Called(ctx, id, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateByID")
+	}
+
+	var r0 models.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateQuery) (models.Template, error)); ok {
+		return rf(ctx, id, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateQuery) models.Template); ok {
+		r0 = rf(ctx, id, query)
+	} else {
+		r0 = ret.Get(0).(models.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TemplateQuery) error); ok {
+		r1 = rf(ctx, id, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetTemplateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateByID'
+type MockService_GetTemplateByID_Call struct {
+	*mock.Call
+}
+
+// GetTemplateByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - query models.TemplateQuery
+func (_e *MockService_Expecter) GetTemplateByID(ctx interface{}, id interface{}, query interface{}) *MockService_GetTemplateByID_Call {
+	return &MockService_GetTemplateByID_Call{Call: _e.mock.On("GetTemplateByID", ctx, id, query)}
+}
+
+func (_c *MockService_GetTemplateByID_Call) Run(run func(ctx context.Context, id int64, query models.TemplateQuery)) *MockService_GetTemplateByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TemplateQuery))
+	})
+	return _c
+}
+
+func (_c *MockService_GetTemplateByID_Call) Return(_a0 models.Template, _a1 error) *MockService_GetTemplateByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetTemplateByID_Call) RunAndReturn(run func(context.Context, int64, models.TemplateQuery) (models.Template, error)) *MockService_GetTemplateByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateRevision provides a mock function with given fields: ctx, templateID, revision
+func (_m *MockService) GetTemplateRevision(ctx context.Context, templateID int64, revision int64) (models.TemplateRevision, error) {
+	ret := _m.Called(ctx, templateID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateRevision")
+	}
+
+	var r0 models.TemplateRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (models.TemplateRevision, error)); ok {
+		return rf(ctx, templateID, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) models.TemplateRevision); ok {
+		r0 = rf(ctx, templateID, revision)
+	} else {
+		r0 = ret.Get(0).(models.TemplateRevision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, templateID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetTemplateRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateRevision'
+type MockService_GetTemplateRevision_Call struct {
+	*mock.Call
+}
+
+// GetTemplateRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - revision int64
+func (_e *MockService_Expecter) GetTemplateRevision(ctx interface{}, templateID interface{}, revision interface{}) *MockService_GetTemplateRevision_Call {
+	return &MockService_GetTemplateRevision_Call{Call: _e.mock.On("GetTemplateRevision", ctx, templateID, revision)}
+}
+
+func (_c *MockService_GetTemplateRevision_Call) Run(run func(ctx context.Context, templateID int64, revision int64)) *MockService_GetTemplateRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetTemplateRevision_Call) Return(_a0 models.TemplateRevision, _a1 error) *MockService_GetTemplateRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetTemplateRevision_Call) RunAndReturn(run func(context.Context, int64, int64) (models.TemplateRevision, error)) *MockService_GetTemplateRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateTotal provides a mock function with given fields: ctx, templateID, priceList
+func (_m *MockService) GetTemplateTotal(ctx context.Context, templateID int64, priceList string) (models.TemplateTotal, error) {
+	ret := _m.Called(ctx, templateID, priceList)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateTotal")
+	}
+
+	var r0 models.TemplateTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (models.TemplateTotal, error)); ok {
+		return rf(ctx, templateID, priceList)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) models.TemplateTotal); ok {
+		r0 = rf(ctx, templateID, priceList)
+	} else {
+		r0 = ret.Get(0).(models.TemplateTotal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, templateID, priceList)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetTemplateTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateTotal'
+type MockService_GetTemplateTotal_Call struct {
+	*mock.Call
+}
+
+// GetTemplateTotal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - priceList string
+func (_e *MockService_Expecter) GetTemplateTotal(ctx interface{}, templateID interface{}, priceList interface{}) *MockService_GetTemplateTotal_Call {
+	return &MockService_GetTemplateTotal_Call{Call: _e.mock.On("GetTemplateTotal", ctx, templateID, priceList)}
+}
+
+func (_c *MockService_GetTemplateTotal_Call) Run(run func(ctx context.Context, templateID int64, priceList string)) *MockService_GetTemplateTotal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_GetTemplateTotal_Call) Return(_a0 models.TemplateTotal, _a1 error) *MockService_GetTemplateTotal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetTemplateTotal_Call) RunAndReturn(run func(context.Context, int64, string) (models.TemplateTotal, error)) *MockService_GetTemplateTotal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportTemplates provides a mock function with given fields: ctx, templates
+func (_m *MockService) ImportTemplates(ctx context.Context, templates []models.PortableTemplate) ([]models.TemplateImportResult, error) {
+	ret := _m.Called(ctx, templates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTemplates")
+	}
+
+	var r0 []models.TemplateImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.PortableTemplate) ([]models.TemplateImportResult, error)); ok {
+		return rf(ctx, templates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.PortableTemplate) []models.TemplateImportResult); ok {
+		r0 = rf(ctx, templates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TemplateImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.PortableTemplate) error); ok {
+		r1 = rf(ctx, templates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ImportTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTemplates'
+type MockService_ImportTemplates_Call struct {
+	*mock.Call
+}
+
+// ImportTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templates []models.PortableTemplate
+func (_e *MockService_Expecter) ImportTemplates(ctx interface{}, templates interface{}) *MockService_ImportTemplates_Call {
+	return &MockService_ImportTemplates_Call{Call: _e.mock.On("ImportTemplates", ctx, templates)}
+}
+
+func (_c *MockService_ImportTemplates_Call) Run(run func(ctx context.Context, templates []models.PortableTemplate)) *MockService_ImportTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.PortableTemplate))
+	})
+	return _c
+}
+
+func (_c *MockService_ImportTemplates_Call) Return(_a0 []models.TemplateImportResult, _a1 error) *MockService_ImportTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ImportTemplates_Call) RunAndReturn(run func(context.Context, []models.PortableTemplate) ([]models.TemplateImportResult, error)) *MockService_ImportTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPriceLists provides a mock function with given fields: ctx
+func (_m *MockService) ListPriceLists(ctx context.Context) ([]models.PriceList, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPriceLists")
 	}
 
-	var r0 int64
+	var r0 []models.PriceList
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Product) (int64, error)); ok {
-		return rf(ctx, p)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.PriceList, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Product) int64); ok {
-		r0 = rf(ctx, p)
+	if rf, ok := ret.Get(0).(func(context.Context) []models.PriceList); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PriceList)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.Product) error); ok {
-		r1 = rf(ctx, p)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -107,105 +1150,116 @@ func (_m *MockService) CreateProduct(ctx context.Context, p *models.Product) (in
 	return r0, r1
 }
 
-// MockService_CreateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProduct'
-type MockService_CreateProduct_Call struct {
+// MockService_ListPriceLists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPriceLists'
+type MockService_ListPriceLists_Call struct {
 	*mock.Call
 }
 
-// CreateProduct is a helper method to define mock.On call
+// ListPriceLists is a helper method to define mock.On call
 //   - ctx context.Context
-//   - p *models.Product
-func (_e *MockService_Expecter) CreateProduct(ctx interface{}, p interface{}) *MockService_CreateProduct_Call {
-	return &MockService_CreateProduct_Call{Call: _e.mock.On("CreateProduct", ctx, p)}
+func (_e *MockService_Expecter) ListPriceLists(ctx interface{}) *MockService_ListPriceLists_Call {
+	return &MockService_ListPriceLists_Call{Call: _e.mock.On("ListPriceLists", ctx)}
 }
 
-func (_c *MockService_CreateProduct_Call) Run(run func(ctx context.Context, p *models.Product)) *MockService_CreateProduct_Call {
+func (_c *MockService_ListPriceLists_Call) Run(run func(ctx context.Context)) *MockService_ListPriceLists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.Product))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockService_CreateProduct_Call) Return(_a0 int64, _a1 error) *MockService_CreateProduct_Call {
+func (_c *MockService_ListPriceLists_Call) Return(_a0 []models.PriceList, _a1 error) *MockService_ListPriceLists_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_CreateProduct_Call) RunAndReturn(run func(context.Context, *models.Product) (int64, error)) *MockService_CreateProduct_Call {
+func (_c *MockService_ListPriceLists_Call) RunAndReturn(run func(context.Context) ([]models.PriceList, error)) *MockService_ListPriceLists_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteProduct provides a mock function with given fields: ctx, id
-func (_m *MockService) DeleteProduct(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// ListTemplateRevisions provides a mock function with given fields: ctx, templateID
+func (_m *MockService) ListTemplateRevisions(ctx context.Context, templateID int64) ([]models.TemplateRevision, error) {
+	ret := _m.Called(ctx, templateID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteProduct")
+		panic("no return value specified for ListTemplateRevisions")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	var r0 []models.TemplateRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.TemplateRevision, error)); ok {
+		return rf(ctx, templateID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.TemplateRevision); ok {
+		r0 = rf(ctx, templateID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TemplateRevision)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockService_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
-type MockService_DeleteProduct_Call struct {
+// MockService_ListTemplateRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplateRevisions'
+type MockService_ListTemplateRevisions_Call struct {
 	*mock.Call
 }
 
-// DeleteProduct is a helper method to define mock.On call
+// ListTemplateRevisions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-func (_e *MockService_Expecter) DeleteProduct(ctx interface{}, id interface{}) *MockService_DeleteProduct_Call {
-	return &MockService_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id)}
+//   - templateID int64
+func (_e *MockService_Expecter) ListTemplateRevisions(ctx interface{}, templateID interface{}) *MockService_ListTemplateRevisions_Call {
+	return &MockService_ListTemplateRevisions_Call{Call: _e.mock.On("ListTemplateRevisions", ctx, templateID)}
 }
 
-func (_c *MockService_DeleteProduct_Call) Run(run func(ctx context.Context, id int64)) *MockService_DeleteProduct_Call {
+func (_c *MockService_ListTemplateRevisions_Call) Run(run func(ctx context.Context, templateID int64)) *MockService_ListTemplateRevisions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockService_DeleteProduct_Call) Return(_a0 error) *MockService_DeleteProduct_Call {
-	_c.Call.Return(_a0)
+func (_c *MockService_ListTemplateRevisions_Call) Return(_a0 []models.TemplateRevision, _a1 error) *MockService_ListTemplateRevisions_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_DeleteProduct_Call) RunAndReturn(run func(context.Context, int64) error) *MockService_DeleteProduct_Call {
+func (_c *MockService_ListTemplateRevisions_Call) RunAndReturn(run func(context.Context, int64) ([]models.TemplateRevision, error)) *MockService_ListTemplateRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllProducts provides a mock function with given fields: ctx
-func (_m *MockService) GetAllProducts(ctx context.Context) ([]models.Product, error) {
-	ret := _m.Called(ctx)
+// ListTemplates provides a mock function with given fields: ctx, query
+func (_m *MockService) ListTemplates(ctx context.Context, query models.TemplateQuery) ([]models.Template, error) {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllProducts")
+		panic("no return value specified for ListTemplates")
 	}
 
-	var r0 []models.Product
+	var r0 []models.Template
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Product, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, models.TemplateQuery) ([]models.Template, error)); ok {
+		return rf(ctx, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []models.Product); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, models.TemplateQuery) []models.Template); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Product)
+			r0 = ret.Get(0).([]models.Template)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, models.TemplateQuery) error); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -213,55 +1267,56 @@ func (_m *MockService) GetAllProducts(ctx context.Context) ([]models.Product, er
 	return r0, r1
 }
 
-// MockService_GetAllProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllProducts'
-type MockService_GetAllProducts_Call struct {
+// MockService_ListTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplates'
+type MockService_ListTemplates_Call struct {
 	*mock.Call
 }
 
-// GetAllProducts is a helper method to define mock.On call
+// ListTemplates is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockService_Expecter) GetAllProducts(ctx interface{}) *MockService_GetAllProducts_Call {
-	return &MockService_GetAllProducts_Call{Call: _e.mock.On("GetAllProducts", ctx)}
+//   - query models.TemplateQuery
+func (_e *MockService_Expecter) ListTemplates(ctx interface{}, query interface{}) *MockService_ListTemplates_Call {
+	return &MockService_ListTemplates_Call{Call: _e.mock.On("ListTemplates", ctx, query)}
 }
 
-func (_c *MockService_GetAllProducts_Call) Run(run func(ctx context.Context)) *MockService_GetAllProducts_Call {
+func (_c *MockService_ListTemplates_Call) Run(run func(ctx context.Context, query models.TemplateQuery)) *MockService_ListTemplates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(models.TemplateQuery))
 	})
 	return _c
 }
 
-func (_c *MockService_GetAllProducts_Call) Return(_a0 []models.Product, _a1 error) *MockService_GetAllProducts_Call {
+func (_c *MockService_ListTemplates_Call) Return(_a0 []models.Template, _a1 error) *MockService_ListTemplates_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_GetAllProducts_Call) RunAndReturn(run func(context.Context) ([]models.Product, error)) *MockService_GetAllProducts_Call {
+func (_c *MockService_ListTemplates_Call) RunAndReturn(run func(context.Context, models.TemplateQuery) ([]models.Template, error)) *MockService_ListTemplates_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProductByID provides a mock function with given fields: ctx, id
-func (_m *MockService) GetProductByID(ctx context.Context, id int64) (models.Product, error) {
-	ret := _m.Called(ctx, id)
+// RemoveTemplateLine provides a mock function with given fields: ctx, templateID, productID, version
+func (_m *MockService) RemoveTemplateLine(ctx context.Context, templateID int64, productID int64, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, productID, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProductByID")
+		panic("no return value specified for RemoveTemplateLine")
 	}
 
-	var r0 models.Product
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.Product, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (int64, error)); ok {
+		return rf(ctx, templateID, productID, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) models.Product); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) int64); ok {
+		r0 = rf(ctx, templateID, productID, version)
 	} else {
-		r0 = ret.Get(0).(models.Product)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, templateID, productID, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -269,56 +1324,117 @@ func (_m *MockService) GetProductByID(ctx context.Context, id int64) (models.Pro
 	return r0, r1
 }
 
-// MockService_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
-type MockService_GetProductByID_Call struct {
+// MockService_RemoveTemplateLine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTemplateLine'
+type MockService_RemoveTemplateLine_Call struct {
 	*mock.Call
 }
 
-// GetProductByID is a helper method to define mock.On call
+// RemoveTemplateLine is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-func (_e *MockService_Expecter) GetProductByID(ctx interface{}, id interface{}) *MockService_GetProductByID_Call {
-	return &MockService_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id)}
+//   - templateID int64
+//   - productID int64
+//   - version int64
+func (_e *MockService_Expecter) RemoveTemplateLine(ctx interface{}, templateID interface{}, productID interface{}, version interface{}) *MockService_RemoveTemplateLine_Call {
+	return &MockService_RemoveTemplateLine_Call{Call: _e.mock.On("RemoveTemplateLine", ctx, templateID, productID, version)}
 }
 
-func (_c *MockService_GetProductByID_Call) Run(run func(ctx context.Context, id int64)) *MockService_GetProductByID_Call {
+func (_c *MockService_RemoveTemplateLine_Call) Run(run func(ctx context.Context, templateID int64, productID int64, version int64)) *MockService_RemoveTemplateLine_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
 	})
 	return _c
 }
 
-func (_c *MockService_GetProductByID_Call) Return(_a0 models.Product, _a1 error) *MockService_GetProductByID_Call {
+func (_c *MockService_RemoveTemplateLine_Call) Return(_a0 int64, _a1 error) *MockService_RemoveTemplateLine_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_GetProductByID_Call) RunAndReturn(run func(context.Context, int64) (models.Product, error)) *MockService_GetProductByID_Call {
+func (_c *MockService_RemoveTemplateLine_Call) RunAndReturn(run func(context.Context, int64, int64, int64) (int64, error)) *MockService_RemoveTemplateLine_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTemplateByID provides a mock function with given fields: ctx, id
-func (_m *MockService) GetTemplateByID(ctx context.Context, id int64) (models.Template, error) {
-	ret := _m.Called(ctx, id)
+// RestoreTemplateRevision provides a mock function with given fields: ctx, templateID, revision, version
+func (_m *MockService) RestoreTemplateRevision(ctx context.Context, templateID int64, revision int64, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, revision, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTemplateByID")
+		panic("no return value specified for RestoreTemplateRevision")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (int64, error)); ok {
+		return rf(ctx, templateID, revision, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) int64); ok {
+		r0 = rf(ctx, templateID, revision, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, templateID, revision, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_RestoreTemplateRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTemplateRevision'
+type MockService_RestoreTemplateRevision_Call struct {
+	*mock.Call
+}
+
+// RestoreTemplateRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - revision int64
+//   - version int64
+func (_e *MockService_Expecter) RestoreTemplateRevision(ctx interface{}, templateID interface{}, revision interface{}, version interface{}) *MockService_RestoreTemplateRevision_Call {
+	return &MockService_RestoreTemplateRevision_Call{Call: _e.mock.On("RestoreTemplateRevision", ctx, templateID, revision, version)}
+}
+
+func (_c *MockService_RestoreTemplateRevision_Call) Run(run func(ctx context.Context, templateID int64, revision int64, version int64)) *MockService_RestoreTemplateRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_RestoreTemplateRevision_Call) Return(_a0 int64, _a1 error) *MockService_RestoreTemplateRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_RestoreTemplateRevision_Call) RunAndReturn(run func(context.Context, int64, int64, int64) (int64, error)) *MockService_RestoreTemplateRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScaleTemplate provides a mock function with given fields: ctx, id, scale, query
+func (_m *MockService) ScaleTemplate(ctx context.Context, id int64, scale models.TemplateScale, query models.TemplateQuery) (models.Template, error) {
+	ret := _m.Called(ctx, id, scale, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScaleTemplate")
 	}
 
 	var r0 models.Template
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.Template, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateScale, models.TemplateQuery) (models.Template, error)); ok {
+		return rf(ctx, id, scale, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) models.Template); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateScale, models.TemplateQuery) models.Template); ok {
+		r0 = rf(ctx, id, scale, query)
 	} else {
 		r0 = ret.Get(0).(models.Template)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TemplateScale, models.TemplateQuery) error); ok {
+		r1 = rf(ctx, id, scale, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -326,38 +1442,40 @@ func (_m *MockService) GetTemplateByID(ctx context.Context, id int64) (models.Te
 	return r0, r1
 }
 
-// MockService_GetTemplateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateByID'
-type MockService_GetTemplateByID_Call struct {
+// MockService_ScaleTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScaleTemplate'
+type MockService_ScaleTemplate_Call struct {
 	*mock.Call
 }
 
-// GetTemplateByID is a helper method to define mock.On call
+// ScaleTemplate is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int64
-func (_e *MockService_Expecter) GetTemplateByID(ctx interface{}, id interface{}) *MockService_GetTemplateByID_Call {
-	return &MockService_GetTemplateByID_Call{Call: _e.mock.On("GetTemplateByID", ctx, id)}
+//   - scale models.TemplateScale
+//   - query models.TemplateQuery
+func (_e *MockService_Expecter) ScaleTemplate(ctx interface{}, id interface{}, scale interface{}, query interface{}) *MockService_ScaleTemplate_Call {
+	return &MockService_ScaleTemplate_Call{Call: _e.mock.On("ScaleTemplate", ctx, id, scale, query)}
 }
 
-func (_c *MockService_GetTemplateByID_Call) Run(run func(ctx context.Context, id int64)) *MockService_GetTemplateByID_Call {
+func (_c *MockService_ScaleTemplate_Call) Run(run func(ctx context.Context, id int64, scale models.TemplateScale, query models.TemplateQuery)) *MockService_ScaleTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TemplateScale), args[3].(models.TemplateQuery))
 	})
 	return _c
 }
 
-func (_c *MockService_GetTemplateByID_Call) Return(_a0 models.Template, _a1 error) *MockService_GetTemplateByID_Call {
+func (_c *MockService_ScaleTemplate_Call) Return(_a0 models.Template, _a1 error) *MockService_ScaleTemplate_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_GetTemplateByID_Call) RunAndReturn(run func(context.Context, int64) (models.Template, error)) *MockService_GetTemplateByID_Call {
+func (_c *MockService_ScaleTemplate_Call) RunAndReturn(run func(context.Context, int64, models.TemplateScale, models.TemplateQuery) (models.Template, error)) *MockService_ScaleTemplate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchTemplates provides a mock function with given fields: ctx, searchString, limit, offset
-func (_m *MockService) SearchTemplates(ctx context.Context, searchString string, limit int64, offset int64) ([]models.Template, error) {
-	ret := _m.Called(ctx, searchString, limit, offset)
+// SearchTemplates provides a mock function with given fields: ctx, searchString, limit, offset, query
+func (_m *MockService) SearchTemplates(ctx context.Context, searchString string, limit int64, offset int64, query models.TemplateQuery) ([]models.Template, error) {
+	ret := _m.Called(ctx, searchString, limit, offset, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SearchTemplates")
@@ -365,19 +1483,19 @@ func (_m *MockService) SearchTemplates(ctx context.Context, searchString string,
 
 	var r0 []models.Template
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) ([]models.Template, error)); ok {
-		return rf(ctx, searchString, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, models.TemplateQuery) ([]models.Template, error)); ok {
+		return rf(ctx, searchString, limit, offset, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) []models.Template); ok {
-		r0 = rf(ctx, searchString, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, models.TemplateQuery) []models.Template); ok {
+		r0 = rf(ctx, searchString, limit, offset, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Template)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
-		r1 = rf(ctx, searchString, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64, models.TemplateQuery) error); ok {
+		r1 = rf(ctx, searchString, limit, offset, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -395,13 +1513,14 @@ type MockService_SearchTemplates_Call struct {
 //   - searchString string
 //   - limit int64
 //   - offset int64
-func (_e *MockService_Expecter) SearchTemplates(ctx interface{}, searchString interface{}, limit interface{}, offset interface{}) *MockService_SearchTemplates_Call {
-	return &MockService_SearchTemplates_Call{Call: _e.mock.On("SearchTemplates", ctx, searchString, limit, offset)}
+//   - query models.TemplateQuery
+func (_e *MockService_Expecter) SearchTemplates(ctx interface{}, searchString interface{}, limit interface{}, offset interface{}, query interface{}) *MockService_SearchTemplates_Call {
+	return &MockService_SearchTemplates_Call{Call: _e.mock.On("SearchTemplates", ctx, searchString, limit, offset, query)}
 }
 
-func (_c *MockService_SearchTemplates_Call) Run(run func(ctx context.Context, searchString string, limit int64, offset int64)) *MockService_SearchTemplates_Call {
+func (_c *MockService_SearchTemplates_Call) Run(run func(ctx context.Context, searchString string, limit int64, offset int64, query models.TemplateQuery)) *MockService_SearchTemplates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64), args[4].(models.TemplateQuery))
 	})
 	return _c
 }
@@ -411,7 +1530,55 @@ func (_c *MockService_SearchTemplates_Call) Return(_a0 []models.Template, _a1 er
 	return _c
 }
 
-func (_c *MockService_SearchTemplates_Call) RunAndReturn(run func(context.Context, string, int64, int64) ([]models.Template, error)) *MockService_SearchTemplates_Call {
+func (_c *MockService_SearchTemplates_Call) RunAndReturn(run func(context.Context, string, int64, int64, models.TemplateQuery) ([]models.Template, error)) *MockService_SearchTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPriceListPrices provides a mock function with given fields: ctx, priceListID, prices
+func (_m *MockService) SetPriceListPrices(ctx context.Context, priceListID int64, prices []models.ProductPrice) error {
+	ret := _m.Called(ctx, priceListID, prices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPriceListPrices")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []models.ProductPrice) error); ok {
+		r0 = rf(ctx, priceListID, prices)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SetPriceListPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPriceListPrices'
+type MockService_SetPriceListPrices_Call struct {
+	*mock.Call
+}
+
+// SetPriceListPrices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - priceListID int64
+//   - prices []models.ProductPrice
+func (_e *MockService_Expecter) SetPriceListPrices(ctx interface{}, priceListID interface{}, prices interface{}) *MockService_SetPriceListPrices_Call {
+	return &MockService_SetPriceListPrices_Call{Call: _e.mock.On("SetPriceListPrices", ctx, priceListID, prices)}
+}
+
+func (_c *MockService_SetPriceListPrices_Call) Run(run func(ctx context.Context, priceListID int64, prices []models.ProductPrice)) *MockService_SetPriceListPrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]models.ProductPrice))
+	})
+	return _c
+}
+
+func (_c *MockService_SetPriceListPrices_Call) Return(_a0 error) *MockService_SetPriceListPrices_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_SetPriceListPrices_Call) RunAndReturn(run func(context.Context, int64, []models.ProductPrice) error) *MockService_SetPriceListPrices_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -463,6 +1630,170 @@ func (_c *MockService_UpdateProduct_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UpdateTemplate provides a mock function with given fields: ctx, template
+func (_m *MockService) UpdateTemplate(ctx context.Context, template *models.Template) error {
+	ret := _m.Called(ctx, template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Template) error); ok {
+		r0 = rf(ctx, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_UpdateTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplate'
+type MockService_UpdateTemplate_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - template *models.Template
+func (_e *MockService_Expecter) UpdateTemplate(ctx interface{}, template interface{}) *MockService_UpdateTemplate_Call {
+	return &MockService_UpdateTemplate_Call{Call: _e.mock.On("UpdateTemplate", ctx, template)}
+}
+
+func (_c *MockService_UpdateTemplate_Call) Run(run func(ctx context.Context, template *models.Template)) *MockService_UpdateTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Template))
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateTemplate_Call) Return(_a0 error) *MockService_UpdateTemplate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_UpdateTemplate_Call) RunAndReturn(run func(context.Context, *models.Template) error) *MockService_UpdateTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTemplateLine provides a mock function with given fields: ctx, templateID, line, version
+func (_m *MockService) UpdateTemplateLine(ctx context.Context, templateID int64, line models.TemplateContent, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, line, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplateLine")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) (int64, error)); ok {
+		return rf(ctx, templateID, line, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) int64); ok {
+		r0 = rf(ctx, templateID, line, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TemplateContent, int64) error); ok {
+		r1 = rf(ctx, templateID, line, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_UpdateTemplateLine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplateLine'
+type MockService_UpdateTemplateLine_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplateLine is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - line models.TemplateContent
+//   - version int64
+func (_e *MockService_Expecter) UpdateTemplateLine(ctx interface{}, templateID interface{}, line interface{}, version interface{}) *MockService_UpdateTemplateLine_Call {
+	return &MockService_UpdateTemplateLine_Call{Call: _e.mock.On("UpdateTemplateLine", ctx, templateID, line, version)}
+}
+
+func (_c *MockService_UpdateTemplateLine_Call) Run(run func(ctx context.Context, templateID int64, line models.TemplateContent, version int64)) *MockService_UpdateTemplateLine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TemplateContent), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateTemplateLine_Call) Return(_a0 int64, _a1 error) *MockService_UpdateTemplateLine_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_UpdateTemplateLine_Call) RunAndReturn(run func(context.Context, int64, models.TemplateContent, int64) (int64, error)) *MockService_UpdateTemplateLine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadProductImage provides a mock function with given fields: ctx, productID, upload
+func (_m *MockService) UploadProductImage(ctx context.Context, productID int64, upload models.Blob) (models.ProductImage, error) {
+	ret := _m.Called(ctx, productID, upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadProductImage")
+	}
+
+	var r0 models.ProductImage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Blob) (models.ProductImage, error)); ok {
+		return rf(ctx, productID, upload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Blob) models.ProductImage); ok {
+		r0 = rf(ctx, productID, upload)
+	} else {
+		r0 = ret.Get(0).(models.ProductImage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.Blob) error); ok {
+		r1 = rf(ctx, productID, upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_UploadProductImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadProductImage'
+type MockService_UploadProductImage_Call struct {
+	*mock.Call
+}
+
+// UploadProductImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int64
+//   - upload models.Blob
+func (_e *MockService_Expecter) UploadProductImage(ctx interface{}, productID interface{}, upload interface{}) *MockService_UploadProductImage_Call {
+	return &MockService_UploadProductImage_Call{Call: _e.mock.On("UploadProductImage", ctx, productID, upload)}
+}
+
+func (_c *MockService_UploadProductImage_Call) Run(run func(ctx context.Context, productID int64, upload models.Blob)) *MockService_UploadProductImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.Blob))
+	})
+	return _c
+}
+
+func (_c *MockService_UploadProductImage_Call) Return(_a0 models.ProductImage, _a1 error) *MockService_UploadProductImage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_UploadProductImage_Call) RunAndReturn(run func(context.Context, int64, models.Blob) (models.ProductImage, error)) *MockService_UploadProductImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockService(t interface {
@@ -22,6 +22,123 @@ func (_m *MockTemplateRepository) EXPECT() *MockTemplateRepository_Expecter {
 	return &MockTemplateRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddTemplateContent provides a mock function with given fields: ctx, templateID, content, version
+func (_m *MockTemplateRepository) AddTemplateContent(ctx context.Context, templateID int64, content models.TemplateContent, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, content, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTemplateContent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) (int64, error)); ok {
+		return rf(ctx, templateID, content, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) int64); ok {
+		r0 = rf(ctx, templateID, content, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TemplateContent, int64) error); ok {
+		r1 = rf(ctx, templateID, content, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_AddTemplateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTemplateContent'
+type MockTemplateRepository_AddTemplateContent_Call struct {
+	*mock.Call
+}
+
+// AddTemplateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - content models.TemplateContent
+//   - version int64
+func (_e *MockTemplateRepository_Expecter) AddTemplateContent(ctx interface{}, templateID interface{}, content interface{}, version interface{}) *MockTemplateRepository_AddTemplateContent_Call {
+	return &MockTemplateRepository_AddTemplateContent_Call{Call: _e.mock.On("AddTemplateContent", ctx, templateID, content, version)}
+}
+
+func (_c *MockTemplateRepository_AddTemplateContent_Call) Run(run func(ctx context.Context, templateID int64, content models.TemplateContent, version int64)) *MockTemplateRepository_AddTemplateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TemplateContent), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_AddTemplateContent_Call) Return(_a0 int64, _a1 error) *MockTemplateRepository_AddTemplateContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_AddTemplateContent_Call) RunAndReturn(run func(context.Context, int64, models.TemplateContent, int64) (int64, error)) *MockTemplateRepository_AddTemplateContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloneTemplate provides a mock function with given fields: ctx, templateID, name
+func (_m *MockTemplateRepository) CloneTemplate(ctx context.Context, templateID int64, name string) (int64, error) {
+	ret := _m.Called(ctx, templateID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneTemplate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return rf(ctx, templateID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = rf(ctx, templateID, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, templateID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_CloneTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloneTemplate'
+type MockTemplateRepository_CloneTemplate_Call struct {
+	*mock.Call
+}
+
+// CloneTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - name string
+func (_e *MockTemplateRepository_Expecter) CloneTemplate(ctx interface{}, templateID interface{}, name interface{}) *MockTemplateRepository_CloneTemplate_Call {
+	return &MockTemplateRepository_CloneTemplate_Call{Call: _e.mock.On("CloneTemplate", ctx, templateID, name)}
+}
+
+func (_c *MockTemplateRepository_CloneTemplate_Call) Run(run func(ctx context.Context, templateID int64, name string)) *MockTemplateRepository_CloneTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_CloneTemplate_Call) Return(_a0 int64, _a1 error) *MockTemplateRepository_CloneTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_CloneTemplate_Call) RunAndReturn(run func(context.Context, int64, string) (int64, error)) *MockTemplateRepository_CloneTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTemplate provides a mock function with given fields: ctx, template
 func (_m *MockTemplateRepository) CreateTemplate(ctx context.Context, template *models.Template) error {
 	ret := _m.Called(ctx, template)
@@ -69,17 +186,17 @@ func (_c *MockTemplateRepository_CreateTemplate_Call) RunAndReturn(run func(cont
 	return _c
 }
 
-// DeleteTemplate provides a mock function with given fields: ctx, templateID
-func (_m *MockTemplateRepository) DeleteTemplate(ctx context.Context, templateID int64) error {
-	ret := _m.Called(ctx, templateID)
+// DeleteTemplate provides a mock function with given fields: ctx, templateID, version
+func (_m *MockTemplateRepository) DeleteTemplate(ctx context.Context, templateID int64, version int64) error {
+	ret := _m.Called(ctx, templateID, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTemplate")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, templateID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, templateID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -95,13 +212,14 @@ type MockTemplateRepository_DeleteTemplate_Call struct {
 // DeleteTemplate is a helper method to define mock.On call
 //   - ctx context.Context
 //   - templateID int64
-func (_e *MockTemplateRepository_Expecter) DeleteTemplate(ctx interface{}, templateID interface{}) *MockTemplateRepository_DeleteTemplate_Call {
-	return &MockTemplateRepository_DeleteTemplate_Call{Call: _e.mock.On("DeleteTemplate", ctx, templateID)}
+//   - version int64
+func (_e *MockTemplateRepository_Expecter) DeleteTemplate(ctx interface{}, templateID interface{}, version interface{}) *MockTemplateRepository_DeleteTemplate_Call {
+	return &MockTemplateRepository_DeleteTemplate_Call{Call: _e.mock.On("DeleteTemplate", ctx, templateID, version)}
 }
 
-func (_c *MockTemplateRepository_DeleteTemplate_Call) Run(run func(ctx context.Context, templateID int64)) *MockTemplateRepository_DeleteTemplate_Call {
+func (_c *MockTemplateRepository_DeleteTemplate_Call) Run(run func(ctx context.Context, templateID int64, version int64)) *MockTemplateRepository_DeleteTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
 	})
 	return _c
 }
@@ -111,7 +229,66 @@ func (_c *MockTemplateRepository_DeleteTemplate_Call) Return(_a0 error) *MockTem
 	return _c
 }
 
-func (_c *MockTemplateRepository_DeleteTemplate_Call) RunAndReturn(run func(context.Context, int64) error) *MockTemplateRepository_DeleteTemplate_Call {
+func (_c *MockTemplateRepository_DeleteTemplate_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockTemplateRepository_DeleteTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTemplateContent provides a mock function with given fields: ctx, templateID, productID, version
+func (_m *MockTemplateRepository) DeleteTemplateContent(ctx context.Context, templateID int64, productID int64, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, productID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTemplateContent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (int64, error)); ok {
+		return rf(ctx, templateID, productID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) int64); ok {
+		r0 = rf(ctx, templateID, productID, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, templateID, productID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_DeleteTemplateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTemplateContent'
+type MockTemplateRepository_DeleteTemplateContent_Call struct {
+	*mock.Call
+}
+
+// DeleteTemplateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - productID int64
+//   - version int64
+func (_e *MockTemplateRepository_Expecter) DeleteTemplateContent(ctx interface{}, templateID interface{}, productID interface{}, version interface{}) *MockTemplateRepository_DeleteTemplateContent_Call {
+	return &MockTemplateRepository_DeleteTemplateContent_Call{Call: _e.mock.On("DeleteTemplateContent", ctx, templateID, productID, version)}
+}
+
+func (_c *MockTemplateRepository_DeleteTemplateContent_Call) Run(run func(ctx context.Context, templateID int64, productID int64, version int64)) *MockTemplateRepository_DeleteTemplateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_DeleteTemplateContent_Call) Return(_a0 int64, _a1 error) *MockTemplateRepository_DeleteTemplateContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_DeleteTemplateContent_Call) RunAndReturn(run func(context.Context, int64, int64, int64) (int64, error)) *MockTemplateRepository_DeleteTemplateContent_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -292,6 +469,300 @@ func (_c *MockTemplateRepository_GetTemplateByID_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// GetTemplateContentProducts provides a mock function with given fields: ctx, templateIDs
+func (_m *MockTemplateRepository) GetTemplateContentProducts(ctx context.Context, templateIDs []int64) (map[int64][]models.TemplateContent, error) {
+	ret := _m.Called(ctx, templateIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateContentProducts")
+	}
+
+	var r0 map[int64][]models.TemplateContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64][]models.TemplateContent, error)); ok {
+		return rf(ctx, templateIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64][]models.TemplateContent); ok {
+		r0 = rf(ctx, templateIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]models.TemplateContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, templateIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_GetTemplateContentProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateContentProducts'
+type MockTemplateRepository_GetTemplateContentProducts_Call struct {
+	*mock.Call
+}
+
+// GetTemplateContentProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateIDs []int64
+func (_e *MockTemplateRepository_Expecter) GetTemplateContentProducts(ctx interface{}, templateIDs interface{}) *MockTemplateRepository_GetTemplateContentProducts_Call {
+	return &MockTemplateRepository_GetTemplateContentProducts_Call{Call: _e.mock.On("GetTemplateContentProducts", ctx, templateIDs)}
+}
+
+func (_c *MockTemplateRepository_GetTemplateContentProducts_Call) Run(run func(ctx context.Context, templateIDs []int64)) *MockTemplateRepository_GetTemplateContentProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_GetTemplateContentProducts_Call) Return(_a0 map[int64][]models.TemplateContent, _a1 error) *MockTemplateRepository_GetTemplateContentProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_GetTemplateContentProducts_Call) RunAndReturn(run func(context.Context, []int64) (map[int64][]models.TemplateContent, error)) *MockTemplateRepository_GetTemplateContentProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateContents provides a mock function with given fields: ctx, templateIDs
+func (_m *MockTemplateRepository) GetTemplateContents(ctx context.Context, templateIDs []int64) (map[int64][]models.TemplateContent, error) {
+	ret := _m.Called(ctx, templateIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateContents")
+	}
+
+	var r0 map[int64][]models.TemplateContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64][]models.TemplateContent, error)); ok {
+		return rf(ctx, templateIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64][]models.TemplateContent); ok {
+		r0 = rf(ctx, templateIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]models.TemplateContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, templateIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_GetTemplateContents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateContents'
+type MockTemplateRepository_GetTemplateContents_Call struct {
+	*mock.Call
+}
+
+// GetTemplateContents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateIDs []int64
+func (_e *MockTemplateRepository_Expecter) GetTemplateContents(ctx interface{}, templateIDs interface{}) *MockTemplateRepository_GetTemplateContents_Call {
+	return &MockTemplateRepository_GetTemplateContents_Call{Call: _e.mock.On("GetTemplateContents", ctx, templateIDs)}
+}
+
+func (_c *MockTemplateRepository_GetTemplateContents_Call) Run(run func(ctx context.Context, templateIDs []int64)) *MockTemplateRepository_GetTemplateContents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_GetTemplateContents_Call) Return(_a0 map[int64][]models.TemplateContent, _a1 error) *MockTemplateRepository_GetTemplateContents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_GetTemplateContents_Call) RunAndReturn(run func(context.Context, []int64) (map[int64][]models.TemplateContent, error)) *MockTemplateRepository_GetTemplateContents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTemplateRevision provides a mock function with given fields: ctx, templateID, revision
+func (_m *MockTemplateRepository) GetTemplateRevision(ctx context.Context, templateID int64, revision int64) (models.TemplateRevision, error) {
+	ret := _m.Called(ctx, templateID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateRevision")
+	}
+
+	var r0 models.TemplateRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (models.TemplateRevision, error)); ok {
+		return rf(ctx, templateID, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) models.TemplateRevision); ok {
+		r0 = rf(ctx, templateID, revision)
+	} else {
+		r0 = ret.Get(0).(models.TemplateRevision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, templateID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_GetTemplateRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplateRevision'
+type MockTemplateRepository_GetTemplateRevision_Call struct {
+	*mock.Call
+}
+
+// GetTemplateRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - revision int64
+func (_e *MockTemplateRepository_Expecter) GetTemplateRevision(ctx interface{}, templateID interface{}, revision interface{}) *MockTemplateRepository_GetTemplateRevision_Call {
+	return &MockTemplateRepository_GetTemplateRevision_Call{Call: _e.mock.On("GetTemplateRevision", ctx, templateID, revision)}
+}
+
+func (_c *MockTemplateRepository_GetTemplateRevision_Call) Run(run func(ctx context.Context, templateID int64, revision int64)) *MockTemplateRepository_GetTemplateRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_GetTemplateRevision_Call) Return(_a0 models.TemplateRevision, _a1 error) *MockTemplateRepository_GetTemplateRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_GetTemplateRevision_Call) RunAndReturn(run func(context.Context, int64, int64) (models.TemplateRevision, error)) *MockTemplateRepository_GetTemplateRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportTemplates provides a mock function with given fields: ctx, templates
+func (_m *MockTemplateRepository) ImportTemplates(ctx context.Context, templates []models.Template) ([]models.TemplateImportResult, error) {
+	ret := _m.Called(ctx, templates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTemplates")
+	}
+
+	var r0 []models.TemplateImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Template) ([]models.TemplateImportResult, error)); ok {
+		return rf(ctx, templates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Template) []models.TemplateImportResult); ok {
+		r0 = rf(ctx, templates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TemplateImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.Template) error); ok {
+		r1 = rf(ctx, templates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_ImportTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTemplates'
+type MockTemplateRepository_ImportTemplates_Call struct {
+	*mock.Call
+}
+
+// ImportTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templates []models.Template
+func (_e *MockTemplateRepository_Expecter) ImportTemplates(ctx interface{}, templates interface{}) *MockTemplateRepository_ImportTemplates_Call {
+	return &MockTemplateRepository_ImportTemplates_Call{Call: _e.mock.On("ImportTemplates", ctx, templates)}
+}
+
+func (_c *MockTemplateRepository_ImportTemplates_Call) Run(run func(ctx context.Context, templates []models.Template)) *MockTemplateRepository_ImportTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.Template))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_ImportTemplates_Call) Return(_a0 []models.TemplateImportResult, _a1 error) *MockTemplateRepository_ImportTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_ImportTemplates_Call) RunAndReturn(run func(context.Context, []models.Template) ([]models.TemplateImportResult, error)) *MockTemplateRepository_ImportTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTemplateRevisions provides a mock function with given fields: ctx, templateID
+func (_m *MockTemplateRepository) ListTemplateRevisions(ctx context.Context, templateID int64) ([]models.TemplateRevision, error) {
+	ret := _m.Called(ctx, templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplateRevisions")
+	}
+
+	var r0 []models.TemplateRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.TemplateRevision, error)); ok {
+		return rf(ctx, templateID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.TemplateRevision); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TemplateRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_ListTemplateRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplateRevisions'
+type MockTemplateRepository_ListTemplateRevisions_Call struct {
+	*mock.Call
+}
+
+// ListTemplateRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+func (_e *MockTemplateRepository_Expecter) ListTemplateRevisions(ctx interface{}, templateID interface{}) *MockTemplateRepository_ListTemplateRevisions_Call {
+	return &MockTemplateRepository_ListTemplateRevisions_Call{Call: _e.mock.On("ListTemplateRevisions", ctx, templateID)}
+}
+
+func (_c *MockTemplateRepository_ListTemplateRevisions_Call) Run(run func(ctx context.Context, templateID int64)) *MockTemplateRepository_ListTemplateRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_ListTemplateRevisions_Call) Return(_a0 []models.TemplateRevision, _a1 error) *MockTemplateRepository_ListTemplateRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_ListTemplateRevisions_Call) RunAndReturn(run func(context.Context, int64) ([]models.TemplateRevision, error)) *MockTemplateRepository_ListTemplateRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListTemplates provides a mock function with given fields: ctx
 func (_m *MockTemplateRepository) ListTemplates(ctx context.Context) ([]models.Template, error) {
 	ret := _m.Called(ctx)
@@ -411,6 +882,112 @@ func (_c *MockTemplateRepository_SearchTemplates_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// UpdateTemplate provides a mock function with given fields: ctx, template
+func (_m *MockTemplateRepository) UpdateTemplate(ctx context.Context, template *models.Template) error {
+	ret := _m.Called(ctx, template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Template) error); ok {
+		r0 = rf(ctx, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTemplateRepository_UpdateTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplate'
+type MockTemplateRepository_UpdateTemplate_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - template *models.Template
+func (_e *MockTemplateRepository_Expecter) UpdateTemplate(ctx interface{}, template interface{}) *MockTemplateRepository_UpdateTemplate_Call {
+	return &MockTemplateRepository_UpdateTemplate_Call{Call: _e.mock.On("UpdateTemplate", ctx, template)}
+}
+
+func (_c *MockTemplateRepository_UpdateTemplate_Call) Run(run func(ctx context.Context, template *models.Template)) *MockTemplateRepository_UpdateTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Template))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_UpdateTemplate_Call) Return(_a0 error) *MockTemplateRepository_UpdateTemplate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTemplateRepository_UpdateTemplate_Call) RunAndReturn(run func(context.Context, *models.Template) error) *MockTemplateRepository_UpdateTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTemplateContent provides a mock function with given fields: ctx, templateID, content, version
+func (_m *MockTemplateRepository) UpdateTemplateContent(ctx context.Context, templateID int64, content models.TemplateContent, version int64) (int64, error) {
+	ret := _m.Called(ctx, templateID, content, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplateContent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) (int64, error)); ok {
+		return rf(ctx, templateID, content, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TemplateContent, int64) int64); ok {
+		r0 = rf(ctx, templateID, content, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TemplateContent, int64) error); ok {
+		r1 = rf(ctx, templateID, content, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTemplateRepository_UpdateTemplateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplateContent'
+type MockTemplateRepository_UpdateTemplateContent_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateID int64
+//   - content models.TemplateContent
+//   - version int64
+func (_e *MockTemplateRepository_Expecter) UpdateTemplateContent(ctx interface{}, templateID interface{}, content interface{}, version interface{}) *MockTemplateRepository_UpdateTemplateContent_Call {
+	return &MockTemplateRepository_UpdateTemplateContent_Call{Call: _e.mock.On("UpdateTemplateContent", ctx, templateID, content, version)}
+}
+
+func (_c *MockTemplateRepository_UpdateTemplateContent_Call) Run(run func(ctx context.Context, templateID int64, content models.TemplateContent, version int64)) *MockTemplateRepository_UpdateTemplateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TemplateContent), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTemplateRepository_UpdateTemplateContent_Call) Return(_a0 int64, _a1 error) *MockTemplateRepository_UpdateTemplateContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTemplateRepository_UpdateTemplateContent_Call) RunAndReturn(run func(context.Context, int64, models.TemplateContent, int64) (int64, error)) *MockTemplateRepository_UpdateTemplateContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockTemplateRepository creates a new instance of MockTemplateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockTemplateRepository(t interface {
@@ -14,7 +14,8 @@ func TestProductMapperToSchema(t *testing.T) {
 		ID:          1,
 		Name:        "Milk",
 		Description: "2.5% milk Vologodskoye",
-		Price:       149.99,
+		Price:       models.Money(14999),
+		VATRate:     models.VATRate10,
 		ImageURL:    "http://example.com/milk.jpg",
 	}
 	m := schemas.NewProductMapper()
@@ -25,6 +26,10 @@ func TestProductMapperToSchema(t *testing.T) {
 	assert.Equal(t, product.Name, schema.Name)
 	assert.Equal(t, product.Description, schema.Description)
 	assert.Equal(t, product.Price, schema.Price)
+	assert.Equal(t, product.VATRate, schema.VATRate)
+	assert.Equal(t, models.Money(13635), schema.NetPrice)
+	assert.Equal(t, models.Money(1364), schema.VATAmount)
+	assert.Equal(t, product.Price, schema.GrossPrice)
 	assert.Equal(t, product.ImageURL, schema.ImageURL)
 }
 
@@ -33,7 +38,8 @@ func TestProductMapperToModel(t *testing.T) {
 		ID:          1,
 		Name:        "Milk",
 		Description: "2.5% milk Vologodskoye",
-		Price:       149.99,
+		Price:       models.Money(14999),
+		VATRate:     models.VATRate10,
 		ImageURL:    "http://example.com/milk.jpg",
 	}
 	m := schemas.NewProductMapper()
@@ -44,9 +50,48 @@ func TestProductMapperToModel(t *testing.T) {
 	assert.Equal(t, productSchema.Name, product.Name)
 	assert.Equal(t, productSchema.Description, product.Description)
 	assert.Equal(t, productSchema.Price, product.Price)
+	assert.Equal(t, productSchema.VATRate, product.VATRate)
 	assert.Equal(t, productSchema.ImageURL, product.ImageURL)
 }
 
+func TestProductMapperNutritionAndAllergens(t *testing.T) {
+	product := models.Product{
+		ID:        1,
+		Name:      "Cookies",
+		Unit:      models.UnitPiece,
+		Packaging: []models.Packaging{{Name: "box", Quantity: 24}},
+		Nutrition: &models.Nutrition{Kcal: 450, Proteins: 6.5, Fats: 18, Carbohydrates: 65},
+		Allergens: []models.Allergen{models.AllergenGluten, models.AllergenNuts},
+	}
+	m := schemas.NewProductMapper()
+
+	schema := m.ToSchema(product)
+
+	assert.Equal(t, &schemas.NutritionSchema{Kcal: 450, Proteins: 6.5, Fats: 18, Carbohydrates: 65}, schema.Nutrition)
+	assert.Equal(t, []string{"gluten", "nuts"}, schema.Allergens)
+	assert.Equal(t, []schemas.PackagingSchema{{Name: "box", Quantity: 24}}, schema.Packaging)
+	assert.Equal(t, product, m.ToModel(schema))
+}
+
+func TestProductMapperToSchemaNestsVariants(t *testing.T) {
+	product := models.Product{
+		ID:   1,
+		Name: "Tea",
+		Variants: []models.Product{
+			{ID: 2, Name: "Green tea", ParentID: 1, Options: map[string]string{"flavor": "green"}},
+		},
+	}
+	m := schemas.NewProductMapper()
+
+	schema := m.ToSchema(product)
+
+	if assert.Len(t, schema.Variants, 1) {
+		assert.Equal(t, int64(2), schema.Variants[0].ID)
+		assert.Equal(t, int64(1), schema.Variants[0].ParentID)
+		assert.Equal(t, "green", schema.Variants[0].Options["flavor"])
+	}
+}
+
 // TemplateContentMapper Block
 func TestTemplateContentMapperToSchema(t *testing.T) {
 	contentModel := models.TemplateContent{
@@ -101,6 +146,30 @@ func TestTemplateMapperToSchema(t *testing.T) {
 	assert.Equal(t, templateModel.Content[1].Quantity, templateSchema.Content[1].Quantity)
 }
 
+func TestTemplateMapperToSchemaExpandedProducts(t *testing.T) {
+	tea := models.Product{ID: 1, Name: "Tea", Price: models.Money(300), ImageURL: "http://example.com/tea.png"}
+	templateModel := models.Template{
+		ID:      1,
+		Content: []models.TemplateContent{{ProductID: 1, Quantity: 2, Product: &tea}, {ProductID: 2, Quantity: 1}},
+		Total:   &models.TemplateTotal{TemplateID: 1, Currency: "RUB", Items: 3, Total: models.Money(600)},
+	}
+	tm := schemas.NewTemplateMapper(schemas.NewTemplateContentMapper(), schemas.NewProductMapper())
+
+	templateSchema := tm.ToSchema(templateModel)
+
+	if assert.NotNil(t, templateSchema.Content[0].Product) {
+		assert.Equal(t, "Tea", templateSchema.Content[0].Product.Name)
+		assert.Equal(t, tea.Price, templateSchema.Content[0].Product.Price)
+		assert.Equal(t, tea.ImageURL, templateSchema.Content[0].Product.ImageURL)
+	}
+	assert.Nil(t, templateSchema.Content[1].Product)
+	if assert.NotNil(t, templateSchema.Total) {
+		assert.Equal(t, 3, templateSchema.Total.Items)
+		assert.Equal(t, models.Money(600), templateSchema.Total.Total)
+	}
+	assert.Empty(t, schemas.GetTemplateByIDResponse{Template: templateSchema}.ETag(), "Expanded template must be tagged by its contents")
+}
+
 func TestTemplateMapperToModel(t *testing.T) {
 	templateSchema := schemas.TemplateSchema{
 		ID:           2,
@@ -134,14 +203,14 @@ func TestProductsMapperToSchemas(t *testing.T) {
 			ID:          1,
 			Name:        "Product 1",
 			Description: "Description 1",
-			Price:       199.99,
+			Price:       models.Money(19999),
 			ImageURL:    "http://example.com/product1.jpg",
 		},
 		{
 			ID:          2,
 			Name:        "Product 2",
 			Description: "Description 2",
-			Price:       299.99,
+			Price:       models.Money(29999),
 			ImageURL:    "http://example.com/product2.jpg",
 		},
 	}
@@ -156,8 +225,8 @@ func TestProductsMapperToSchemas(t *testing.T) {
 
 func TestProductsMapperToModels(t *testing.T) {
 	productSchemas := []schemas.ProductSchema{
-		{ID: 1, Name: "Product 1", Description: "Description 1", Price: 199.99, ImageURL: "http://example.com/product1.jpg"},
-		{ID: 2, Name: "Product 2", Description: "Description 2", Price: 299.99, ImageURL: "http://example.com/product2.jpg"},
+		{ID: 1, Name: "Product 1", Description: "Description 1", Price: models.Money(19999), ImageURL: "http://example.com/product1.jpg"},
+		{ID: 2, Name: "Product 2", Description: "Description 2", Price: models.Money(29999), ImageURL: "http://example.com/product2.jpg"},
 	}
 	pm := schemas.NewProductsMapper(schemas.NewProductMapper())
 
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Техника тест-дизайна: Анализ граничных значений
+// Описание:
+//   - Тест для метода Availability.Allows с окнами продаж.
+//   - Проверяются границы окна (начало входит, конец нет), дни недели и окно, переходящее через полночь.
+func TestAvailabilityAllowsWindows(t *testing.T) {
+	breakfast := models.Availability{Windows: []models.TimeWindow{
+		{Days: []models.Weekday{models.Monday, models.Tuesday, models.Wednesday, models.Thursday, models.Friday}, Start: "07:00", End: "11:00"},
+	}}
+	night := models.Availability{Windows: []models.TimeWindow{
+		{Days: []models.Weekday{models.Friday}, Start: "22:00", End: "02:00"},
+	}}
+
+	tests := []struct {
+		name         string
+		availability models.Availability
+		at           string
+		expected     bool
+	}{
+		{name: "Начало окна", availability: breakfast, at: "2024-06-03T07:00:00+03:00", expected: true},
+		{name: "Конец окна", availability: breakfast, at: "2024-06-03T11:00:00+03:00", expected: false},
+		{name: "Выходной день", availability: breakfast, at: "2024-06-08T08:00:00+03:00", expected: false},
+		{name: "Ночное окно до полуночи", availability: night, at: "2024-06-07T23:30:00+03:00", expected: true},
+		{name: "Ночное окно после полуночи", availability: night, at: "2024-06-08T01:30:00+03:00", expected: true},
+		{name: "Ночное окно в день начала после полуночи", availability: night, at: "2024-06-07T01:30:00+03:00", expected: false},
+		{name: "Без правил", availability: models.Availability{}, at: "2024-06-08T03:00:00+03:00", expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, tc.at)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, tc.availability.Allows(models.SaleContext{Route: "MOW-SPB", At: at}))
+		})
+	}
+}
+
+// Техника тест-дизайна: Таблица решений
+// Описание:
+//   - Тест для метода Availability.Allows со списками маршрутов и регионов и периодами продаж.
+//   - Проверяется, что запрет сильнее разрешения, регистр кодов не важен, а даты периода входят в него.
+func TestAvailabilityAllowsLists(t *testing.T) {
+	availability := models.Availability{
+		Routes:  models.AccessList{Allow: []string{"MOW-SPB", "MOW-KZN"}, Deny: []string{"MOW-KZN"}},
+		Regions: models.AccessList{Deny: []string{"RU-LEN"}},
+		Periods: []models.DateRange{{From: "2024-06-01", To: "2024-08-31"}},
+	}
+	summer := time.Date(2024, time.August, 31, 23, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		sale     models.SaleContext
+		expected bool
+	}{
+		{name: "Разрешенный маршрут", sale: models.SaleContext{Route: "mow-spb", Region: "RU-MOW", At: summer}, expected: true},
+		{name: "Маршрут не в списке", sale: models.SaleContext{Route: "MOW-NNV", At: summer}, expected: false},
+		{name: "Маршрут и разрешен, и запрещен", sale: models.SaleContext{Route: "MOW-KZN", At: summer}, expected: false},
+		{name: "Запрещенный регион", sale: models.SaleContext{Route: "MOW-SPB", Region: "RU-LEN", At: summer}, expected: false},
+		{name: "Дата вне периода", sale: models.SaleContext{Route: "MOW-SPB", At: summer.AddDate(0, 0, 1)}, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, availability.Allows(tc.sale))
+		})
+	}
+}
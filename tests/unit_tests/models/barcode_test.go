@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// Техника тест-дизайна: Классы эквивалентности + граничные значения
+// Описание:
+//   - Тест для функции NormalizeBarcode.
+//   - Классы эквивалентности: допустимые EAN-8, UPC-A, EAN-13 и GTIN-14, неверная контрольная цифра,
+//     недопустимая длина, нецифровые символы.
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "EAN-8", code: "96385074", expected: "00000096385074"},
+		{name: "UPC-A", code: "036000291452", expected: "00036000291452"},
+		{name: "EAN-13", code: "4006381333931", expected: "04006381333931"},
+		{name: "GTIN-14", code: "10012345678902", expected: "10012345678902"},
+		{name: "EAN-13 и GTIN-14 с ведущим нулем совпадают", code: "04006381333931", expected: "04006381333931"},
+		{name: "Неверная контрольная цифра", code: "4006381333932", wantErr: true},
+		{name: "Недопустимая длина", code: "400638133393", wantErr: true},
+		{name: "Пустой штрихкод", code: "", wantErr: true},
+		{name: "Нецифровые символы", code: "40063813339A1", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gtin, err := models.NormalizeBarcode(tc.code)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.True(t, myerr.IsValidation(err), "Expected error to be of type Validation")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, gtin)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Техника тест-дизайна: Таблица решений
+// Описание:
+//   - Тест для метода Product.Localize.
+//   - Условия: наличие перевода на предпочитаемый язык, язык по умолчанию раньше перевода, частичный перевод, варианты.
+func TestProductLocalize(t *testing.T) {
+	product := models.Product{
+		Name:        "Вода",
+		Description: "Питьевая вода",
+		Translations: map[string]models.Translation{
+			"en": {Name: "Water", Description: "Drinking water"},
+			"zh": {Name: "水"},
+		},
+		Variants: []models.Product{
+			{Name: "Вода 0,5 л", Translations: map[string]models.Translation{"en": {Name: "Water 0.5 L"}}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		locales     []string
+		expName     string
+		expDesc     string
+		expVariants string
+	}{
+		{name: "Без предпочтений", locales: nil, expName: "Вода", expDesc: "Питьевая вода", expVariants: "Вода 0,5 л"},
+		{name: "Английский", locales: []string{"en"}, expName: "Water", expDesc: "Drinking water", expVariants: "Water 0.5 L"},
+		{name: "Частичный перевод", locales: []string{"zh"}, expName: "水", expDesc: "Питьевая вода", expVariants: "Вода 0,5 л"},
+		{name: "Первый доступный язык", locales: []string{"de", "en"}, expName: "Water", expDesc: "Drinking water", expVariants: "Water 0.5 L"},
+		{name: "Язык по умолчанию раньше перевода", locales: []string{"ru", "en"}, expName: "Вода", expDesc: "Питьевая вода", expVariants: "Вода 0,5 л"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			localized := product.Localize(tc.locales)
+			assert.Equal(t, tc.expName, localized.Name)
+			assert.Equal(t, tc.expDesc, localized.Description)
+			assert.Equal(t, tc.expVariants, localized.Variants[0].Name)
+		})
+	}
+	assert.Equal(t, "Вода 0,5 л", product.Variants[0].Name, "Localize must not modify the original product")
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функций NormalizeLocale и ValidLocale.
+func TestNormalizeLocale(t *testing.T) {
+	assert.Equal(t, "en", models.NormalizeLocale("en-US"))
+	assert.Equal(t, "zh", models.NormalizeLocale(" ZH-Hans-CN "))
+	assert.True(t, models.ValidLocale("en"))
+	assert.True(t, models.ValidLocale("fil"))
+	assert.False(t, models.ValidLocale("e"))
+	assert.False(t, models.ValidLocale("en-us"))
+	assert.False(t, models.ValidLocale("*"))
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// Техника тест-дизайна: Классы эквивалентности + граничные значения
+// Описание:
+//   - Тест для функции ParseMoney.
+//   - Классы эквивалентности: целое число, одна и две цифры после запятой, отрицательная сумма,
+//     больше двух цифр после запятой, нечисловая строка.
+//   - Граничные значения: наибольшая и наименьшая суммы, помещающиеся в numeric(10,2), и суммы сразу за ними;
+//     целая часть, при которой units*100+cents переполнил бы int64.
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected models.Money
+		wantErr  bool
+	}{
+		{name: "Целое число", input: "150", expected: 15000},
+		{name: "Одна цифра после запятой", input: "0.5", expected: 50},
+		{name: "Две цифры после запятой", input: "149.99", expected: 14999},
+		{name: "Отрицательная сумма", input: "-10.01", expected: -1001},
+		{name: "Три цифры после запятой", input: "1.999", wantErr: true},
+		{name: "Точка без дробной части", input: "1.", wantErr: true},
+		{name: "Нечисловая строка", input: "abc", wantErr: true},
+		{name: "Пустая строка", input: "", wantErr: true},
+		{name: "Наибольшая сумма numeric(10,2)", input: "99999999.99", expected: 9999999999},
+		{name: "Наименьшая сумма numeric(10,2)", input: "-99999999.99", expected: -9999999999},
+		{name: "Сумма 10^8", input: "100000000", wantErr: true},
+		{name: "Отрицательная сумма -10^8", input: "-100000000.00", wantErr: true},
+		{name: "Переполнение int64 после добавления копеек", input: "92233720368547758.99", wantErr: true},
+		{name: "Целая часть больше int64", input: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			money, err := models.ParseMoney(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.True(t, myerr.IsValidation(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, money)
+		})
+	}
+}
+
+// Техника тест-дизайна: Причинно-следственный анализ
+// Описание:
+//   - Тест для кодирования и декодирования Money в JSON.
+//   - Сумма кодируется строкой, декодируется как из строки, так и из числа без потери точности.
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(models.Money(14999))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `"149.99"`, string(data))
+
+	var fromString models.Money
+	assert.NoError(t, json.Unmarshal([]byte(`"0.10"`), &fromString))
+	assert.Equal(t, models.Money(10), fromString)
+
+	var fromNumber models.Money
+	assert.NoError(t, json.Unmarshal([]byte(`0.29`), &fromNumber))
+	assert.Equal(t, models.Money(29), fromNumber)
+
+	var invalid models.Money
+	assert.Error(t, json.Unmarshal([]byte(`"0.299"`), &invalid))
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для методов Scan и Value, используемых при работе с колонкой numeric(10,2).
+func TestMoneyScanValue(t *testing.T) {
+	var money models.Money
+	assert.NoError(t, money.Scan("99.90"))
+	assert.Equal(t, models.Money(9990), money)
+
+	assert.NoError(t, money.Scan(int64(5)))
+	assert.Equal(t, models.Money(500), money)
+
+	assert.Error(t, money.Scan(true))
+
+	value, err := models.Money(-105).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "-1.05", value)
+}
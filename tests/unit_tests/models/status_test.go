@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Техника тест-дизайна: Тестирование переходов состояний
+// Описание:
+//   - Тест для метода ProductStatus.CanTransitionTo.
+//   - Проверяются все пары статусов: разрешены публикация, снятие с продажи, возврат в продажу и сохранение статуса.
+func TestProductStatusCanTransitionTo(t *testing.T) {
+	allowed := map[[2]models.ProductStatus]bool{
+		{models.ProductStatusDraft, models.ProductStatusActive}:        true,
+		{models.ProductStatusActive, models.ProductStatusDiscontinued}: true,
+		{models.ProductStatusDiscontinued, models.ProductStatusActive}: true,
+	}
+	for _, from := range models.ProductStatuses {
+		for _, to := range models.ProductStatuses {
+			expected := from == to || allowed[[2]models.ProductStatus{from, to}]
+			assert.Equal(t, expected, from.CanTransitionTo(to), "%s -> %s", from, to)
+		}
+	}
+	assert.False(t, models.ProductStatus("archived").Valid())
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// Техника тест-дизайна: Классы эквивалентности + граничные значения
+// Описание:
+//   - Тест для метода Product.BaseQuantity.
+//   - Классы эквивалентности: количество без упаковки, в базовой единице, в коробках, в паллетах,
+//     в неизвестной упаковке, переполнение при пересчете.
+func TestProductBaseQuantity(t *testing.T) {
+	product := models.Product{
+		ID:   1,
+		Unit: models.UnitPiece,
+		Packaging: []models.Packaging{
+			{Name: "box", Quantity: 24},
+			{Name: "pallet", Quantity: 1440},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		quantity int
+		unit     string
+		expected int
+		wantErr  bool
+	}{
+		{name: "Без упаковки", quantity: 5, unit: "", expected: 5},
+		{name: "Базовая единица", quantity: 5, unit: models.UnitPiece, expected: 5},
+		{name: "Коробки", quantity: 2, unit: "box", expected: 48},
+		{name: "Паллета", quantity: 1, unit: "pallet", expected: 1440},
+		{name: "Неизвестная упаковка", quantity: 1, unit: "crate", wantErr: true},
+		{name: "Переполнение", quantity: math.MaxInt32, unit: "box", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			quantity, err := product.BaseQuantity(tc.quantity, tc.unit)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.True(t, myerr.IsValidation(err), "Expected error to be of type Validation")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, quantity)
+		})
+	}
+}
+
+// Техника тест-дизайна: Классы эквивалентности + граничные значения
+// Описание:
+//   - Тест для метода Product.ScaledQuantity.
+//   - Классы эквивалентности: округление до базовых единиц, до целых коробок, ровное число коробок,
+//     дробный множитель без ошибки округления, переполнение.
+func TestProductScaledQuantity(t *testing.T) {
+	loose := models.Product{ID: 1, Unit: models.UnitPiece}
+	boxed := models.Product{
+		ID:   2,
+		Unit: models.UnitPiece,
+		Packaging: []models.Packaging{
+			{Name: "box", Quantity: 24, RoundScaled: true},
+			{Name: "pallet", Quantity: 1440},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		product  models.Product
+		quantity int
+		factor   float64
+		expected int
+		wantErr  bool
+	}{
+		{name: "Базовые единицы", product: loose, quantity: 7, factor: 0.5, expected: 4},
+		{name: "Дробный множитель", product: loose, quantity: 10, factor: 1.1, expected: 11},
+		{name: "До целых коробок", product: boxed, quantity: 30, factor: 5, expected: 168},
+		{name: "Ровно коробки", product: boxed, quantity: 24, factor: 2, expected: 48},
+		{name: "Переполнение", product: loose, quantity: math.MaxInt32, factor: 2, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			quantity, err := tc.product.ScaledQuantity(tc.quantity, tc.factor)
+			if tc.wantErr {
+				assert.True(t, myerr.IsValidation(err), "Expected error to be of type Validation")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, quantity)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода VATRate.Valid.
+//   - Классы эквивалентности: каждая допустимая ставка, пустая ставка, неизвестная ставка.
+func TestVATRateValid(t *testing.T) {
+	for _, rate := range models.VATRates {
+		assert.True(t, rate.Valid(), "rate %q must be valid", rate)
+	}
+	assert.False(t, models.VATRate("").Valid())
+	assert.False(t, models.VATRate("18").Valid())
+}
+
+// Техника тест-дизайна: Классы эквивалентности + граничные значения
+// Описание:
+//   - Тест для метода VATRate.Breakdown.
+//   - Проверяется выделение НДС из цены с НДС, округление до копейки и равенство Net + VAT == Gross.
+func TestVATRateBreakdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     models.VATRate
+		gross    models.Money
+		expected models.PriceBreakdown
+	}{
+		{name: "20% без округления", rate: models.VATRate20, gross: 12000, expected: models.PriceBreakdown{Net: 10000, VAT: 2000, Gross: 12000}},
+		{name: "20% с округлением", rate: models.VATRate20, gross: 14999, expected: models.PriceBreakdown{Net: 12499, VAT: 2500, Gross: 14999}},
+		{name: "10% с округлением", rate: models.VATRate10, gross: 9999, expected: models.PriceBreakdown{Net: 9090, VAT: 909, Gross: 9999}},
+		{name: "Нулевая ставка", rate: models.VATRate0, gross: 9999, expected: models.PriceBreakdown{Net: 9999, Gross: 9999}},
+		{name: "Без НДС", rate: models.VATExempt, gross: 9999, expected: models.PriceBreakdown{Net: 9999, Gross: 9999}},
+		{name: "Отрицательная сумма", rate: models.VATRate20, gross: -14999, expected: models.PriceBreakdown{Net: -12499, VAT: -2500, Gross: -14999}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			breakdown := tc.rate.Breakdown(tc.gross)
+			assert.Equal(t, tc.expected, breakdown)
+			assert.Equal(t, breakdown.Gross, breakdown.Net+breakdown.VAT)
+		})
+	}
+}
@@ -134,6 +134,8 @@ func TestPredefinedErrorConstructors(t *testing.T) {
 		{"Forbidden", myerr.Forbidden, myerr.ErrorTypeForbidden, "forbidden"},
 		{"Conflict", myerr.Conflict, myerr.ErrorTypeConflict, "conflict"},
 		{"Unknown", myerr.Unknown, myerr.ErrorTypeUnknown, "unknown"},
+		{"PreconditionFailed", myerr.PreconditionFailed, myerr.ErrorTypePreconditionFailed, "precondition failed"},
+		{"PreconditionRequired", myerr.PreconditionRequired, myerr.ErrorTypePreconditionRequired, "precondition required"},
 	}
 
 	for _, tt := range tests {
@@ -247,6 +249,18 @@ func TestIsSpecificErrorTypes(t *testing.T) {
 			checkFunc:           myerr.IsUnknown,
 			negativeConstructor: myerr.NotFound,
 		},
+		{
+			name:                "IsPreconditionFailed",
+			constructor:         myerr.PreconditionFailed,
+			checkFunc:           myerr.IsPreconditionFailed,
+			negativeConstructor: myerr.Conflict,
+		},
+		{
+			name:                "IsPreconditionRequired",
+			constructor:         myerr.PreconditionRequired,
+			checkFunc:           myerr.IsPreconditionRequired,
+			negativeConstructor: myerr.PreconditionFailed,
+		},
 	}
 
 	for _, tt := range tests {
@@ -258,3 +272,22 @@ func TestIsSpecificErrorTypes(t *testing.T) {
 		})
 	}
 }
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для функций ValidationFields и Fields.
+//   - Классы: ошибка с проблемами полей, обычная ошибка приложения и ошибка не из пакета myerr.
+func TestValidationFields(t *testing.T) {
+	line := 1
+	fields := []myerr.FieldError{
+		{Field: "templateName", Message: "must not be empty"},
+		{Field: "quantity", Index: &line, Message: "must be positive"},
+	}
+
+	err := myerr.ValidationFields("Template is invalid", fields)
+
+	assert.True(t, myerr.IsValidation(err))
+	assert.Equal(t, fields, myerr.Fields(err))
+	assert.Nil(t, myerr.Fields(myerr.Validation("plain", nil)))
+	assert.Nil(t, myerr.Fields(errors.New("plain")))
+}
@@ -21,6 +21,9 @@ func (suite *ServiceTestSuite) TestAddTemplate_Success() {
 	}
 	expectedID := int64(1)
 
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee")}, nil).Once()
 	suite.mockRepo.On("CreateTemplate", mock.Anything, mock.MatchedBy(func(p *models.Template) bool {
 		return p.TemplateName == newTemplate.TemplateName &&
 			p.Description == newTemplate.Description &&
@@ -55,6 +58,9 @@ func (suite *ServiceTestSuite) TestAddTemplate_AlreadyExists() {
 	}
 	expectedError := myerr.Conflict("Template with name New Template already exists", nil)
 
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee")}, nil).Once()
 	suite.mockRepo.On("CreateTemplate", mock.Anything, mock.MatchedBy(func(p *models.Template) bool {
 		return p.TemplateName == newTemplate.TemplateName &&
 			p.Description == newTemplate.Description &&
@@ -80,22 +86,22 @@ func (suite *ServiceTestSuite) TestAddTemplate_ProductNotFound() {
 			{ProductID: 2, Quantity: 3},
 		},
 	}
-	expectedError := myerr.NotFound("Product with ID 1 not found", nil)
 
-	suite.mockRepo.On("CreateTemplate", mock.Anything, mock.MatchedBy(func(p *models.Template) bool {
-		return p.TemplateName == newTemplate.TemplateName &&
-			p.Description == newTemplate.Description &&
-			len(p.Content) == len(newTemplate.Content)
-	})).
-		Return(expectedError).
-		Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(2, "Coffee")}, nil).Once()
 
 	createdID, err := suite.svc.AddTemplate(context.Background(), newTemplate)
 
 	assert.Error(suite.T(), err, "Expected error when Product is not found")
-	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
-	assert.Equal(suite.T(), expectedError, err, "Expected error to match the mocked error")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	fields := myerr.Fields(err)
+	if assert.Len(suite.T(), fields, 1) {
+		assert.Equal(suite.T(), 0, *fields[0].Index)
+		assert.Equal(suite.T(), "Product with ID 1 not found", fields[0].Message)
+	}
 	assert.Equal(suite.T(), int64(0), createdID, "Expected created ID to be 0 on error")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateTemplate", mock.Anything, mock.Anything)
 }
 
 func (suite *ServiceTestSuite) TestAddTemplate_RepositoryError() {
@@ -109,6 +115,9 @@ func (suite *ServiceTestSuite) TestAddTemplate_RepositoryError() {
 	}
 	expectedError := myerr.Internal("Database error", errors.New("connection failed"))
 
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee")}, nil).Once()
 	suite.mockRepo.On("CreateTemplate", mock.Anything, mock.MatchedBy(func(p *models.Template) bool {
 		return p.TemplateName == newTemplate.TemplateName &&
 			p.Description == newTemplate.Description &&
@@ -123,3 +132,23 @@ func (suite *ServiceTestSuite) TestAddTemplate_RepositoryError() {
 	assert.Equal(suite.T(), expectedError, err, "Expected error to match the mocked error")
 	assert.Equal(suite.T(), int64(0), createdID, "Expected created ID to be 0 on error")
 }
+
+func (suite *ServiceTestSuite) TestAddTemplate_ParentProductWithVariants() {
+	newTemplate := &models.Template{
+		TemplateName: "Tea set",
+		Content: []models.TemplateContent{
+			{ProductID: 1, Quantity: 2},
+		},
+	}
+	variant := createTestProduct(2, "Green tea")
+	variant.ParentID = 1
+
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1}).Return([]models.Product{variant}, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1}).Return([]models.Product{createTestProduct(1, "Tea")}, nil).Once()
+
+	_, err := suite.svc.AddTemplate(context.Background(), newTemplate)
+
+	assert.Error(suite.T(), err, "Expected error when template references a parent product")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateTemplate", mock.Anything, mock.Anything)
+}
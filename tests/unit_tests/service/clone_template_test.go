@@ -0,0 +1,26 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestCloneTemplate() {
+	suite.mockRepo.On("CloneTemplate", mock.Anything, int64(7), "Summer").Return(int64(12), nil).Once()
+	suite.mockRepo.On("CloneTemplate", mock.Anything, int64(7), "Winter").
+		Return(int64(0), myerr.Conflict("Template with name Winter already exists", nil)).Once()
+
+	id, err := suite.svc.CloneTemplate(context.Background(), 7, " Summer ")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(12), id)
+
+	_, err = suite.svc.CloneTemplate(context.Background(), 7, "Winter")
+	assert.True(suite.T(), myerr.IsConflict(err), "Expected error to be of type Conflict")
+
+	_, err = suite.svc.CloneTemplate(context.Background(), 7, "  ")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertExpectations(suite.T())
+}
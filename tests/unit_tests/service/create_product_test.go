@@ -16,7 +16,8 @@ func (suite *ServiceTestSuite) TestCreateProduct_Success() {
 	newProduct := &models.Product{
 		Name:        "New Product",
 		Description: "New Description",
-		Price:       79.99,
+		Price:       models.Money(7999),
+		VATRate:     models.VATRate20,
 		ImageURL:    "http://example.com/newimage.png",
 		SKU:         "SKUNEW",
 	}
@@ -34,7 +35,7 @@ func (suite *ServiceTestSuite) TestCreateProduct_Success() {
 			product.ID = expectedID
 			// Дополнительные проверки
 			assert.NotEmpty(suite.T(), product.Name, "Product name should not be empty")
-			assert.GreaterOrEqual(suite.T(), product.Price, float64(0), "Product price should be non-negative")
+			assert.GreaterOrEqual(suite.T(), product.Price, models.Money(0), "Product price should be non-negative")
 		}).
 		Return(expectedID, nil).
 		Once()
@@ -50,7 +51,8 @@ func (suite *ServiceTestSuite) TestCreateProduct_AlreadyExists() {
 	newProduct := &models.Product{
 		Name:        "New Product",
 		Description: "New Description",
-		Price:       79.99,
+		Price:       models.Money(7999),
+		VATRate:     models.VATRate20,
 		ImageURL:    "http://example.com/newimage.png",
 		SKU:         "SKUNEW",
 	}
@@ -79,7 +81,8 @@ func (suite *ServiceTestSuite) TestCreateProduct_RepositoryError() {
 	newProduct := &models.Product{
 		Name:        "New Product",
 		Description: "New Description",
-		Price:       79.99,
+		Price:       models.Money(7999),
+		VATRate:     models.VATRate20,
 		ImageURL:    "http://example.com/newimage.png",
 		SKU:         "SKUNEW",
 	}
@@ -102,3 +105,18 @@ func (suite *ServiceTestSuite) TestCreateProduct_RepositoryError() {
 	assert.Equal(suite.T(), int64(0), createdID, "Expected created ID to be 0 on error")
 	assert.Equal(suite.T(), int64(0), newProduct.ID, "Expected product ID to remain 0 on error")
 }
+
+func (suite *ServiceTestSuite) TestCreateProduct_InvalidVATRate() {
+	newProduct := &models.Product{
+		Name:  "New Product",
+		Price: models.Money(7999),
+		SKU:   "SKUNEW",
+	}
+
+	createdID, err := suite.svc.CreateProduct(context.Background(), newProduct)
+
+	assert.Error(suite.T(), err, "Expected error when VAT rate is missing")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	assert.Equal(suite.T(), int64(0), createdID, "Expected created ID to be 0 on error")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything, mock.Anything)
+}
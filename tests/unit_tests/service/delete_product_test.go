@@ -15,11 +15,11 @@ func (suite *ServiceTestSuite) TestDeleteProduct_Success() {
 
 	suite.mockRepo.On("DeleteProduct", mock.Anything, mock.MatchedBy(func(id int64) bool {
 		return id == productID
-	})).
+	}), int64(0)).
 		Return(nil).
 		Once()
 
-	err := suite.svc.DeleteProduct(context.Background(), productID)
+	err := suite.svc.DeleteProduct(context.Background(), productID, 0)
 
 	assert.NoError(suite.T(), err, "Expected no error when deleting product")
 }
@@ -30,11 +30,11 @@ func (suite *ServiceTestSuite) TestDeleteProduct_NotFound() {
 
 	suite.mockRepo.On("DeleteProduct", mock.Anything, mock.MatchedBy(func(id int64) bool {
 		return id == productID
-	})).
+	}), int64(0)).
 		Return(expectedError).
 		Once()
 
-	err := suite.svc.DeleteProduct(context.Background(), productID)
+	err := suite.svc.DeleteProduct(context.Background(), productID, 0)
 
 	assert.Error(suite.T(), err, "Expected error when product is not found")
 	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
@@ -47,11 +47,11 @@ func (suite *ServiceTestSuite) TestDeleteProduct_RepositoryError() {
 
 	suite.mockRepo.On("DeleteProduct", mock.Anything, mock.MatchedBy(func(id int64) bool {
 		return id == productID
-	})).
+	}), int64(0)).
 		Return(expectedError).
 		Once()
 
-	err := suite.svc.DeleteProduct(context.Background(), productID)
+	err := suite.svc.DeleteProduct(context.Background(), productID, 0)
 
 	assert.Error(suite.T(), err, "Expected error when repository returns an error")
 	assert.True(suite.T(), myerr.IsInternal(err), "Expected error to be of type Internal")
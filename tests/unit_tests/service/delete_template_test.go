@@ -0,0 +1,89 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestDeleteTemplate_Success() {
+	template := createTestTemplate(7, "Breakfast")
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(7)).Return(template, nil).Once()
+	suite.mockRepo.On("DeleteTemplate", mock.Anything, int64(7), int64(1)).Return(nil).Once()
+
+	deleted, err := suite.svc.DeleteTemplate(context.Background(), 7, 1, false)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), template, deleted, "Expected deleted template to be returned with its content")
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestDeleteTemplate_DryRun() {
+	template := createTestTemplate(7, "Breakfast")
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(7)).Return(template, nil).Once()
+
+	deleted, err := suite.svc.DeleteTemplate(context.Background(), 7, 0, true)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), template, deleted)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteTemplate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для метода DeleteTemplate.
+//   - Пробное удаление с устаревшей версией сообщает PreconditionFailed, как и настоящее удаление.
+func (suite *ServiceTestSuite) TestDeleteTemplate_DryRunStaleVersion() {
+	template := createTestTemplate(7, "Breakfast")
+	template.Version = 3
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(7)).Return(template, nil).Once()
+
+	_, err := suite.svc.DeleteTemplate(context.Background(), 7, 2, true)
+
+	assert.True(suite.T(), myerr.IsPreconditionFailed(err), "Expected error to be of type PreconditionFailed")
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteTemplate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для метода DeleteTemplate.
+//   - Ошибка PreconditionFailed из репозитория возвращается клиенту без изменений.
+func (suite *ServiceTestSuite) TestDeleteTemplate_StaleVersion() {
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(7)).Return(createTestTemplate(7, "Breakfast"), nil).Once()
+	suite.mockRepo.On("DeleteTemplate", mock.Anything, int64(7), int64(2)).
+		Return(myerr.PreconditionFailed("Template with ID 7 was modified concurrently: current version is 3, expected 2", nil)).Once()
+
+	_, err := suite.svc.DeleteTemplate(context.Background(), 7, 2, false)
+
+	assert.True(suite.T(), myerr.IsPreconditionFailed(err), "Expected error to be of type PreconditionFailed")
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestDeleteTemplate_NotFound() {
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(99)).
+		Return(models.Template{}, myerr.NotFound("Template with ID 99 not found", nil)).Once()
+
+	_, err := suite.svc.DeleteTemplate(context.Background(), 99, 0, false)
+
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteTemplate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestListTemplates_Localized() {
+	template := createTestTemplate(7, "Breakfast")
+	template.Translations = map[string]models.Translation{"en": {Name: "Morning set"}}
+
+	suite.mockRepo.On("ListTemplates", mock.Anything).Return([]models.Template{template}, nil).Once()
+
+	templates, err := suite.svc.ListTemplates(context.Background(), models.TemplateQuery{Locales: []string{"en"}})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), templates, 1)
+	assert.Equal(suite.T(), "Morning set", templates[0].TemplateName)
+}
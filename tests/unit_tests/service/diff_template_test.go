@@ -0,0 +1,56 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода DiffTemplates.
+//   - Классы строк: только в первом шаблоне, только во втором, в обоих с разным и с одинаковым количеством.
+func (suite *ServiceTestSuite) TestDiffTemplates() {
+	summer := createTestTemplate(1, "Summer Sapsan")
+	summer.Content = []models.TemplateContent{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 3}, {ProductID: 3, Quantity: 10}}
+	winter := createTestTemplate(2, "Winter Sapsan")
+	winter.Content = []models.TemplateContent{{ProductID: 2, Quantity: 3}, {ProductID: 3, Quantity: 4}, {ProductID: 4, Quantity: 6}}
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(summer, nil).Once()
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(2)).Return(winter, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 4, 3}).
+		Return([]models.Product{createTestProduct(1, "Ice cream"), createTestProduct(3, "Water"), createTestProduct(4, "Tea")}, nil).Once()
+
+	diff, err := suite.svc.DiffTemplates(context.Background(), 1, 2, models.TemplateQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Summer Sapsan", diff.A.TemplateName)
+	assert.Empty(suite.T(), diff.A.Content, "Expected templates to be returned without content")
+	if assert.Len(suite.T(), diff.OnlyInA, 1) {
+		assert.Equal(suite.T(), int64(1), diff.OnlyInA[0].ProductID)
+		assert.Equal(suite.T(), "Ice cream", diff.OnlyInA[0].Product.Name)
+	}
+	if assert.Len(suite.T(), diff.OnlyInB, 1) {
+		assert.Equal(suite.T(), int64(4), diff.OnlyInB[0].ProductID)
+		assert.Equal(suite.T(), 6, diff.OnlyInB[0].Quantity)
+	}
+	if assert.Len(suite.T(), diff.Changed, 1) {
+		assert.Equal(suite.T(), int64(3), diff.Changed[0].ProductID)
+		assert.Equal(suite.T(), 10, diff.Changed[0].QuantityA)
+		assert.Equal(suite.T(), 4, diff.Changed[0].QuantityB)
+		assert.Equal(suite.T(), "Water", diff.Changed[0].Product.Name)
+	}
+}
+
+func (suite *ServiceTestSuite) TestDiffTemplates_NotFound() {
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(createTestTemplate(1, "Summer Sapsan"), nil).Once()
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(99)).
+		Return(models.Template{}, myerr.NotFound("Template with ID 99 not found", nil)).Once()
+
+	_, err := suite.svc.DiffTemplates(context.Background(), 1, 99, models.TemplateQuery{})
+
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+}
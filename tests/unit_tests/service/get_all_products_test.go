@@ -21,8 +21,11 @@ func (suite *ServiceTestSuite) TestGetAllProducts_Success() {
 		Return(expectedProducts, nil).
 		Once()
 
-	products, err := suite.svc.GetAllProducts(context.Background())
+	products, err := suite.svc.GetAllProducts(context.Background(), models.ProductQuery{})
 
+	for i := range expectedProducts {
+		expectedProducts[i].Currency = models.BaseCurrency
+	}
 	assert.NoError(suite.T(), err, "Expected no error when getting all products")
 	assert.Equal(suite.T(), expectedProducts, products, "Expected products to match the mocked products")
 }
@@ -34,7 +37,7 @@ func (suite *ServiceTestSuite) TestGetAllProducts_RepositoryError() {
 		Return(nil, expectedError).
 		Once()
 
-	products, err := suite.svc.GetAllProducts(context.Background())
+	products, err := suite.svc.GetAllProducts(context.Background(), models.ProductQuery{})
 
 	assert.Error(suite.T(), err, "Expected error when repository returns an error")
 	assert.Equal(suite.T(), expectedError, err, "Expected error to match the mocked error")
@@ -21,9 +21,11 @@ func (suite *ServiceTestSuite) TestGetProductByID_Success() {
 		GetProductByID(mock.Anything, productID).
 		Return(expectedProduct, nil).
 		Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{productID}).Return(nil, nil).Once()
 
-	product, err := suite.svc.GetProductByID(context.Background(), productID)
+	product, err := suite.svc.GetProductByID(context.Background(), productID, models.ProductQuery{})
 
+	expectedProduct.Currency = models.BaseCurrency
 	assert.NoError(suite.T(), err, "Expected no error when getting product by ID")
 	assert.Equal(suite.T(), expectedProduct, product, "Expected product to match the mocked product")
 }
@@ -36,7 +38,7 @@ func (suite *ServiceTestSuite) TestGetProductByID_NotFound() {
 		Return(models.Product{}, expectedError).
 		Once()
 
-	product, err := suite.svc.GetProductByID(context.Background(), productID)
+	product, err := suite.svc.GetProductByID(context.Background(), productID, models.ProductQuery{})
 
 	assert.Error(suite.T(), err, "Expected error when product is not found")
 	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
@@ -52,7 +54,7 @@ func (suite *ServiceTestSuite) TestGetProductByID_RepositoryError() {
 		Return(models.Product{}, expectedError).
 		Once()
 
-	product, err := suite.svc.GetProductByID(context.Background(), productID)
+	product, err := suite.svc.GetProductByID(context.Background(), productID, models.ProductQuery{})
 
 	assert.Error(suite.T(), err, "Expected error when repository returns an error")
 	assert.True(suite.T(), myerr.IsInternal(err), "Expected error to be of type Internal")
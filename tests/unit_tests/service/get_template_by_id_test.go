@@ -19,7 +19,7 @@ func (suite *ServiceTestSuite) TestGetTemplateByID_Success() {
 		Return(expectedTemplate, nil).
 		Once()
 
-	Template, err := suite.svc.GetTemplateByID(context.Background(), TemplateID)
+	Template, err := suite.svc.GetTemplateByID(context.Background(), TemplateID, models.TemplateQuery{})
 
 	assert.NoError(suite.T(), err, "Expected no error when getting Template by ID")
 	assert.Equal(suite.T(), expectedTemplate, Template, "Expected Template to match the mocked Template")
@@ -33,7 +33,7 @@ func (suite *ServiceTestSuite) TestGetTemplateByID_NotFound() {
 		Return(models.Template{}, expectedError).
 		Once()
 
-	Template, err := suite.svc.GetTemplateByID(context.Background(), TemplateID)
+	Template, err := suite.svc.GetTemplateByID(context.Background(), TemplateID, models.TemplateQuery{})
 
 	assert.Error(suite.T(), err, "Expected error when Template is not found")
 	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
@@ -48,7 +48,7 @@ func (suite *ServiceTestSuite) TestGetTemplateByID_RepositoryError() {
 		Return(models.Template{}, expectedError).
 		Once()
 
-	Template, err := suite.svc.GetTemplateByID(context.Background(), TemplateID)
+	Template, err := suite.svc.GetTemplateByID(context.Background(), TemplateID, models.TemplateQuery{})
 
 	assert.Error(suite.T(), err, "Expected error when repository returns an error during GetTemplateByID")
 	assert.True(suite.T(), myerr.IsInternal(err), "Expected error to be of type Internal")
@@ -0,0 +1,57 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestGetAllProducts_Localized() {
+	water := createTestProduct(1, "Вода")
+	water.Translations = map[string]models.Translation{"en": {Name: "Water"}}
+	tea := createTestProduct(2, "Чай")
+	suite.mockRepo.On("GetAllProducts", mock.Anything).Return([]models.Product{water, tea}, nil).Once()
+
+	result, err := suite.svc.GetAllProducts(context.Background(), models.ProductQuery{Locales: []string{"en"}})
+
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), result, 2) {
+		assert.Equal(suite.T(), "Water", result[0].Name)
+		assert.Equal(suite.T(), "Чай", result[1].Name, "Expected fallback to the default language")
+	}
+}
+
+func (suite *ServiceTestSuite) TestGetTemplateByID_Localized() {
+	template := createTestTemplate(1, "Завтрак")
+	template.Translations = map[string]models.Translation{"zh": {Name: "早餐"}}
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(template, nil).Once()
+
+	result, err := suite.svc.GetTemplateByID(context.Background(), 1, models.TemplateQuery{Locales: []string{"zh"}})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "早餐", result.TemplateName)
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_InvalidTranslations() {
+	tests := map[string]map[string]models.Translation{
+		"Неверный код языка": {"English": {Name: "Water"}},
+		"Язык по умолчанию":  {models.DefaultLocale: {Name: "Вода"}},
+		"Пустой перевод":     {"en": {}},
+	}
+	for name, translations := range tests {
+		suite.Run(name, func() {
+			product := createTestProduct(0, "Вода")
+			product.VATRate = models.VATRate20
+			product.Translations = translations
+
+			_, err := suite.svc.CreateProduct(context.Background(), &product)
+
+			assert.Error(suite.T(), err)
+			assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+		})
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,110 @@
+package unit_tests
+
+import (
+	"context"
+	"math"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/Chaika-Team/ChaikaGoods/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода BuildPickList.
+//   - Количества общих продуктов складываются с учетом числа комплектов, строки сортируются по названию или артикулу.
+func (suite *ServiceTestSuite) TestBuildPickList_MergesTemplates() {
+	tea := createTestProduct(1, "Tea")
+	tea.SKU = "B-TEA"
+	coffee := createTestProduct(2, "Coffee")
+	coffee.SKU = "C-COFFEE"
+	water := createTestProduct(3, "Water")
+	water.SKU = "A-WATER"
+
+	for sortBy, expected := range map[string][]int64{
+		models.PickListSortName: {2, 1, 3},
+		models.PickListSortSKU:  {3, 1, 2},
+	} {
+		suite.Run(sortBy, func() {
+			suite.mockRepo.On("GetProductsByTemplateID", mock.Anything, int64(10)).
+				Return([]models.TemplateContent{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}}, nil).Once()
+			suite.mockRepo.On("GetProductsByTemplateID", mock.Anything, int64(20)).
+				Return([]models.TemplateContent{{ProductID: 1, Quantity: 5}, {ProductID: 3, Quantity: 4}}, nil).Once()
+			suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2, 3}).
+				Return([]models.Product{tea, coffee, water}, nil).Once()
+
+			entries := []models.PickListEntry{{TemplateID: 10, Multiplier: 3}, {TemplateID: 20, Multiplier: 2}}
+			items, err := suite.svc.BuildPickList(context.Background(), entries, models.PickListQuery{SortBy: sortBy})
+
+			assert.NoError(suite.T(), err)
+			quantities := make(map[int64]int)
+			var order []int64
+			for _, item := range items {
+				quantities[item.Product.ID] = item.Quantity
+				order = append(order, item.Product.ID)
+			}
+			assert.Equal(suite.T(), map[int64]int{1: 16, 2: 3, 3: 8}, quantities)
+			assert.Equal(suite.T(), expected, order)
+		})
+	}
+}
+
+func (suite *ServiceTestSuite) TestBuildPickList_TemplateNotFound() {
+	suite.mockRepo.On("GetProductsByTemplateID", mock.Anything, int64(99)).Return(nil, nil).Once()
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(99)).
+		Return(models.Template{}, myerr.NotFound("Template with ID 99 not found", nil)).Once()
+
+	_, err := suite.svc.BuildPickList(context.Background(), []models.PickListEntry{{TemplateID: 99, Multiplier: 1}}, models.PickListQuery{})
+
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+}
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для проверки запроса BuildPickList: пустой список, неположительное или слишком большое число комплектов
+//     и неизвестный порядок строк.
+func (suite *ServiceTestSuite) TestBuildPickList_InvalidRequest() {
+	tests := map[string]struct {
+		entries []models.PickListEntry
+		sortBy  string
+	}{
+		"Нет шаблонов":      {entries: nil},
+		"Нулевой множитель": {entries: []models.PickListEntry{{TemplateID: 1, Multiplier: 0}}},
+		"Слишком большой множитель": {
+			entries: []models.PickListEntry{{TemplateID: 1, Multiplier: service.MaxPickListMultiplier + 1}},
+		},
+		"Неизвестный порядок": {entries: []models.PickListEntry{{TemplateID: 1, Multiplier: 1}}, sortBy: "price"},
+	}
+	for name, tc := range tests {
+		suite.Run(name, func() {
+			_, err := suite.svc.BuildPickList(context.Background(), tc.entries, models.PickListQuery{SortBy: tc.sortBy})
+
+			assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+		})
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetProductsByTemplateID", mock.Anything, mock.Anything)
+}
+
+// Техника тест-дизайна: Анализ граничных значений
+// Описание:
+//   - Тест для метода BuildPickList.
+//   - Общее количество продукта, переполняющее int32 при умножении или сложении, отклоняется ошибкой валидации.
+func (suite *ServiceTestSuite) TestBuildPickList_QuantityOverflow() {
+	suite.mockRepo.On("GetProductsByTemplateID", mock.Anything, int64(1)).
+		Return([]models.TemplateContent{{ProductID: 5, Quantity: math.MaxInt32 / 2}}, nil)
+
+	tests := map[string][]models.PickListEntry{
+		"Переполнение при умножении": {{TemplateID: 1, Multiplier: 3}},
+		"Переполнение при сложении":  {{TemplateID: 1, Multiplier: 2}, {TemplateID: 1, Multiplier: 1}},
+	}
+	for name, entries := range tests {
+		suite.Run(name, func() {
+			_, err := suite.svc.BuildPickList(context.Background(), entries, models.PickListQuery{})
+
+			assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+		})
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetProductsByIDs", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,78 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestGetAllProducts_WithPriceList() {
+	products := []models.Product{
+		createTestProduct(1, "Product 1"),
+		createTestProduct(2, "Product 2"),
+	}
+	priceList := models.PriceList{ID: 5, Name: "belarus", Currency: "BYN"}
+
+	suite.mockRepo.On("GetAllProducts", mock.Anything).Return(products, nil).Once()
+	suite.mockRepo.On("GetPriceListByName", mock.Anything, "belarus").Return(priceList, nil).Once()
+	suite.mockRepo.On("GetPriceListPrices", mock.Anything, int64(5), []int64{1, 2}).
+		Return(map[int64]models.Money{2: 450}, nil).
+		Once()
+
+	result, err := suite.svc.GetAllProducts(context.Background(), models.ProductQuery{PriceList: "belarus"})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1, "Products without a price in the list should be skipped")
+	assert.Equal(suite.T(), int64(2), result[0].ID)
+	assert.Equal(suite.T(), models.Money(450), result[0].Price)
+	assert.Equal(suite.T(), "BYN", result[0].Currency)
+}
+
+func (suite *ServiceTestSuite) TestGetProductByID_NoPriceInPriceList() {
+	product := createTestProduct(1, "Product 1")
+	priceList := models.PriceList{ID: 5, Name: "belarus", Currency: "BYN"}
+
+	suite.mockRepo.On("GetProductByID", mock.Anything, int64(1)).Return(product, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1}).Return(nil, nil).Once()
+	suite.mockRepo.On("GetPriceListByName", mock.Anything, "belarus").Return(priceList, nil).Once()
+	suite.mockRepo.On("GetPriceListPrices", mock.Anything, int64(5), []int64{1}).
+		Return(map[int64]models.Money{}, nil).
+		Once()
+
+	_, err := suite.svc.GetProductByID(context.Background(), 1, models.ProductQuery{PriceList: "belarus"})
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+}
+
+func (suite *ServiceTestSuite) TestGetTemplateTotal_BasePriceList() {
+	template := createTestTemplate(1, "Template 1")
+	products := []models.Product{
+		{ID: 1, Price: 1050, VATRate: models.VATRate20},
+		{ID: 2, Price: 9999, VATRate: models.VATExempt},
+	}
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(template, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).Return(products, nil).Once()
+
+	total, err := suite.svc.GetTemplateTotal(context.Background(), 1, "")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.BaseCurrency, total.Currency)
+	assert.Equal(suite.T(), models.Money(2*1050+3*9999), total.Total)
+	assert.Equal(suite.T(), models.Money(350), total.VAT, "VAT is extracted only from the 20% line")
+	assert.Equal(suite.T(), total.Total-total.VAT, total.Net)
+	assert.Equal(suite.T(), 5, total.Items)
+}
+
+func (suite *ServiceTestSuite) TestCreatePriceList_InvalidCurrency() {
+	priceList := &models.PriceList{Name: "kazakhstan", Currency: "tenge"}
+
+	_, err := suite.svc.CreatePriceList(context.Background(), priceList)
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+}
@@ -0,0 +1,58 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestGetAllProducts_ExcludesAllergens() {
+	bread := createTestProduct(1, "Bread")
+	bread.Allergens = []models.Allergen{models.AllergenGluten}
+	chocolate := createTestProduct(2, "Chocolate")
+	chocolate.Allergens = []models.Allergen{models.AllergenLactose, models.AllergenNuts}
+	water := createTestProduct(3, "Water")
+	suite.mockRepo.On("GetAllProducts", mock.Anything).Return([]models.Product{bread, chocolate, water}, nil).Once()
+
+	result, err := suite.svc.GetAllProducts(context.Background(), models.ProductQuery{
+		ExcludeAllergens: []models.Allergen{models.AllergenNuts, models.AllergenGluten},
+	})
+
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), result, 1) {
+		assert.Equal(suite.T(), int64(3), result[0].ID)
+	}
+}
+
+func (suite *ServiceTestSuite) TestGetAllProducts_UnknownAllergen() {
+	_, err := suite.svc.GetAllProducts(context.Background(), models.ProductQuery{
+		ExcludeAllergens: []models.Allergen{"pollen"},
+	})
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetAllProducts", mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_InvalidNutrition() {
+	tests := map[string]*models.Nutrition{
+		"Отрицательная калорийность": {Kcal: -1},
+		"Больше 100 г на 100 г":      {Proteins: 40, Fats: 40, Carbohydrates: 30},
+	}
+	for name, nutrition := range tests {
+		suite.Run(name, func() {
+			product := createTestProduct(0, "Cookies")
+			product.VATRate = models.VATRate20
+			product.Nutrition = nutrition
+
+			_, err := suite.svc.CreateProduct(context.Background(), &product)
+
+			assert.Error(suite.T(), err)
+			assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+		})
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,66 @@
+package unit_tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestGetAvailableProducts_FiltersByRules() {
+	breakfast := createTestProduct(1, "Porridge")
+	breakfast.Availability = models.Availability{Windows: []models.TimeWindow{{Start: "07:00", End: "11:00"}}}
+	beer := createTestProduct(2, "Beer")
+	beer.Availability = models.Availability{Routes: models.AccessList{Deny: []string{"MOW-SPB"}}}
+	beerCan := createTestProduct(3, "Beer can")
+	beerCan.ParentID = beer.ID
+	tea := createTestProduct(4, "Tea")
+
+	suite.mockRepo.On("GetAllProducts", mock.Anything).
+		Return([]models.Product{breakfast, beer, beerCan, tea}, nil).
+		Once()
+
+	sale := models.SaleContext{Route: "MOW-SPB", At: time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC)}
+	products, err := suite.svc.GetAvailableProducts(context.Background(), sale, models.ProductQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), products, 1, "Expected only products without restricting rules")
+	assert.Equal(suite.T(), tea.ID, products[0].ID)
+}
+
+func (suite *ServiceTestSuite) TestGetAvailableProducts_RouteRequired() {
+	products, err := suite.svc.GetAvailableProducts(context.Background(), models.SaleContext{At: time.Now()}, models.ProductQuery{})
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	assert.Nil(suite.T(), products)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetAllProducts", mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_InvalidAvailability() {
+	tests := []struct {
+		name         string
+		availability models.Availability
+	}{
+		{name: "Неизвестный день недели", availability: models.Availability{Windows: []models.TimeWindow{{Days: []models.Weekday{"monday"}, Start: "07:00", End: "11:00"}}}},
+		{name: "Время без ведущего нуля", availability: models.Availability{Windows: []models.TimeWindow{{Start: "7:00", End: "11:00"}}}},
+		{name: "Пустое окно", availability: models.Availability{Windows: []models.TimeWindow{{Start: "07:00", End: "07:00"}}}},
+		{name: "Период в обратном порядке", availability: models.Availability{Periods: []models.DateRange{{From: "2024-08-31", To: "2024-06-01"}}}},
+		{name: "Маршрут и разрешен, и запрещен", availability: models.Availability{Routes: models.AccessList{Allow: []string{"MOW-SPB"}, Deny: []string{"MOW-SPB"}}}},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			product := createTestProduct(0, "Tea")
+			product.VATRate = models.VATRate20
+			product.Availability = tc.availability
+
+			_, err := suite.svc.CreateProduct(context.Background(), &product)
+
+			assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+		})
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything, mock.Anything)
+}
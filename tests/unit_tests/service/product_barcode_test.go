@@ -0,0 +1,53 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestGetProductByBarcode_Success() {
+	product := createTestProduct(1, "Water")
+	product.Barcodes = []string{"4006381333931"}
+	suite.mockRepo.On("GetProductIDByBarcode", mock.Anything, "04006381333931").Return(int64(1), nil).Once()
+	suite.mockRepo.On("GetProductByID", mock.Anything, int64(1)).Return(product, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1}).Return([]models.Product{}, nil).Once()
+
+	result, err := suite.svc.GetProductByBarcode(context.Background(), "4006381333931", models.ProductQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), result.ID)
+}
+
+func (suite *ServiceTestSuite) TestGetProductByBarcode_InvalidCheckDigit() {
+	_, err := suite.svc.GetProductByBarcode(context.Background(), "4006381333932", models.ProductQuery{})
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetProductIDByBarcode", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGetProductByBarcode_NotFound() {
+	suite.mockRepo.On("GetProductIDByBarcode", mock.Anything, "00000096385074").
+		Return(int64(0), myerr.NotFound("Product with barcode 96385074 not found", nil)).Once()
+
+	_, err := suite.svc.GetProductByBarcode(context.Background(), "96385074", models.ProductQuery{})
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_DuplicateBarcodes() {
+	product := createTestProduct(0, "Water")
+	product.VATRate = models.VATRate20
+	product.Barcodes = []string{"4006381333931", "04006381333931"}
+
+	_, err := suite.svc.CreateProduct(context.Background(), &product)
+
+	assert.Error(suite.T(), err, "Expected error when EAN-13 and GTIN-14 encode the same product")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,123 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestCreateProduct_DefaultsToDraft() {
+	product := createTestProduct(0, "Tea")
+	product.VATRate = models.VATRate20
+	product.Status = ""
+
+	suite.mockRepo.On("CreateProduct", mock.Anything, mock.MatchedBy(func(p *models.Product) bool {
+		return p.Status == models.ProductStatusDraft
+	})).Return(int64(1), nil).Once()
+
+	_, err := suite.svc.CreateProduct(context.Background(), &product)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_InvalidStatus() {
+	product := createTestProduct(0, "Tea")
+	product.VATRate = models.VATRate20
+	product.Status = "archived"
+
+	_, err := suite.svc.CreateProduct(context.Background(), &product)
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestUpdateProduct_StatusTransitions() {
+	tests := []struct {
+		name     string
+		current  models.ProductStatus
+		next     models.ProductStatus
+		expected models.ProductStatus
+		allowed  bool
+	}{
+		{name: "Публикация черновика", current: models.ProductStatusDraft, next: models.ProductStatusActive, expected: models.ProductStatusActive, allowed: true},
+		{name: "Снятие с продажи", current: models.ProductStatusActive, next: models.ProductStatusDiscontinued, expected: models.ProductStatusDiscontinued, allowed: true},
+		{name: "Возврат в продажу", current: models.ProductStatusDiscontinued, next: models.ProductStatusActive, expected: models.ProductStatusActive, allowed: true},
+		{name: "Пустой статус сохраняет текущий", current: models.ProductStatusDiscontinued, next: "", expected: models.ProductStatusDiscontinued, allowed: true},
+		{name: "Возврат в черновик", current: models.ProductStatusActive, next: models.ProductStatusDraft},
+		{name: "Снятие черновика с продажи", current: models.ProductStatusDraft, next: models.ProductStatusDiscontinued},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			current := createTestProduct(1, "Tea")
+			current.Status = tc.current
+			product := createTestProduct(1, "Tea")
+			product.VATRate = models.VATRate20
+			product.Status = tc.next
+
+			suite.mockRepo.On("GetProductByID", mock.Anything, int64(1)).Return(current, nil).Once()
+			if tc.allowed {
+				suite.mockRepo.On("UpdateProduct", mock.Anything, mock.MatchedBy(func(p *models.Product) bool {
+					return p.Status == tc.expected
+				})).Return(nil).Once()
+			}
+
+			err := suite.svc.UpdateProduct(context.Background(), &product)
+
+			if tc.allowed {
+				assert.NoError(suite.T(), err)
+			} else {
+				assert.True(suite.T(), myerr.IsConflict(err), "Expected error to be of type Conflict")
+			}
+		})
+	}
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGetAllProducts_ExcludesDrafts() {
+	draft := createTestProduct(1, "Tea")
+	draft.Status = models.ProductStatusDraft
+	draftVariant := createTestProduct(2, "Green tea")
+	draftVariant.ParentID = 1
+	discontinued := createTestProduct(3, "Coffee")
+	discontinued.Status = models.ProductStatusDiscontinued
+	active := createTestProduct(4, "Water")
+
+	suite.mockRepo.On("GetAllProducts", mock.Anything).
+		Return([]models.Product{draft, draftVariant, discontinued, active}, nil).Once()
+
+	products, err := suite.svc.GetAllProducts(context.Background(), models.ProductQuery{})
+
+	assert.NoError(suite.T(), err)
+	var ids []int64
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(suite.T(), []int64{3, 4}, ids, "Drafts and variants of drafts must not be listed")
+}
+
+func (suite *ServiceTestSuite) TestAddTemplate_RejectsInactiveProducts() {
+	for _, status := range []models.ProductStatus{models.ProductStatusDraft, models.ProductStatusDiscontinued} {
+		suite.Run(string(status), func() {
+			inactive := createTestProduct(2, "Coffee")
+			inactive.Status = status
+			newTemplate := &models.Template{
+				TemplateName: "Breakfast",
+				Content:      []models.TemplateContent{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 1}},
+			}
+
+			suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Once()
+			suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+				Return([]models.Product{createTestProduct(1, "Tea"), inactive}, nil).Once()
+
+			_, err := suite.svc.AddTemplate(context.Background(), newTemplate)
+
+			assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+		})
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateTemplate", mock.Anything, mock.Anything)
+}
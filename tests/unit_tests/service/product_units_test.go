@@ -0,0 +1,94 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// createTestBoxedProduct создает продукт, поставляемый коробками по 24 штуки и паллетами по 60 коробок.
+func createTestBoxedProduct(id int64, name string) models.Product {
+	product := createTestProduct(id, name)
+	product.VATRate = models.VATRate20
+	product.Unit = models.UnitPiece
+	product.Packaging = []models.Packaging{{Name: "box", Quantity: 24}, {Name: "pallet", Quantity: 1440}}
+	return product
+}
+
+func (suite *ServiceTestSuite) TestAddTemplate_NormalizesPackagingToBaseUnits() {
+	newTemplate := &models.Template{
+		TemplateName: "Snacks",
+		Content: []models.TemplateContent{
+			{ProductID: 1, Quantity: 2, Unit: "box"},
+			{ProductID: 2, Quantity: 3},
+		},
+	}
+
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestBoxedProduct(1, "Water"), createTestProduct(2, "Chips")}, nil).Once()
+	suite.mockRepo.On("CreateTemplate", mock.Anything, mock.MatchedBy(func(t *models.Template) bool {
+		return t.Content[0] == models.TemplateContent{ProductID: 1, Quantity: 48} &&
+			t.Content[1] == models.TemplateContent{ProductID: 2, Quantity: 3}
+	})).Return(nil).Once()
+
+	_, err := suite.svc.AddTemplate(context.Background(), newTemplate)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestAddTemplate_UnknownPackaging() {
+	newTemplate := &models.Template{
+		TemplateName: "Snacks",
+		Content:      []models.TemplateContent{{ProductID: 1, Quantity: 1, Unit: "crate"}},
+	}
+
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1}).
+		Return([]models.Product{createTestBoxedProduct(1, "Water")}, nil).Once()
+
+	_, err := suite.svc.AddTemplate(context.Background(), newTemplate)
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateTemplate", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_DefaultsUnitToPiece() {
+	product := createTestProduct(0, "Water")
+	product.VATRate = models.VATRate20
+
+	suite.mockRepo.On("CreateProduct", mock.Anything, mock.MatchedBy(func(p *models.Product) bool {
+		return p.Unit == models.UnitPiece
+	})).Return(int64(1), nil).Once()
+
+	_, err := suite.svc.CreateProduct(context.Background(), &product)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_InvalidPackaging() {
+	tests := map[string][]models.Packaging{
+		"Пустое название":        {{Name: "", Quantity: 24}},
+		"Совпадает с базовой":    {{Name: models.UnitPiece, Quantity: 1}},
+		"Повторяющаяся упаковка": {{Name: "box", Quantity: 24}, {Name: "box", Quantity: 12}},
+		"Неположительное кол-во": {{Name: "box", Quantity: 0}},
+		"Два уровня округления":  {{Name: "box", Quantity: 24, RoundScaled: true}, {Name: "pallet", Quantity: 1440, RoundScaled: true}},
+	}
+	for name, packaging := range tests {
+		suite.Run(name, func() {
+			product := createTestBoxedProduct(0, "Water")
+			product.Packaging = packaging
+
+			_, err := suite.svc.CreateProduct(context.Background(), &product)
+
+			assert.Error(suite.T(), err)
+			assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+		})
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,77 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// createTestVariant создает вариант родительского продукта с заданными значениями опций.
+func createTestVariant(id, parentID int64, name string, options map[string]string) models.Product {
+	variant := createTestProduct(id, name)
+	variant.VATRate = models.VATRate20
+	variant.ParentID = parentID
+	variant.Options = options
+	return variant
+}
+
+func (suite *ServiceTestSuite) TestGetAllProducts_NestsVariants() {
+	products := []models.Product{
+		createTestProduct(1, "Tea"),
+		createTestVariant(2, 1, "Black tea", map[string]string{"flavor": "black"}),
+		createTestProduct(3, "Cookies"),
+		createTestVariant(4, 1, "Green tea", map[string]string{"flavor": "green"}),
+	}
+	suite.mockRepo.On("GetAllProducts", mock.Anything).Return(products, nil).Once()
+
+	result, err := suite.svc.GetAllProducts(context.Background(), models.ProductQuery{})
+
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), result, 2, "Expected only top-level products") {
+		assert.Equal(suite.T(), int64(1), result[0].ID)
+		assert.Len(suite.T(), result[0].Variants, 2)
+		assert.Equal(suite.T(), "green", result[0].Variants[1].Options["flavor"])
+		assert.Empty(suite.T(), result[1].Variants)
+	}
+}
+
+func (suite *ServiceTestSuite) TestGetProductByID_ParentWithVariants() {
+	parent := createTestProduct(1, "Water")
+	variants := []models.Product{
+		createTestVariant(2, 1, "Still water 0.5 L", map[string]string{"type": "still", "volume": "0.5 L"}),
+		createTestVariant(3, 1, "Sparkling water 1 L", map[string]string{"type": "sparkling", "volume": "1 L"}),
+	}
+	suite.mockRepo.On("GetProductByID", mock.Anything, int64(1)).Return(parent, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1}).Return(variants, nil).Once()
+
+	product, err := suite.svc.GetProductByID(context.Background(), 1, models.ProductQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), product.ID)
+	assert.Len(suite.T(), product.Variants, 2)
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_VariantOfVariant() {
+	parent := createTestVariant(2, 1, "Black tea", map[string]string{"flavor": "black"})
+	variant := createTestVariant(0, 2, "Black tea 100 g", map[string]string{"weight": "100 g"})
+
+	suite.mockRepo.On("GetProductByID", mock.Anything, int64(2)).Return(parent, nil).Once()
+
+	_, err := suite.svc.CreateProduct(context.Background(), &variant)
+
+	assert.Error(suite.T(), err, "Expected error when parent is itself a variant")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestCreateProduct_VariantWithoutOptions() {
+	variant := createTestVariant(0, 1, "Tea", nil)
+
+	_, err := suite.svc.CreateProduct(context.Background(), &variant)
+
+	assert.Error(suite.T(), err, "Expected error when variant has no option values")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+}
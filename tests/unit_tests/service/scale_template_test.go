@@ -0,0 +1,77 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода ScaleTemplate.
+//   - Шаблон на 60 пассажиров пересчитывается на 300: поштучный продукт округляется до штук, продукт с RoundScaled — до коробок.
+func (suite *ServiceTestSuite) TestScaleTemplate_ByPassengers() {
+	template := createTestTemplate(1, "Breakfast")
+	template.Passengers = 60
+	template.Content = []models.TemplateContent{{ProductID: 1, Quantity: 7}, {ProductID: 2, Quantity: 30}}
+	water := createTestProduct(2, "Water")
+	water.Packaging = []models.Packaging{{Name: "box", Quantity: 24, RoundScaled: true}}
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(template, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), water}, nil).Once()
+
+	scaled, err := suite.svc.ScaleTemplate(context.Background(), 1, models.TemplateScale{Passengers: 300}, models.TemplateQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 300, scaled.Passengers)
+	assert.Equal(suite.T(), []models.TemplateContent{{ProductID: 1, Quantity: 35}, {ProductID: 2, Quantity: 168}}, scaled.Content)
+}
+
+func (suite *ServiceTestSuite) TestScaleTemplate_ByFactor() {
+	template := createTestTemplate(1, "Breakfast")
+	template.Passengers = 60
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(template, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee")}, nil).Once()
+
+	scaled, err := suite.svc.ScaleTemplate(context.Background(), 1, models.TemplateScale{Factor: 1.5}, models.TemplateQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 90, scaled.Passengers)
+	assert.Equal(suite.T(), 3, scaled.Content[0].Quantity)
+	assert.Equal(suite.T(), 5, scaled.Content[1].Quantity, "Expected 4.5 to be rounded up")
+}
+
+// Техника тест-дизайна: Таблица решений
+// Описание:
+//   - Тест для проверки параметров ScaleTemplate: нужно ровно одно из passengers и factor,
+//     а по числу пассажиров масштабируется только шаблон с базовым числом пассажиров.
+func (suite *ServiceTestSuite) TestScaleTemplate_InvalidScale() {
+	tests := map[string]struct {
+		base  int
+		scale models.TemplateScale
+	}{
+		"Ничего не задано":        {base: 60, scale: models.TemplateScale{}},
+		"Задано и то и другое":    {base: 60, scale: models.TemplateScale{Passengers: 300, Factor: 2}},
+		"Отрицательное число":     {base: 60, scale: models.TemplateScale{Passengers: -1}},
+		"Отрицательный множитель": {base: 60, scale: models.TemplateScale{Factor: -2}},
+		"Нет базового числа":      {base: 0, scale: models.TemplateScale{Passengers: 300}},
+	}
+	for name, tc := range tests {
+		suite.Run(name, func() {
+			template := createTestTemplate(1, "Breakfast")
+			template.Passengers = tc.base
+			suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(template, nil).Once()
+
+			_, err := suite.svc.ScaleTemplate(context.Background(), 1, tc.scale, models.TemplateQuery{})
+
+			assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+		})
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetProductsByIDs", mock.Anything, mock.Anything)
+}
@@ -23,7 +23,7 @@ func (suite *ServiceTestSuite) TestSearchTemplates_WithQuery_Success() {
 		Return(expectedTemplates, nil).
 		Once()
 
-	templates, err := suite.svc.SearchTemplates(context.Background(), searchString, limit, offset)
+	templates, err := suite.svc.SearchTemplates(context.Background(), searchString, limit, offset, models.TemplateQuery{})
 
 	assert.NoError(suite.T(), err, "Expected no error when searching Templates with query")
 	assert.Equal(suite.T(), expectedTemplates, templates, "Expected templates to match the mocked templates")
@@ -42,7 +42,7 @@ func (suite *ServiceTestSuite) TestSearchTemplates_EmptyQuery_Success() {
 		Return(expectedTemplates, nil).
 		Once()
 
-	templates, err := suite.svc.SearchTemplates(context.Background(), searchString, limit, offset)
+	templates, err := suite.svc.SearchTemplates(context.Background(), searchString, limit, offset, models.TemplateQuery{})
 
 	assert.NoError(suite.T(), err, "Expected no error when searching Templates with empty query")
 	assert.Equal(suite.T(), expectedTemplates, templates, "Expected templates to match the mocked templates")
@@ -58,7 +58,7 @@ func (suite *ServiceTestSuite) TestSearchTemplates_WithQuery_RepositoryError() {
 		Return(nil, expectedError).
 		Once()
 
-	templates, err := suite.svc.SearchTemplates(context.Background(), searchString, limit, offset)
+	templates, err := suite.svc.SearchTemplates(context.Background(), searchString, limit, offset, models.TemplateQuery{})
 
 	assert.Error(suite.T(), err, "Expected error when repository returns an error during search")
 	assert.True(suite.T(), myerr.IsInternal(err), "Expected error to be of type Internal")
@@ -76,10 +76,44 @@ func (suite *ServiceTestSuite) TestSearchTemplates_EmptyQuery_RepositoryError()
 		Return(nil, expectedError).
 		Once()
 
-	templates, err := suite.svc.SearchTemplates(context.Background(), searchString, limit, offset)
+	templates, err := suite.svc.SearchTemplates(context.Background(), searchString, limit, offset, models.TemplateQuery{})
 
 	assert.Error(suite.T(), err, "Expected error when repository returns an error during GetAllTemplates")
 	assert.True(suite.T(), myerr.IsInternal(err), "Expected error to be of type Internal")
 	assert.Equal(suite.T(), expectedError, err, "Expected error to match the mocked error")
 	assert.Nil(suite.T(), templates, "Expected templates to be nil on error")
 }
+
+func (suite *ServiceTestSuite) TestSearchTemplates_WithContent() {
+	first := createTestTemplate(1, "Template 1")
+	first.Content = nil
+	second := createTestTemplate(2, "Template 2")
+	second.Content = nil
+	contents := map[int64][]models.TemplateContent{
+		1: {{ProductID: 10, Quantity: 2}, {ProductID: 11, Quantity: 1}},
+	}
+
+	suite.mockRepo.On("GetAllTemplates", mock.Anything, int64(5), int64(0)).
+		Return([]models.Template{first, second}, nil).Once()
+	suite.mockRepo.On("GetTemplateContents", mock.Anything, []int64{1, 2}).Return(contents, nil).Once()
+
+	templates, err := suite.svc.SearchTemplates(context.Background(), "", 5, 0, models.TemplateQuery{WithContent: true})
+
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), templates, 2) {
+		assert.Equal(suite.T(), contents[1], templates[0].Content)
+		assert.Empty(suite.T(), templates[1].Content, "Expected template without lines to stay empty")
+	}
+	suite.mockRepo.AssertNumberOfCalls(suite.T(), "GetTemplateContents", 1)
+}
+
+func (suite *ServiceTestSuite) TestSearchTemplates_WithContentEmptyPage() {
+	suite.mockRepo.On("SearchTemplates", mock.Anything, "missing", int64(5), int64(0)).
+		Return([]models.Template{}, nil).Once()
+
+	templates, err := suite.svc.SearchTemplates(context.Background(), "missing", 5, 0, models.TemplateQuery{WithContent: true})
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), templates)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetTemplateContents", mock.Anything, mock.Anything)
+}
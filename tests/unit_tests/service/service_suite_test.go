@@ -12,22 +12,25 @@ import (
 
 type ServiceTestSuite struct {
 	suite.Suite
-	mockRepo *mocks.MockGoodsRepository
-	svc      service.Service // Ваш сервисный слой
+	mockRepo   *mocks.MockGoodsRepository
+	mockImages *mocks.MockBlobStore
+	svc        service.Service // Ваш сервисный слой
 }
 
 func (suite *ServiceTestSuite) SetupTest() {
 	// Инициализация мока
 	suite.mockRepo = mocks.NewMockGoodsRepository(suite.T())
+	suite.mockImages = mocks.NewMockBlobStore(suite.T())
 	// Инициализация логгера (можно использовать заглушку или реальный)
 	logger := log.NewNopLogger()
 	// Инициализация сервисного слоя с мок-репозиторием
-	suite.svc = service.NewService(suite.mockRepo, logger)
+	suite.svc = service.NewService(suite.mockRepo, suite.mockImages, logger)
 }
 
 func (suite *ServiceTestSuite) TearDownTest() {
 	// Проверка, что все ожидания моков были выполнены
 	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockImages.AssertExpectations(suite.T())
 }
 
 func TestServiceTestSuite(t *testing.T) {
@@ -0,0 +1,80 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// templateContentProducts возвращает строки шаблона с подставленными продуктами, как их читает репозиторий.
+func templateContentProducts(products ...models.Product) []models.TemplateContent {
+	content := make([]models.TemplateContent, len(products))
+	for i := range products {
+		content[i] = models.TemplateContent{ProductID: products[i].ID, Quantity: i + 2, Product: &products[i]}
+	}
+	return content
+}
+
+func (suite *ServiceTestSuite) TestGetTemplateByID_ExpandProducts() {
+	template := createTestTemplate(1, "Завтрак")
+	tea := createTestProduct(1, "Чай")
+	tea.Translations = map[string]models.Translation{"en": {Name: "Tea"}}
+	coffee := createTestProduct(2, "Кофе")
+	priceList := models.PriceList{ID: 5, Name: "belarus", Currency: "BYN"}
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(template, nil).Once()
+	suite.mockRepo.On("GetTemplateContentProducts", mock.Anything, []int64{1}).
+		Return(map[int64][]models.TemplateContent{1: templateContentProducts(tea, coffee)}, nil).Once()
+	suite.mockRepo.On("GetPriceListByName", mock.Anything, "belarus").Return(priceList, nil).Once()
+	suite.mockRepo.On("GetPriceListPrices", mock.Anything, int64(5), []int64{1, 2}).
+		Return(map[int64]models.Money{1: 300, 2: 500}, nil).Once()
+
+	result, err := suite.svc.GetTemplateByID(context.Background(), 1, models.TemplateQuery{
+		Locales:        []string{"en"},
+		ExpandProducts: true,
+		PriceList:      "belarus",
+	})
+
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), result.Content, 2) {
+		assert.Equal(suite.T(), "Tea", result.Content[0].Product.Name, "Expected expanded products to be localized")
+		assert.Equal(suite.T(), models.Money(500), result.Content[1].Product.Price)
+		assert.Equal(suite.T(), "BYN", result.Content[1].Product.Currency)
+	}
+	if assert.NotNil(suite.T(), result.Total) {
+		assert.Equal(suite.T(), 5, result.Total.Items)
+		assert.Equal(suite.T(), models.Money(2*300+3*500), result.Total.Total)
+		assert.Equal(suite.T(), "BYN", result.Total.Currency)
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetProductByID", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGetTemplateByID_ExpandProductsWithoutPrice() {
+	template := createTestTemplate(1, "Завтрак")
+	priceList := models.PriceList{ID: 5, Name: "belarus", Currency: "BYN"}
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(template, nil).Once()
+	suite.mockRepo.On("GetTemplateContentProducts", mock.Anything, []int64{1}).
+		Return(map[int64][]models.TemplateContent{1: templateContentProducts(createTestProduct(1, "Чай"))}, nil).Once()
+	suite.mockRepo.On("GetPriceListByName", mock.Anything, "belarus").Return(priceList, nil).Once()
+	suite.mockRepo.On("GetPriceListPrices", mock.Anything, int64(5), []int64{1}).
+		Return(map[int64]models.Money{}, nil).Once()
+
+	_, err := suite.svc.GetTemplateByID(context.Background(), 1, models.TemplateQuery{ExpandProducts: true, PriceList: "belarus"})
+
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+}
+
+func (suite *ServiceTestSuite) TestGetTemplateByID_WithoutExpand() {
+	template := createTestTemplate(1, "Завтрак")
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(1)).Return(template, nil).Once()
+
+	result, err := suite.svc.GetTemplateByID(context.Background(), 1, models.TemplateQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.Total)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetTemplateContentProducts", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,118 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestAddTemplateLine_Success() {
+	coffee := createTestProduct(2, "Coffee")
+	coffee.Unit = models.UnitPiece
+	coffee.Packaging = []models.Packaging{{Name: "box", Quantity: 10}}
+
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{2}).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{2}).Return([]models.Product{coffee}, nil).Once()
+	suite.mockRepo.On("AddTemplateContent", mock.Anything, int64(7), models.TemplateContent{ProductID: 2, Quantity: 20}, int64(3)).
+		Return(int64(4), nil).Once()
+
+	version, err := suite.svc.AddTemplateLine(context.Background(), 7, models.TemplateContent{ProductID: 2, Quantity: 2, Unit: "box"}, 3)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(4), version)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для метода AddTemplateLine.
+//   - Все проблемы строки собираются в одну ошибку Validation с полями запроса, как и для шаблона целиком.
+func (suite *ServiceTestSuite) TestAddTemplateLine_InvalidLine() {
+	draft := createTestProduct(2, "Coffee")
+	draft.Status = models.ProductStatusDraft
+
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{2}).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{2}).Return([]models.Product{draft}, nil).Once()
+
+	_, err := suite.svc.AddTemplateLine(context.Background(), 7, models.TemplateContent{ProductID: 2, Quantity: 0}, 0)
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	var fields []string
+	for _, f := range myerr.Fields(err) {
+		fields = append(fields, f.Field)
+		assert.Nil(suite.T(), f.Index)
+	}
+	assert.Equal(suite.T(), []string{"quantity", "productID"}, fields)
+	suite.mockRepo.AssertNotCalled(suite.T(), "AddTemplateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestAddTemplateLine_InactiveProduct() {
+	draft := createTestProduct(2, "Coffee")
+	draft.Status = models.ProductStatusDraft
+
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{2}).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{2}).Return([]models.Product{draft}, nil).Once()
+
+	_, err := suite.svc.AddTemplateLine(context.Background(), 7, models.TemplateContent{ProductID: 2, Quantity: 1}, 0)
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "AddTemplateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestAddTemplateLine_Duplicate() {
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1}).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1}).Return([]models.Product{createTestProduct(1, "Tea")}, nil).Once()
+	suite.mockRepo.On("AddTemplateContent", mock.Anything, int64(7), models.TemplateContent{ProductID: 1, Quantity: 1}, int64(0)).
+		Return(int64(0), myerr.Conflict("Product with ID 1 already exists in template", nil)).Once()
+
+	_, err := suite.svc.AddTemplateLine(context.Background(), 7, models.TemplateContent{ProductID: 1, Quantity: 1}, 0)
+
+	assert.True(suite.T(), myerr.IsConflict(err), "Expected error to be of type Conflict")
+}
+
+func (suite *ServiceTestSuite) TestUpdateTemplateLine_DiscontinuedProduct() {
+	// Снятый с продажи продукт остается в шаблоне, и его количество можно менять
+	tea := createTestProduct(1, "Tea")
+	tea.Status = models.ProductStatusDiscontinued
+
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1}).Return([]models.Product{tea}, nil).Once()
+	suite.mockRepo.On("UpdateTemplateContent", mock.Anything, int64(7), models.TemplateContent{ProductID: 1, Quantity: 3}, int64(4)).
+		Return(int64(5), nil).Once()
+
+	version, err := suite.svc.UpdateTemplateLine(context.Background(), 7, models.TemplateContent{ProductID: 1, Quantity: 3}, 4)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(5), version)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetProductVariants", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestUpdateTemplateLine_NotInTemplate() {
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{3}).Return([]models.Product{createTestProduct(3, "Juice")}, nil).Once()
+	suite.mockRepo.On("UpdateTemplateContent", mock.Anything, int64(7), models.TemplateContent{ProductID: 3, Quantity: 1}, int64(0)).
+		Return(int64(0), myerr.NotFound("Product with ID 3 is not in template with ID 7", nil)).Once()
+
+	_, err := suite.svc.UpdateTemplateLine(context.Background(), 7, models.TemplateContent{ProductID: 3, Quantity: 1}, 0)
+
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+}
+
+func (suite *ServiceTestSuite) TestRemoveTemplateLine() {
+	suite.mockRepo.On("DeleteTemplateContent", mock.Anything, int64(7), int64(1), int64(5)).Return(int64(6), nil).Once()
+	suite.mockRepo.On("DeleteTemplateContent", mock.Anything, int64(7), int64(3), int64(0)).
+		Return(int64(0), myerr.NotFound("Product with ID 3 is not in template with ID 7", nil)).Once()
+	suite.mockRepo.On("DeleteTemplateContent", mock.Anything, int64(7), int64(2), int64(5)).
+		Return(int64(0), myerr.PreconditionFailed("Template with ID 7 was modified concurrently: current version is 6, expected 5", nil)).Once()
+
+	version, err := suite.svc.RemoveTemplateLine(context.Background(), 7, 1, 5)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(6), version)
+
+	_, err = suite.svc.RemoveTemplateLine(context.Background(), 7, 3, 0)
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+
+	_, err = suite.svc.RemoveTemplateLine(context.Background(), 7, 2, 5)
+	assert.True(suite.T(), myerr.IsPreconditionFailed(err), "Expected error to be of type PreconditionFailed")
+}
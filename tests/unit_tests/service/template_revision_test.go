@@ -0,0 +1,114 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода ListTemplateRevisions.
+//   - Классы эквивалентности: у шаблона есть ревизии, у существующего шаблона ревизий нет, шаблона нет.
+func (suite *ServiceTestSuite) TestListTemplateRevisions() {
+	revisions := []models.TemplateRevision{{TemplateID: 7, Revision: 2, Author: "ivanov"}, {TemplateID: 7, Revision: 1}}
+	suite.mockRepo.On("ListTemplateRevisions", mock.Anything, int64(7)).Return(revisions, nil).Once()
+	suite.mockRepo.On("ListTemplateRevisions", mock.Anything, int64(8)).Return([]models.TemplateRevision(nil), nil).Once()
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(8)).Return(createTestTemplate(8, "Lunch"), nil).Once()
+	suite.mockRepo.On("ListTemplateRevisions", mock.Anything, int64(9)).Return([]models.TemplateRevision(nil), nil).Once()
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(9)).
+		Return(models.Template{}, myerr.NotFound("Template with ID 9 not found", nil)).Once()
+
+	result, err := suite.svc.ListTemplateRevisions(context.Background(), 7)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), revisions, result)
+
+	result, err = suite.svc.ListTemplateRevisions(context.Background(), 8)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result)
+
+	_, err = suite.svc.ListTemplateRevisions(context.Background(), 9)
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода RestoreTemplateRevision.
+//   - Восстановление записывает снимок ревизии в текущий шаблон с ожидаемой версией; снятые с продажи продукты ревизии остаются.
+//   - Классы эквивалентности: успешное восстановление, ревизия не найдена, версия шаблона устарела.
+func (suite *ServiceTestSuite) TestRestoreTemplateRevision() {
+	revision := models.TemplateRevision{
+		TemplateID: 7,
+		Revision:   2,
+		Template: models.Template{
+			ID:           7,
+			TemplateName: "Breakfast",
+			Description:  "Morning set",
+			Passengers:   60,
+			Version:      2,
+			Content:      []models.TemplateContent{{ProductID: 1, Quantity: 5}},
+		},
+	}
+	tea := createTestProduct(1, "Tea")
+	tea.Status = models.ProductStatusDiscontinued
+	suite.mockRepo.On("GetTemplateRevision", mock.Anything, int64(7), int64(2)).Return(revision, nil).Twice()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1}).Return(nil, nil).Twice()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1}).Return([]models.Product{tea}, nil).Twice()
+	suite.mockRepo.On("UpdateTemplate", mock.Anything, mock.MatchedBy(func(t *models.Template) bool {
+		return t.ID == 7 && t.Version == 5 && t.TemplateName == "Breakfast" && t.Passengers == 60 && len(t.Content) == 1
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Template).Version = 6
+	}).Return(nil).Once()
+	suite.mockRepo.On("UpdateTemplate", mock.Anything, mock.MatchedBy(func(t *models.Template) bool { return t.Version == 4 })).
+		Return(myerr.PreconditionFailed("Template with ID 7 was modified concurrently", nil)).Once()
+	suite.mockRepo.On("GetTemplateRevision", mock.Anything, int64(7), int64(9)).
+		Return(models.TemplateRevision{}, myerr.NotFound("Revision 9 of template with ID 7 not found", nil)).Once()
+
+	version, err := suite.svc.RestoreTemplateRevision(context.Background(), 7, 2, 5)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(6), version)
+
+	_, err = suite.svc.RestoreTemplateRevision(context.Background(), 7, 2, 4)
+	assert.True(suite.T(), myerr.IsPreconditionFailed(err), "Expected error to be of type PreconditionFailed")
+
+	_, err = suite.svc.RestoreTemplateRevision(context.Background(), 7, 9, 5)
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для метода RestoreTemplateRevision.
+//   - Снимок с продуктом, у которого с тех пор появились варианты, и с повторяющейся строкой отклоняется
+//     ошибкой Validation до записи.
+func (suite *ServiceTestSuite) TestRestoreTemplateRevision_InvalidSnapshot() {
+	revision := models.TemplateRevision{
+		TemplateID: 7,
+		Revision:   2,
+		Template: models.Template{
+			ID:           7,
+			TemplateName: "Breakfast",
+			Content:      []models.TemplateContent{{ProductID: 1, Quantity: 5}, {ProductID: 2, Quantity: 1}, {ProductID: 2, Quantity: 2}},
+		},
+	}
+	variant := createTestProduct(3, "Tea Green")
+	variant.ParentID = 1
+	suite.mockRepo.On("GetTemplateRevision", mock.Anything, int64(7), int64(2)).Return(revision, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1, 2, 2}).Return([]models.Product{variant}, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee")}, nil).Once()
+
+	_, err := suite.svc.RestoreTemplateRevision(context.Background(), 7, 2, 5)
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	var fields []string
+	for _, f := range myerr.Fields(err) {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(suite.T(), []string{"content[0].productID", "content[2].productID"}, fields)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateTemplate", mock.Anything, mock.Anything)
+}
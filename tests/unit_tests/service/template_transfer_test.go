@@ -0,0 +1,169 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода ExportTemplates.
+//   - Без ID экспортируются все шаблоны с составом, продукты заменяются артикулами.
+func (suite *ServiceTestSuite) TestExportTemplates() {
+	breakfast := createTestTemplate(1, "Breakfast")
+	breakfast.Content = nil
+	breakfast.Translations = map[string]models.Translation{"en": {Name: "Breakfast"}}
+	lunch := createTestTemplate(2, "Lunch")
+	lunch.Content = nil
+	suite.mockRepo.On("ListTemplates", mock.Anything).Return([]models.Template{breakfast, lunch}, nil).Once()
+	suite.mockRepo.On("GetTemplateContents", mock.Anything, []int64{1, 2}).Return(map[int64][]models.TemplateContent{
+		1: {{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 3}},
+	}, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee")}, nil).Once()
+
+	templates, err := suite.svc.ExportTemplates(context.Background(), nil)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []models.PortableTemplate{
+		{
+			TemplateName: "Breakfast",
+			Description:  "Description for Breakfast",
+			Translations: map[string]models.Translation{"en": {Name: "Breakfast"}},
+			Content:      []models.PortableTemplateLine{{SKU: "SKU1", Quantity: 2}, {SKU: "SKU2", Quantity: 3}},
+		},
+		{TemplateName: "Lunch", Description: "Description for Lunch", Content: []models.PortableTemplateLine{}},
+	}, templates)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода ImportTemplates.
+//   - Артикулы разрешаются в ID продуктов, а проверенные шаблоны записываются одним вызовом репозитория.
+func (suite *ServiceTestSuite) TestImportTemplates() {
+	portable := []models.PortableTemplate{
+		{TemplateName: " Breakfast ", Content: []models.PortableTemplateLine{{SKU: "SKU1", Quantity: 2}, {SKU: "SKU2", Quantity: 3}}},
+		{TemplateName: "Lunch", Content: []models.PortableTemplateLine{{SKU: "SKU2", Quantity: 1}}},
+	}
+	results := []models.TemplateImportResult{
+		{TemplateID: 5, TemplateName: "Breakfast", Version: 4},
+		{TemplateID: 9, TemplateName: "Lunch", Version: 1, Created: true},
+	}
+
+	suite.mockRepo.On("GetProductsBySKUs", mock.Anything, []string{"SKU1", "SKU2", "SKU2"}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee")}, nil).Once()
+	suite.mockRepo.On("ListTemplates", mock.Anything).Return([]models.Template{createTestTemplate(5, "Breakfast"), createTestTemplate(6, "Dinner")}, nil).Once()
+	suite.mockRepo.On("GetTemplateContents", mock.Anything, []int64{5}).
+		Return(map[int64][]models.TemplateContent{5: {{ProductID: 1, Quantity: 1}}}, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee")}, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{2}).
+		Return([]models.Product{createTestProduct(2, "Coffee")}, nil).Once()
+	suite.mockRepo.On("ImportTemplates", mock.Anything, []models.Template{
+		{TemplateName: "Breakfast", Content: []models.TemplateContent{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 3}}},
+		{TemplateName: "Lunch", Content: []models.TemplateContent{{ProductID: 2, Quantity: 1}}},
+	}).Return(results, nil).Once()
+
+	imported, err := suite.svc.ImportTemplates(context.Background(), portable)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), results, imported)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для метода ImportTemplates.
+//   - Ненайденные артикулы и повторяющиеся названия возвращаются одной ошибкой, ничего не записывается.
+func (suite *ServiceTestSuite) TestImportTemplates_MissingSKUs() {
+	portable := []models.PortableTemplate{
+		{TemplateName: "Breakfast", Content: []models.PortableTemplateLine{{SKU: "SKU1", Quantity: 2}, {SKU: "GONE", Quantity: 3}}},
+		{TemplateName: "Breakfast", Content: []models.PortableTemplateLine{{SKU: "LOST", Quantity: 1}}},
+	}
+	suite.mockRepo.On("GetProductsBySKUs", mock.Anything, []string{"SKU1", "GONE", "LOST"}).
+		Return([]models.Product{createTestProduct(1, "Tea")}, nil).Once()
+
+	_, err := suite.svc.ImportTemplates(context.Background(), portable)
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	fields := myerr.Fields(err)
+	if assert.Len(suite.T(), fields, 3) {
+		assert.Equal(suite.T(), "templates[0].content[1].sku", fields[0].Field)
+		assert.Equal(suite.T(), 1, *fields[0].Index)
+		assert.Equal(suite.T(), "templates[1].templateName", fields[1].Field)
+		assert.Equal(suite.T(), "templates[1].content[0].sku", fields[2].Field)
+		assert.Equal(suite.T(), 0, *fields[2].Index)
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "ImportTemplates", mock.Anything, mock.Anything)
+}
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для метода ImportTemplates.
+//   - Ошибки проверки всех шаблонов собираются вместе с путем к шаблону, ничего не записывается.
+func (suite *ServiceTestSuite) TestImportTemplates_InvalidTemplates() {
+	draft := createTestProduct(2, "Coffee")
+	draft.Status = models.ProductStatusDraft
+	portable := []models.PortableTemplate{
+		{TemplateName: "Breakfast", Content: []models.PortableTemplateLine{{SKU: "SKU1", Quantity: 0}}},
+		{TemplateName: "Lunch", Translations: map[string]models.Translation{"EN-us": {Name: "Lunch"}}},
+		{TemplateName: "Dinner", Content: []models.PortableTemplateLine{{SKU: "SKU2", Quantity: 1}}},
+	}
+	suite.mockRepo.On("GetProductsBySKUs", mock.Anything, []string{"SKU1", "SKU2"}).
+		Return([]models.Product{createTestProduct(1, "Tea"), draft}, nil).Once()
+	suite.mockRepo.On("ListTemplates", mock.Anything).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1}).Return([]models.Product{createTestProduct(1, "Tea")}, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{2}).Return([]models.Product{draft}, nil).Once()
+
+	_, err := suite.svc.ImportTemplates(context.Background(), portable)
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	var fields []string
+	for _, f := range myerr.Fields(err) {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(suite.T(), []string{"templates[0].content[0].quantity", "templates[1].translations.EN-us", "templates[2].content[0].productID"}, fields)
+	suite.mockRepo.AssertNotCalled(suite.T(), "ImportTemplates", mock.Anything, mock.Anything)
+}
+
+// Техника тест-дизайна: Причинно-следственный анализ
+// Описание:
+//   - Тест экспорта и обратного импорта шаблона.
+//   - Причина: в шаблоне есть снятый с продажи продукт; следствие: экспорт переносит строку, а импорт в ту же базу
+//     заменяет шаблон с тем же составом без ошибки проверки.
+func (suite *ServiceTestSuite) TestExportImportTemplates_RoundTripWithDiscontinuedProduct() {
+	discontinued := createTestProduct(1, "Tea")
+	discontinued.Status = models.ProductStatusDiscontinued
+	products := []models.Product{discontinued, createTestProduct(2, "Coffee")}
+	breakfast := createTestTemplate(5, "Breakfast")
+	breakfast.Content = []models.TemplateContent{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 3}}
+	results := []models.TemplateImportResult{{TemplateID: 5, TemplateName: "Breakfast", Version: 2}}
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(5)).Return(breakfast, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).Return(products, nil).Twice()
+	suite.mockRepo.On("GetProductsBySKUs", mock.Anything, []string{"SKU1", "SKU2"}).Return(products, nil).Once()
+	suite.mockRepo.On("ListTemplates", mock.Anything).Return([]models.Template{createTestTemplate(5, "Breakfast")}, nil).Once()
+	suite.mockRepo.On("GetTemplateContents", mock.Anything, []int64{5}).
+		Return(map[int64][]models.TemplateContent{5: breakfast.Content}, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1, 2}).Return(nil, nil).Once()
+	suite.mockRepo.On("ImportTemplates", mock.Anything, mock.MatchedBy(func(templates []models.Template) bool {
+		return len(templates) == 1 && templates[0].TemplateName == "Breakfast" &&
+			assert.ObjectsAreEqual(breakfast.Content, templates[0].Content)
+	})).Return(results, nil).Once()
+
+	exported, err := suite.svc.ExportTemplates(context.Background(), []int64{5})
+	assert.NoError(suite.T(), err)
+
+	imported, err := suite.svc.ImportTemplates(context.Background(), exported)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), results, imported)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
@@ -0,0 +1,68 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Техника тест-дизайна: Прогнозирование ошибок
+// Описание:
+//   - Тест для проверки шаблона перед записью.
+//   - Все проблемы (пустое название, некорректные переводы, неположительное количество, повтор продукта,
+//     отсутствующий и неактивный продукт, продукт с вариантами, неизвестная упаковка) возвращаются одной ошибкой
+//     с путями вида content[i].поле и индексами строк.
+func (suite *ServiceTestSuite) TestAddTemplate_ReportsAllProblems() {
+	draft := createTestProduct(3, "Juice")
+	draft.Status = models.ProductStatusDraft
+	variant := createTestProduct(7, "Green tea")
+	variant.ParentID = 5
+	newTemplate := &models.Template{
+		TemplateName: "  ",
+		Translations: map[string]models.Translation{"EN": {Name: "Breakfast"}, "de": {}},
+		Content: []models.TemplateContent{
+			{ProductID: 1, Quantity: 2},
+			{ProductID: 2, Quantity: 0},
+			{ProductID: 1, Quantity: 1},
+			{ProductID: 3, Quantity: 1},
+			{ProductID: 4, Quantity: 1},
+			{ProductID: 5, Quantity: 1},
+			{ProductID: 6, Quantity: 1, Unit: "crate"},
+		},
+	}
+
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1, 2, 1, 3, 4, 5, 6}).Return([]models.Product{variant}, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2, 1, 3, 4, 5, 6}).
+		Return([]models.Product{createTestProduct(1, "Tea"), createTestProduct(2, "Coffee"), draft, createTestProduct(5, "Tea"), createTestProduct(6, "Water")}, nil).Once()
+
+	_, err := suite.svc.AddTemplate(context.Background(), newTemplate)
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	type problem struct {
+		field string
+		index int
+	}
+	var problems []problem
+	for _, f := range myerr.Fields(err) {
+		p := problem{field: f.Field, index: -1}
+		if f.Index != nil {
+			p.index = *f.Index
+		}
+		problems = append(problems, p)
+	}
+	assert.Equal(suite.T(), []problem{
+		{"templateName", -1},
+		{"translations.EN", -1},
+		{"translations.de", -1},
+		{"content[1].quantity", 1},
+		{"content[2].productID", 2},
+		{"content[3].productID", 3},
+		{"content[4].productID", 4},
+		{"content[5].productID", 5},
+		{"content[6].unit", 6},
+	}, problems)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateTemplate", mock.Anything, mock.Anything)
+}
@@ -12,9 +12,10 @@ func createTestProduct(id int64, name string) models.Product {
 		ID:          id,
 		Name:        name,
 		Description: "Description for " + name,
-		Price:       99.99,
+		Price:       models.Money(9999),
 		ImageURL:    "http://example.com/" + name + ".png",
 		SKU:         "SKU" + strconv.FormatInt(id, 10),
+		Status:      models.ProductStatusActive,
 	}
 }
 
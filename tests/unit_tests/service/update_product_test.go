@@ -14,11 +14,14 @@ func (suite *ServiceTestSuite) TestUpdateProduct_Success() {
 		ID:          1,
 		Name:        "Updated Product",
 		Description: "Updated Description",
-		Price:       89.99,
+		Price:       models.Money(8999),
+		VATRate:     models.VATRate20,
 		ImageURL:    "http://example.com/updatedimage.png",
 		SKU:         "SKU001U",
 	}
 
+	suite.mockRepo.On("GetProductByID", mock.Anything, int64(1)).Return(createTestProduct(1, "Product"), nil).Once()
+
 	suite.mockRepo.On("UpdateProduct", mock.Anything, updatedProduct).
 		Return(nil).
 		Once()
@@ -33,10 +36,13 @@ func (suite *ServiceTestSuite) TestUpdateProduct_RepositoryError() {
 		ID:          2,
 		Name:        "Updated Product",
 		Description: "Updated Description",
-		Price:       89.99,
+		Price:       models.Money(8999),
+		VATRate:     models.VATRate20,
 		ImageURL:    "http://example.com/updatedimage.png",
 		SKU:         "SKU002U",
 	}
+
+	suite.mockRepo.On("GetProductByID", mock.Anything, int64(2)).Return(createTestProduct(2, "Product"), nil).Once()
 	expectedError := errors.New("database error")
 
 	suite.mockRepo.On("UpdateProduct", mock.Anything, updatedProduct).
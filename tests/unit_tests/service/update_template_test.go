@@ -0,0 +1,141 @@
+package unit_tests
+
+import (
+	"context"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceTestSuite) TestUpdateTemplate_Success() {
+	template := &models.Template{
+		ID:           7,
+		TemplateName: "Breakfast",
+		Description:  "Fixed typo",
+		Version:      2,
+		Content: []models.TemplateContent{
+			{ProductID: 1, Quantity: 2},
+			{ProductID: 2, Quantity: 1, Unit: "box"},
+		},
+	}
+	coffee := createTestProduct(2, "Coffee")
+	coffee.Unit = models.UnitPiece
+	coffee.Packaging = []models.Packaging{{Name: "box", Quantity: 10}}
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(7)).
+		Return(models.Template{ID: 7, Version: 2, Content: []models.TemplateContent{{ProductID: 1, Quantity: 1}}}, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1, 2}).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{createTestProduct(1, "Tea"), coffee}, nil).Once()
+	suite.mockRepo.On("UpdateTemplate", mock.Anything, mock.MatchedBy(func(t *models.Template) bool {
+		return t.ID == 7 && t.Version == 2 && t.Content[1].Quantity == 10
+	})).
+		Run(func(args mock.Arguments) { args.Get(1).(*models.Template).Version = 3 }).
+		Return(nil).Once()
+
+	err := suite.svc.UpdateTemplate(context.Background(), template)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), template.Version, "Expected new version to be returned")
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestUpdateTemplate_InactiveProduct() {
+	template := &models.Template{
+		ID:           7,
+		TemplateName: "Breakfast",
+		Content:      []models.TemplateContent{{ProductID: 1, Quantity: 2}},
+	}
+	draft := createTestProduct(1, "Tea")
+	draft.Status = models.ProductStatusDraft
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(7)).Return(models.Template{ID: 7, Version: 1}, nil).Once()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1}).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1}).Return([]models.Product{draft}, nil).Once()
+
+	err := suite.svc.UpdateTemplate(context.Background(), template)
+
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateTemplate", mock.Anything, mock.Anything)
+}
+
+// Техника тест-дизайна: Классы эквивалентности
+// Описание:
+//   - Тест для метода UpdateTemplate.
+//   - Продукт, снятый с продажи после добавления в шаблон, остается в шаблоне при переименовании,
+//     а тот же статус у нового продукта по-прежнему приводит к ошибке.
+func (suite *ServiceTestSuite) TestUpdateTemplate_KeepsDiscontinuedLine() {
+	discontinued := createTestProduct(1, "Tea")
+	discontinued.Status = models.ProductStatusDiscontinued
+	juice := createTestProduct(3, "Juice")
+	juice.Status = models.ProductStatusDiscontinued
+	stored := models.Template{ID: 7, Version: 4, Content: []models.TemplateContent{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}}}
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(7)).Return(stored, nil).Twice()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 2}).
+		Return([]models.Product{discontinued, createTestProduct(2, "Coffee")}, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1, 3}).
+		Return([]models.Product{discontinued, juice}, nil).Once()
+	suite.mockRepo.On("UpdateTemplate", mock.Anything, mock.MatchedBy(func(t *models.Template) bool {
+		return t.TemplateName == "Morning breakfast" && t.Version == 4
+	})).Return(nil).Once()
+
+	renamed := &models.Template{
+		ID:           7,
+		TemplateName: "Morning breakfast",
+		Content:      []models.TemplateContent{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}},
+	}
+	err := suite.svc.UpdateTemplate(context.Background(), renamed)
+	assert.NoError(suite.T(), err)
+
+	added := &models.Template{
+		ID:           7,
+		TemplateName: "Breakfast",
+		Content:      []models.TemplateContent{{ProductID: 1, Quantity: 2}, {ProductID: 3, Quantity: 1}},
+	}
+	err = suite.svc.UpdateTemplate(context.Background(), added)
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+	fields := myerr.Fields(err)
+	if assert.Len(suite.T(), fields, 1) {
+		assert.Equal(suite.T(), "content[1].productID", fields[0].Field)
+	}
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestUpdateTemplate_NotFound() {
+	template := &models.Template{ID: 99, TemplateName: "Missing"}
+
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(99)).
+		Return(models.Template{}, myerr.NotFound("Template with ID 99 not found", nil)).Once()
+
+	err := suite.svc.UpdateTemplate(context.Background(), template)
+
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateTemplate", mock.Anything, mock.Anything)
+}
+
+// Техника тест-дизайна: Причинно-следственный анализ
+// Описание:
+//   - Тест для метода UpdateTemplate.
+//   - Без ожидаемой версии (If-Match: *) запись проверяет версию шаблона, по составу которого проверялись строки,
+//     поэтому параллельно добавленная строка дает PreconditionFailed, а не проверку по устаревшему составу.
+//   - Ожидаемая версия, не совпадающая с прочитанной, отклоняется до проверки строк.
+func (suite *ServiceTestSuite) TestUpdateTemplate_PinsStoredVersion() {
+	stored := models.Template{ID: 7, Version: 3, Content: []models.TemplateContent{{ProductID: 1, Quantity: 2}}}
+	suite.mockRepo.On("GetTemplateByID", mock.Anything, int64(7)).Return(stored, nil).Twice()
+	suite.mockRepo.On("GetProductVariants", mock.Anything, []int64{1}).Return(nil, nil).Once()
+	suite.mockRepo.On("GetProductsByIDs", mock.Anything, []int64{1}).Return([]models.Product{createTestProduct(1, "Tea")}, nil).Once()
+	suite.mockRepo.On("UpdateTemplate", mock.Anything, mock.MatchedBy(func(t *models.Template) bool { return t.Version == 3 })).
+		Return(myerr.PreconditionFailed("Template with ID 7 was modified concurrently: current version is 4, expected 3", nil)).Once()
+
+	unconditional := &models.Template{ID: 7, TemplateName: "Breakfast", Content: []models.TemplateContent{{ProductID: 1, Quantity: 2}}}
+	err := suite.svc.UpdateTemplate(context.Background(), unconditional)
+	assert.True(suite.T(), myerr.IsPreconditionFailed(err), "Expected error to be of type PreconditionFailed")
+
+	stale := &models.Template{ID: 7, TemplateName: "Breakfast", Version: 2, Content: []models.TemplateContent{{ProductID: 1, Quantity: 2}}}
+	err = suite.svc.UpdateTemplate(context.Background(), stale)
+	assert.True(suite.T(), myerr.IsPreconditionFailed(err), "Expected error to be of type PreconditionFailed")
+	suite.mockRepo.AssertExpectations(suite.T())
+}
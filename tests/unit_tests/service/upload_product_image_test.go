@@ -0,0 +1,95 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"strings"
+
+	"github.com/Chaika-Team/ChaikaGoods/internal/models"
+	"github.com/Chaika-Team/ChaikaGoods/internal/myerr"
+	"github.com/Chaika-Team/ChaikaGoods/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// encodeTestPNG создает PNG-изображение заданного размера.
+func encodeTestPNG(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func (suite *ServiceTestSuite) expectImageURLs() {
+	suite.mockImages.EXPECT().URL(mock.Anything).RunAndReturn(func(key string) string {
+		return "/img/" + key
+	}).Maybe()
+}
+
+func (suite *ServiceTestSuite) TestUploadProductImage_Success() {
+	product := createTestProduct(1, "Tea")
+	product.ImageURL = "/img/product-1-old.png"
+	product.ThumbnailURL = "/img/product-1-old-thumb.png"
+	data := encodeTestPNG(600, 300)
+
+	suite.expectImageURLs()
+	suite.mockRepo.On("GetProductByID", mock.Anything, int64(1)).Return(product, nil).Once()
+
+	var stored []models.Blob
+	suite.mockImages.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ string, blob models.Blob) { stored = append(stored, blob) }).
+		Return(nil).Twice()
+	suite.mockRepo.On("SetProductImage", mock.Anything, int64(1), mock.MatchedBy(func(img models.ProductImage) bool {
+		return strings.HasPrefix(img.ImageURL, "/img/product-1-") && strings.HasSuffix(img.ThumbnailURL, "-thumb.png")
+	})).Return(nil).Once()
+	suite.mockImages.EXPECT().Delete(mock.Anything, "product-1-old.png").Return(nil).Once()
+	suite.mockImages.EXPECT().Delete(mock.Anything, "product-1-old-thumb.png").Return(nil).Once()
+
+	productImage, err := suite.svc.UploadProductImage(context.Background(), 1, models.Blob{ContentType: "image/png", Data: data})
+
+	assert.NoError(suite.T(), err, "Expected no error when uploading image")
+	assert.NotEqual(suite.T(), product.ImageURL, productImage.ImageURL)
+	if assert.Len(suite.T(), stored, 2) {
+		assert.Equal(suite.T(), data, stored[0].Data, "Expected the original image to be stored as is")
+		thumb, err := png.DecodeConfig(bytes.NewReader(stored[1].Data))
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), service.ThumbnailSize, thumb.Width)
+		assert.Equal(suite.T(), service.ThumbnailSize/2, thumb.Height)
+	}
+}
+
+func (suite *ServiceTestSuite) TestUploadProductImage_UnsupportedType() {
+	_, err := suite.svc.UploadProductImage(context.Background(), 1, models.Blob{Data: []byte("just some text")})
+
+	assert.Error(suite.T(), err, "Expected error for non-image data")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+}
+
+func (suite *ServiceTestSuite) TestUploadProductImage_ContentTypeMismatch() {
+	_, err := suite.svc.UploadProductImage(context.Background(), 1, models.Blob{ContentType: "image/jpeg", Data: encodeTestPNG(10, 10)})
+
+	assert.Error(suite.T(), err, "Expected error when declared type differs from data")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+}
+
+func (suite *ServiceTestSuite) TestUploadProductImage_TooLarge() {
+	data := append(encodeTestPNG(10, 10), make([]byte, service.MaxImageSize)...)
+
+	_, err := suite.svc.UploadProductImage(context.Background(), 1, models.Blob{Data: data})
+
+	assert.Error(suite.T(), err, "Expected error for oversized image")
+	assert.True(suite.T(), myerr.IsValidation(err), "Expected error to be of type Validation")
+}
+
+func (suite *ServiceTestSuite) TestUploadProductImage_ProductNotFound() {
+	suite.mockRepo.On("GetProductByID", mock.Anything, int64(2)).
+		Return(models.Product{}, myerr.NotFound("Product with ID 2 not found", nil)).Once()
+
+	_, err := suite.svc.UploadProductImage(context.Background(), 2, models.Blob{Data: encodeTestPNG(10, 10)})
+
+	assert.Error(suite.T(), err, "Expected error when product does not exist")
+	assert.True(suite.T(), myerr.IsNotFound(err), "Expected error to be of type NotFound")
+	suite.mockImages.AssertNotCalled(suite.T(), "Put", mock.Anything, mock.Anything, mock.Anything)
+}